@@ -26,16 +26,50 @@ import (
 	"google.golang.org/grpc"
 	"log"
 	"net"
+	"os"
+	"strconv"
 )
 
+// SplitMergePolicyVariable names the environment variable that can hold a split and merge policy, written as
+// cellmanager.ParseSplitMergePolicy reads it.
+const SplitMergePolicyVariable = "SPLIT_MERGE_POLICY"
+
+// SplitModeVariable names the environment variable that can hold the name of a cellmanager.SplitMode, such as
+// BALANCED_HALVES, used when cells are split on their own.
+const SplitModeVariable = "SPLIT_MODE"
+
+// Started as "cellManager replica <index> [dataDir]" it is the replica at that index of
+// constants.CellManagerAddresses, otherwise it is the only cell manager. Either keeps its state in a data directory.
 func main() {
-	lis, err := net.Listen("tcp", constants.CellManagerPort)
+	cm := cellmanager.NewCellManager()
+	if spec, ok := os.LookupEnv(SplitMergePolicyVariable); ok {
+		policy, err := cellmanager.ParseSplitMergePolicy(spec)
+		if err != nil {
+			log.Fatalf("invalid %v: %v", SplitMergePolicyVariable, err)
+		}
+		cm.SetSplitMergePolicy(policy)
+	}
+	if mode, ok := os.LookupEnv(SplitModeVariable); ok {
+		value, known := generated.SplitMode_value[mode]
+		if !known {
+			log.Fatalf("invalid %v: %v", SplitModeVariable, mode)
+		}
+		cm.SetSplitMode(generated.SplitMode(value))
+	}
+	port := constants.CellManagerPort
+	if len(os.Args) >= 3 && os.Args[1] == "replica" {
+		port = startReplica(&cm, os.Args[2])
+	} else {
+		restoreState(&cm)
+	}
+
+	lis, err := net.Listen("tcp", port)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	cm := cellmanager.NewCellManager()
+	s := grpc.NewServer(grpc.UnaryInterceptor(cm.LeaderOnlyInterceptor))
 	generated.RegisterCellManagerServer(s, &cm)
+	generated.RegisterReplicationServer(s, &cm)
 	//pb.RegisterGreeterServer(s, &pb.GreeterServi)
 
 	go func() {
@@ -50,3 +84,50 @@ func main() {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// startReplica returns the port the replica listens on. Replicas on the same host need data directories of their own,
+// by default the index is appended to the name of the data directory.
+func startReplica(cm *cellmanager.CellManager, index string) string {
+	id, err := strconv.Atoi(index)
+	if err != nil {
+		log.Fatalf("invalid replica index %v: %v", index, err)
+	}
+
+	dataDir := constants.CellManagerDataDir + index
+	if len(os.Args) >= 4 {
+		dataDir = os.Args[3]
+	}
+	if err := cm.EnablePersistence(dataDir, constants.SnapshotInterval); err != nil {
+		log.Fatalf("failed to restore state from %v: %v", dataDir, err)
+	}
+
+	if err := cm.EnableReplication(id, constants.CellManagerAddresses); err != nil {
+		log.Fatalf("failed to start replica: %v", err)
+	}
+
+	_, port, err := net.SplitHostPort(constants.CellManagerAddresses[id])
+	if err != nil {
+		log.Fatalf("invalid replica address: %v", err)
+	}
+	return ":" + port
+}
+
+func restoreState(cm *cellmanager.CellManager) {
+	// the state is restored from, and written to, the data directory given as the first argument
+	dataDir := constants.CellManagerDataDir
+	if len(os.Args) >= 2 {
+		dataDir = os.Args[1]
+	}
+	if err := cm.EnablePersistence(dataDir, constants.SnapshotInterval); err != nil {
+		log.Fatalf("failed to restore state from %v: %v", dataDir, err)
+	}
+
+	// without stored state the tree is rebuilt from the cell masters given as the remaining arguments
+	if cm.CellTree == nil && len(os.Args) > 2 {
+		accepted, err := cm.RecoverFromPeers(os.Args[2:], constants.MAP_SIZE, constants.MAP_SIZE)
+		if err != nil {
+			log.Fatalf("failed to recover from cell masters: %v", err)
+		}
+		log.Printf("recovered %v cells from cell masters", accepted)
+	}
+}
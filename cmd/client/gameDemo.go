@@ -26,6 +26,7 @@ import (
 	"github.com/Frans-Lukas/checkerboard/cmd/constants"
 	"github.com/Frans-Lukas/checkerboard/cmd/mapDrawer"
 	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
 	NS "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
 	OBJ "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
 	"google.golang.org/grpc"
@@ -106,12 +107,13 @@ func main() {
 		}
 	}()
 
-	conn, err := grpc.Dial(constants.CellManagerAddress, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	conn, err := cellmanager.DialCellManager(constants.CellManagerAddresses)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 	cellManager := NS.NewCellManagerClient(conn)
+	thisPlayer.SetCellManager(cellManager)
 
 	go func() {
 		updateWorld(thisPlayer, &cellManager)
@@ -120,7 +122,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
 
-	_, err = cellManager.AddPlayerToCellWithPositions(ctx, &NS.PlayerInCellRequestWithPositions{Ip: os.Args[1], Port: int32(port), PosX: thisPlayer.PosX, PosY: thisPlayer.PosY})
+	_, err = cellManager.AddPlayerToCellWithPositions(ctx, &NS.PlayerInCellRequestWithPositions{Ip: os.Args[1], Port: int32(port), PosX: thisPlayer.PosX, PosY: thisPlayer.PosY, ObjectId: thisPlayer.ObjectId})
 
 	if err != nil {
 		log.Fatalf("Failed to add player to cell: ", err.Error())
@@ -135,21 +137,26 @@ func main() {
 	for {
 
 		ctx, _ := context.WithTimeout(context.Background(), time.Second)
-		_, err = (*thisPlayer.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
-			Ip:       "localhost",
-			Port:     int32(port),
-			PosX:     thisPlayer.PosX,
-			PosY:     thisPlayer.PosY,
-			ObjectId: thisPlayer.ObjectId,
-		})
-		if err == nil {
-			break
-		} else {
-			RequestNewCellMaster(cellManager, thisPlayer)
+		cellMaster := thisPlayer.CurrentCellMaster()
+		if cellMaster.CellMaster != nil {
+			_, err = (*cellMaster.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
+				Ip:              "localhost",
+				Port:            int32(port),
+				PosX:            thisPlayer.PosX,
+				PosY:            thisPlayer.PosY,
+				ObjectId:        thisPlayer.ObjectId,
+				CellId:          cellMaster.CellId,
+				TopologyVersion: cellMaster.TopologyVersion,
+			})
+			if err == nil {
+				break
+			}
 			println("got error ", err.Error())
-			time.Sleep(time.Second)
 		}
+		RequestNewCellMaster(cellManager, thisPlayer)
+		time.Sleep(time.Second)
 	}
+	bootstrapCellState(thisPlayer)
 
 	gameLoop(thisPlayer, cellManager)
 
@@ -178,39 +185,44 @@ func gameLoop(thisPlayer *objects.Player, cellManager NS.CellManagerClient) {
 		//TODO check so that defer is not needed
 		//defer cancel()
 
-		for thisPlayer.CellMaster == nil {
+		for thisPlayer.CurrentCellMaster().CellMaster == nil {
 			println("Requesting cellmaster")
 
 			playerList = make(map[string]*Player, 0)
 
 			RequestNewCellMaster(cellManager, thisPlayer)
-			if thisPlayer.CellMaster != nil {
+			if cellMaster := thisPlayer.CurrentCellMaster(); cellMaster.CellMaster != nil {
 
 				ctx, _ := context.WithTimeout(context.Background(), time.Second)
 				println("Got cellmaster, subscribing")
-				_, err := (*thisPlayer.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
-					Ip:       thisPlayer.Ip,
-					Port:     int32(thisPlayer.Port),
-					PosX:     thisPlayer.PosX,
-					PosY:     thisPlayer.PosY,
-					ObjectId: thisPlayer.ObjectId,
+				_, err := (*cellMaster.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
+					Ip:              thisPlayer.Ip,
+					Port:            int32(thisPlayer.Port),
+					PosX:            thisPlayer.PosX,
+					PosY:            thisPlayer.PosY,
+					ObjectId:        thisPlayer.ObjectId,
+					CellId:          cellMaster.CellId,
+					TopologyVersion: cellMaster.TopologyVersion,
 				})
 
 				for err != nil {
 					println("Failed to subscribe: ", err.Error())
 					RequestNewCellMaster(cellManager, thisPlayer)
 
-					if thisPlayer.CellMaster != nil {
+					if cellMaster := thisPlayer.CurrentCellMaster(); cellMaster.CellMaster != nil {
 						ctx, _ := context.WithTimeout(context.Background(), time.Second)
-						_, err = (*thisPlayer.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
-							Ip:       thisPlayer.Ip,
-							Port:     int32(thisPlayer.Port),
-							PosX:     thisPlayer.PosX,
-							PosY:     thisPlayer.PosY,
-							ObjectId: thisPlayer.ObjectId,
+						_, err = (*cellMaster.CellMaster).SubscribePlayer(ctx, &OBJ.PlayerInfo{
+							Ip:              thisPlayer.Ip,
+							Port:            int32(thisPlayer.Port),
+							PosX:            thisPlayer.PosX,
+							PosY:            thisPlayer.PosY,
+							ObjectId:        thisPlayer.ObjectId,
+							CellId:          cellMaster.CellId,
+							TopologyVersion: cellMaster.TopologyVersion,
 						})
 					}
 				}
+				bootstrapCellState(thisPlayer)
 
 				time.Sleep(time.Second)
 
@@ -218,15 +230,26 @@ func gameLoop(thisPlayer *objects.Player, cellManager NS.CellManagerClient) {
 			time.Sleep(time.Second)
 		}
 		ctx, _ := context.WithTimeout(context.Background(), time.Second)
-		_, err := (*thisPlayer.CellMaster).RequestObjectMutation(ctx, &OBJ.SingleObject{
+		// the cell master may have been changed since the loop above, it is asked for again on the next move
+		cellMaster := thisPlayer.CurrentCellMaster()
+		if cellMaster.CellMaster == nil {
+			continue
+		}
+		_, err := (*cellMaster.CellMaster).RequestObjectMutation(ctx, &OBJ.SingleObject{
 			ObjectType: PlayerObjectType,
 			ObjectId:   thisPlayer.ObjectId,
 			PosX:       int64(thisPlayer.PosX),
 			PosY:       int64(thisPlayer.PosY),
 			UpdateKey:  []string{"icon"},
 			NewValue:   []string{thisPlayerIcon},
+
+			CellId:          cellMaster.CellId,
+			TopologyVersion: cellMaster.TopologyVersion,
 		})
-		if err != nil {
+		if stale, ok := objects.StaleTopologyOf(err); ok {
+			println("cell ", stale.CellId, " has been split or merged, the position is now in cell ", stale.OwnerCellId)
+			thisPlayer.DropCellMaster(cellMaster.CellMaster)
+		} else if err != nil {
 			println("request object mutation failed: %v", err.Error())
 		}
 		println()
@@ -242,7 +265,7 @@ func gameLoop(thisPlayer *objects.Player, cellManager NS.CellManagerClient) {
 
 func RequestNewCellMaster(cellManager NS.CellManagerClient, thisPlayer *objects.Player) {
 	ctx, _ := context.WithTimeout(context.Background(), time.Second)
-	_, err := cellManager.AddPlayerToCellWithPositions(ctx, &NS.PlayerInCellRequestWithPositions{Ip: thisPlayer.Ip, Port: int32(thisPlayer.Port), PosX: thisPlayer.PosX, PosY: thisPlayer.PosY})
+	_, err := cellManager.AddPlayerToCellWithPositions(ctx, &NS.PlayerInCellRequestWithPositions{Ip: thisPlayer.Ip, Port: int32(thisPlayer.Port), PosX: thisPlayer.PosX, PosY: thisPlayer.PosY, ObjectId: thisPlayer.ObjectId})
 
 	if err != nil {
 		log.Println("RequestNewCellMaster: failed AddyerToCellWithPosition: ", err.Error())
@@ -257,10 +280,10 @@ func RequestNewCellMaster(cellManager NS.CellManagerClient, thisPlayer *objects.
 	conn, err2 := grpc.Dial(objects.ToAddress(cm.Ip, cm.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
 	if err2 != nil {
 		log.Println("did not connect to new cell master: %v", err2)
+		return
 	}
 	cmConn := OBJ.NewPlayerClient(conn)
-	thisPlayer.CellMaster = &cmConn
-	thisPlayer.Connection = conn
+	thisPlayer.SetCellMaster(&cmConn, conn, cm.CellId, cm.TopologyVersion)
 	println(cmConn)
 }
 
@@ -286,14 +309,35 @@ func botMove() {
 
 }
 
+// bootstrapCellState fills the player list with the objects the cell master holds for the cell, the mutations after
+// that are received as a subscriber.
+func bootstrapCellState(thisPlayer *objects.Player) {
+	cellMaster := thisPlayer.CurrentCellMaster()
+	if cellMaster.CellMaster == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	state, err := (*cellMaster.CellMaster).GetCellState(ctx, &OBJ.Cell{
+		CellId: cellMaster.CellId, TopologyVersion: cellMaster.TopologyVersion,
+		PosX: thisPlayer.PosX, PosY: thisPlayer.PosY,
+	})
+	if err != nil {
+		println("could not get cell state: ", err.Error())
+		return
+	}
+	for _, object := range state.Objects {
+		addRemoveOrUpdatePlayer(object)
+	}
+}
+
 func checkForPlayerUpdates(cellMaster *objects.Player) {
-	for _, object := range *cellMaster.MutatedObjects {
+	for _, object := range cellMaster.TakeMutatedObjects() {
 		addRemoveOrUpdatePlayer(object)
 	}
-	cellMaster.MutatedObjects = new([]OBJ.SingleObject)
 }
 
-func addRemoveOrUpdatePlayer(object OBJ.SingleObject) {
+func addRemoveOrUpdatePlayer(object *OBJ.SingleObject) {
 	for _, key := range object.UpdateKey {
 		if key == constants.RemovedKey {
 			println("Removing player from list, ", object.ObjectId)
@@ -302,9 +346,9 @@ func addRemoveOrUpdatePlayer(object OBJ.SingleObject) {
 		}
 	}
 	if _, ok := playerList[object.ObjectId]; ok {
-		updatePlayer(&object)
+		updatePlayer(object)
 	} else {
-		playerList[object.ObjectId] = PlayerFromObject(&object)
+		playerList[object.ObjectId] = PlayerFromObject(object)
 	}
 }
 
@@ -333,8 +377,8 @@ func printMap(cellMaster *objects.Player) {
 
 	playersMap.DrawClient(int(thisPlayer.PosX), int(thisPlayer.PosY), thisPlayerIcon)
 
-	if cellMaster.Cells != nil {
-		playersMap.DrawCellBoundaries(*cellMaster.Cells)
+	if ownedCell, ok := cellMaster.OwnedCell(); ok {
+		playersMap.DrawCellBoundaries(ownedCell)
 	}
 
 	playersMap.SaveMapAsPNG()
@@ -371,8 +415,8 @@ func printPosition(row int64, column int64, cellMaster *objects.Player) {
 	}
 
 	if !printedPlayer {
-		c := cellMaster.Cells
-		if c != nil {
+		c, ok := cellMaster.OwnedCell()
+		if ok {
 			if row == c.PosY && column == c.PosX {
 				print("+-")
 				printedMap = true
@@ -422,25 +466,25 @@ func updatePlayer(object *OBJ.SingleObject) {
 }
 
 func updateWorld(player *objects.Player, cellManager *NS.CellManagerClient) {
+	lastLoadReport := time.Now()
+	lastPositionReport := time.Now()
+	lastLeaseRenewal := time.Now()
 	// poll mutatingobjects
 	for {
 
 		objectsToCellMap := make(map[string][]*OBJ.SingleObject, 0)
 
-		objectsToMutate := make([]OBJ.SingleObject, len(*player.MutatingObjects))
-		copy(objectsToMutate, *player.MutatingObjects)
-		player.MutatingObjects = new([]OBJ.SingleObject)
-		for _, mutatingObject := range objectsToMutate {
+		for _, mutatingObject := range player.TakeMutatingObjects() {
 			mutatedObject := performGameLogic(mutatingObject, cellManager)
 			if len(mutatedObject.CellId) == 0 {
 				println("mutated object cellId: ", mutatedObject.CellId)
 			}
 
 			if objectList, ok := objectsToCellMap[mutatingObject.CellId]; ok {
-				objectsToCellMap[mutatingObject.CellId] = append(objectList, &mutatedObject)
+				objectsToCellMap[mutatingObject.CellId] = append(objectList, mutatedObject)
 			} else {
 				objectsToCellMap[mutatingObject.CellId] = make([]*OBJ.SingleObject, 0)
-				objectsToCellMap[mutatingObject.CellId] = append((objectsToCellMap)[mutatingObject.CellId], &mutatedObject)
+				objectsToCellMap[mutatingObject.CellId] = append((objectsToCellMap)[mutatingObject.CellId], mutatedObject)
 			}
 		}
 
@@ -450,7 +494,29 @@ func updateWorld(player *objects.Player, cellManager *NS.CellManagerClient) {
 			//defer cancel()
 			player.BroadcastMutatedObjects(ctx, &OBJ.MultipleObjects{Objects: objectList})
 		}
-		player.MutatingObjects = new([]OBJ.SingleObject)
+
+		if time.Since(lastLeaseRenewal) > time.Millisecond*constants.LeaseRenewIntervalMilli {
+			if err := player.RenewCellMastership(cellManager); err != nil {
+				println("could not renew cell mastership: ", err.Error())
+			}
+			lastLeaseRenewal = time.Now()
+		}
+
+		if time.Since(lastLoadReport) > time.Second*constants.LoadReportInterval {
+			if _, owned := player.OwnedCell(); owned {
+				if err := player.ReportCellLoad(cellManager); err != nil {
+					println("could not report cell load: ", err.Error())
+				}
+			}
+			lastLoadReport = time.Now()
+		}
+
+		if time.Since(lastPositionReport) > time.Millisecond*constants.PositionReportIntervalMilli {
+			if err := player.ReportPositions(cellManager); err != nil {
+				println("could not report positions: ", err.Error())
+			}
+			lastPositionReport = time.Now()
+		}
 		time.Sleep(time.Millisecond * 50)
 	}
 
@@ -458,7 +524,7 @@ func updateWorld(player *objects.Player, cellManager *NS.CellManagerClient) {
 	// broadcast update
 }
 
-func performGameLogic(mutatingObject OBJ.SingleObject, cellManager *NS.CellManagerClient) OBJ.SingleObject {
+func performGameLogic(mutatingObject *OBJ.SingleObject, cellManager *NS.CellManagerClient) *OBJ.SingleObject {
 	switch mutatingObject.ObjectType {
 	case PlayerObjectType:
 		return performPlayerUpdate(mutatingObject, cellManager)
@@ -466,9 +532,10 @@ func performGameLogic(mutatingObject OBJ.SingleObject, cellManager *NS.CellManag
 	return mutatingObject
 }
 
-func performPlayerUpdate(object OBJ.SingleObject, cellManager *NS.CellManagerClient) OBJ.SingleObject {
+func performPlayerUpdate(object *OBJ.SingleObject, cellManager *NS.CellManagerClient) *OBJ.SingleObject {
 	//playerToUpdate := singleObjectToPlayer(object)
 	//TODO: check for valid update
+	thisPlayer.RecordPosition(object.ObjectId, object.PosX, object.PosY)
 	thisPlayer.PlayerMightLeaveCellHandle(object, cellManager)
 	return object
 }
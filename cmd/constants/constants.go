@@ -8,9 +8,26 @@ const SplitCellRequirement = 8
 const MergeCellRequirement = 1
 const SplitCellInterval = 3
 const MergeAgeRequirement = 30
+const TopologyCooldown = 15
+const MinCellLifetime = 10
+const FlapWindow = 120
+const LoadReportInterval = 2
+const PositionReportIntervalMilli = 500
+const CellMasterLeaseMilli = 6000
+const LeaseRenewIntervalMilli = 2000
+const MinCellSize = 1
 const ClientImage = "client.png"
 const PlayerImage = "player.png"
 const IconSize = int(500 / MAP_SIZE)
 const AliveCheckInterval = 5
 const DialTimeoutMilli = 100
 const RemovedKey = "REMOVE_KEY"
+const CellManagerDataDir = "cellManagerData"
+const SnapshotInterval = 1000
+const HeartbeatIntervalMilli = 50
+const ElectionTimeoutMilli = 300
+const ReplicationTimeoutMilli = 1000
+const MaxReplicatedEntries = 100
+
+// the replicas of a replicated cell manager, clients are redirected to whichever of them is the leader
+var CellManagerAddresses = []string{CellManagerAddress, "localhost:50052", "localhost:50053"}
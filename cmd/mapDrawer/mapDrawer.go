@@ -165,11 +165,13 @@ func (thisMap *MapInfo) DrawClient(posX int, posY int, imagePath string) {
 
 }
 
+// DrawCellBoundaries draws the border of any rectangular cell, quadrants as well as halves cut along one axis.
 func (thisMap *MapInfo) DrawCellBoundaries(cell objects2.Cell) {
-	topLeft := image.Pt(int(cell.PosX)*(thisMap.sizeX/constants.MAP_SIZE), int(cell.PosY)*(thisMap.sizeX/constants.MAP_SIZE))
-	bottomLeft := image.Pt(int(cell.PosX)*(thisMap.sizeX/constants.MAP_SIZE), int(cell.PosY+cell.Height)*(thisMap.sizeX/constants.MAP_SIZE))
-	topRight := image.Pt(int(cell.PosX+cell.Width)*(thisMap.sizeX/constants.MAP_SIZE), int(cell.PosY)*(thisMap.sizeX/constants.MAP_SIZE))
-	bottomRight := image.Pt(int(cell.PosX+cell.Width)*(thisMap.sizeX/constants.MAP_SIZE), int(cell.PosY+cell.Height)*(thisMap.sizeX/constants.MAP_SIZE))
+	scaleX, scaleY := thisMap.sizeX/constants.MAP_SIZE, thisMap.sizeY/constants.MAP_SIZE
+	topLeft := image.Pt(int(cell.PosX)*scaleX, int(cell.PosY)*scaleY)
+	bottomLeft := image.Pt(int(cell.PosX)*scaleX, int(cell.PosY+cell.Height)*scaleY)
+	topRight := image.Pt(int(cell.PosX+cell.Width)*scaleX, int(cell.PosY)*scaleY)
+	bottomRight := image.Pt(int(cell.PosX+cell.Width)*scaleX, int(cell.PosY+cell.Height)*scaleY)
 
 	borderColor := color.RGBA{R: 100, G: 0, B: 0, A: 0xff}
 
@@ -3,8 +3,8 @@ package main
 import (
 	"context"
 	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
 	NS "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
-	"google.golang.org/grpc"
 	"log"
 	"time"
 )
@@ -17,7 +17,7 @@ const (
 
 func main() {
 	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	conn, err := cellmanager.DialCellManager(constants.CellManagerAddresses)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -3,8 +3,8 @@ package main
 import (
 	"context"
 	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
 	NS "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
-	"google.golang.org/grpc"
 	"log"
 	"os"
 	"time"
@@ -13,7 +13,7 @@ import (
 func main() {
 	//1. connect to cell manager
 
-	conn, err := grpc.Dial(constants.CellManagerAddress, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	conn, err := cellmanager.DialCellManager(constants.CellManagerAddresses)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
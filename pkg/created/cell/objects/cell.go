@@ -15,6 +15,10 @@ type Cell struct {
 	Height     int64
 	Locked     bool
 	Lockee     string
+	// the epoch the latest cell master was granted the cell with
+	Epoch int64
+	// the version of the tree the cell was created at by a split or merge
+	TopologyVersion int64
 }
 
 func NewCell(cellID string) Cell {
@@ -55,7 +59,10 @@ func NewCellFromCells(cellID string, cell1 Cell, cell2 Cell) Cell {
 }
 
 func (cell *Cell) ToGeneratedCell() objects.Cell {
-	return objects.Cell{CellId: cell.CellId, PosX: cell.PosX, PosY: cell.PosY, Width: cell.Width, Height: cell.Height}
+	return objects.Cell{
+		CellId: cell.CellId, PosX: cell.PosX, PosY: cell.PosY, Width: cell.Width, Height: cell.Height, Epoch: cell.Epoch,
+		TopologyVersion: cell.TopologyVersion,
+	}
 }
 
 func (cell *Cell) AppendPlayer(player Client) {
@@ -75,6 +82,33 @@ func (cell *Cell) CollidesWith(in *generated.Position) bool {
 		cell.PosY <= in.PosY && cell.PosY+cell.Height > in.PosY
 }
 
+// Touches returns true if the cells share at least a corner without overlapping.
+func (cell *Cell) Touches(other *Cell) bool {
+	overlapX := overlap(cell.PosX, cell.PosX+cell.Width, other.PosX, other.PosX+other.Width)
+	overlapY := overlap(cell.PosY, cell.PosY+cell.Height, other.PosY, other.PosY+other.Height)
+	return overlapX >= 0 && overlapY >= 0 && !(overlapX > 0 && overlapY > 0)
+}
+
+// SharesEdgeWith returns true if the cells touch along a border of non-zero length.
+func (cell *Cell) SharesEdgeWith(other *Cell) bool {
+	overlapX := overlap(cell.PosX, cell.PosX+cell.Width, other.PosX, other.PosX+other.Width)
+	overlapY := overlap(cell.PosY, cell.PosY+cell.Height, other.PosY, other.PosY+other.Height)
+	return cell.Touches(other) && (overlapX > 0 || overlapY > 0)
+}
+
+// overlap returns the length two intervals have in common, negative if they are apart.
+func overlap(start1 int64, end1 int64, start2 int64, end2 int64) int64 {
+	start := start1
+	if start2 > start {
+		start = start2
+	}
+	end := end1
+	if end2 < end {
+		end = end2
+	}
+	return end - start
+}
+
 func (cell *Cell) SelectNewCellMaster() int {
 	bestTrustLevel := uint32(0)
 	cmIndex := -1
@@ -87,6 +121,18 @@ func (cell *Cell) SelectNewCellMaster() int {
 	return cmIndex
 }
 
+// MovePlayer sets the position of a registered player, it returns false if the player is not registered in the cell.
+func (cell *Cell) MovePlayer(movedPlayer Client) bool {
+	for index, player := range cell.Players {
+		if player.Ip == movedPlayer.Ip && player.Port == movedPlayer.Port {
+			cell.Players[index].PosX = movedPlayer.PosX
+			cell.Players[index].PosY = movedPlayer.PosY
+			return true
+		}
+	}
+	return false
+}
+
 func (cell *Cell) DeletePlayer(playerToRemove Client) {
 	for index, player := range cell.Players {
 		if player.Ip == playerToRemove.Ip && player.Port == playerToRemove.Port {
@@ -6,6 +6,7 @@ import (
 	"github.com/Frans-Lukas/checkerboard/cmd/constants"
 	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
 	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"github.com/golang/protobuf/proto"
 	"google.golang.org/grpc"
 	"strconv"
 	"sync"
@@ -18,11 +19,18 @@ type Client struct {
 	Ip   string
 	// 0 = lowest trust level UINT32_MAX = highest trust level
 	TrustLevel uint32
+	ObjectId   string
+	// where the player was when it was registered
+	PosX int64
+	PosY int64
 }
 
 type CellMasterConnection struct {
 	CellMaster *generated.PlayerClient
 	Connection *grpc.ClientConn
+	// the cell the cell master was found for, messages to the cell master are addressed with it
+	CellId          string
+	TopologyVersion int64
 }
 
 type PlayerInfoClient struct {
@@ -30,6 +38,9 @@ type PlayerInfoClient struct {
 	Port     int
 	Ip       string
 	ObjectId string
+	// the latest known position of the player, guarded by CellMasterMutex
+	PosX int64
+	PosY int64
 }
 
 type Player struct {
@@ -44,22 +55,52 @@ type Player struct {
 	PosY     int64
 	ObjectId string
 
-	MutatedObjects  *[]generated.SingleObject
-	MutatingObjects *[]generated.SingleObject
+	// the queues are only swapped or appended to while holding queueMutex, use TakeMutatedObjects and
+	// TakeMutatingObjects to consume them
+	MutatedObjects  *[]*generated.SingleObject
+	MutatingObjects *[]*generated.SingleObject
+	queueMutex      *sync.Mutex
+
+	// the mutations taken since the last load report, guarded by queueMutex
+	mutationCount  int
+	lastLoadReport time.Time
+	// the latest position of every player moved since the last position report, by object id, guarded by queueMutex
+	movedPlayers map[string]*cellmanager.PlayerPosition
+	// the highest epoch of a cell master objects were received from, by cell id, guarded by queueMutex
+	cellEpochs map[string]int64
+	// the objects set aside for the cell manager when a cell was given up, by cell id, guarded by queueMutex
+	releasedObjects map[string]releasedCell
+	// the released objects the cell master was handed for the cell it holds, guarded by queueMutex
+	deliveredObjects map[releaseKey]bool
 
 	//map of cellid map of playerid
 	SubscribedPlayers *map[string]map[string]*PlayerInfoClient
 
-	CellMasterMutex      *sync.Mutex
-	Cells                *Cell
+	// guards Cells and SubscribedPlayers, it is never held while calling other players
+	CellMasterMutex *sync.Mutex
+	Cells           *Cell
+	// when the lease on Cells runs out, zero if it does not, guarded by CellMasterMutex
+	leaseExpiry time.Time
+	// used to look up the owner of a position when a stale message is rejected, guarded by CellMasterMutex
+	cellManager cellmanager.CellManagerClient
+	// the cell this player is about to be handed and its subscribers, guarded by CellMasterMutex
+	handover *cellHandover
+	// the latest value of every key of every object in the owned cell, by object id, guarded by CellMasterMutex
+	state                map[string]*generated.SingleObject
 	splitCellRequirement int
 	splitCheckInterval   int
 }
 
+// cellHandover is a cell a player has been primed for, its subscribers are connected to before the cell is granted.
+type cellHandover struct {
+	cell        Cell
+	subscribers map[string]*PlayerInfoClient
+}
+
 func NewPlayer(splitCellRequirement int, splitCheckInterval int) *Player {
-	emptyObjectList := make([]generated.SingleObject, 0)
+	emptyObjectList := make([]*generated.SingleObject, 0)
 	emptyPlayerMap := make(map[string]map[string]*PlayerInfoClient, 0)
-	mutatedObjects := make([]generated.SingleObject, 0)
+	mutatedObjects := make([]*generated.SingleObject, 0)
 	cmConn := CellMasterConnection{}
 	mutex := &sync.Mutex{}
 	return &Player{
@@ -67,8 +108,14 @@ func NewPlayer(splitCellRequirement int, splitCheckInterval int) *Player {
 		CellMasterConnection: &cmConn,
 		SubscribedPlayers:    &emptyPlayerMap,
 		MutatingObjects:      &emptyObjectList,
+		queueMutex:           &sync.Mutex{},
+		movedPlayers:         make(map[string]*cellmanager.PlayerPosition, 0),
+		cellEpochs:           make(map[string]int64, 0),
+		releasedObjects:      make(map[string]releasedCell, 0),
+		deliveredObjects:     make(map[releaseKey]bool, 0),
 		CellMasterMutex:      mutex,
 		Cells:                nil,
+		state:                make(map[string]*generated.SingleObject, 0),
 		splitCellRequirement: splitCellRequirement,
 		splitCheckInterval:   splitCheckInterval,
 	}
@@ -86,33 +133,251 @@ func (player *Player) ReceiveMutatedObjects(
 		}
 	}
 
+	player.queueMutex.Lock()
+	defer player.queueMutex.Unlock()
+	// objects from a cell master that has since been replaced are dropped
+	for _, object := range in.Objects {
+		if in.Epoch < player.cellEpochs[object.CellId] {
+			return &generated.EmptyReply{}, errors.New("stale epoch for cell " + object.CellId)
+		}
+	}
 	for _, object := range in.Objects {
-		*player.MutatedObjects = append(*player.MutatedObjects, *object)
+		if len(object.CellId) > 0 {
+			player.cellEpochs[object.CellId] = in.Epoch
+		}
 	}
+	*player.MutatedObjects = append(*player.MutatedObjects, in.Objects...)
 
 	return &generated.EmptyReply{}, nil
 }
 
-func (cm *Player) AppendMutatingObject(object generated.SingleObject) {
+// TakeMutatedObjects empties the queue of received objects and returns what was in it.
+func (player *Player) TakeMutatedObjects() []*generated.SingleObject {
+	player.queueMutex.Lock()
+	defer player.queueMutex.Unlock()
+	mutatedObjects := *player.MutatedObjects
+	emptyObjectList := make([]*generated.SingleObject, 0)
+	player.MutatedObjects = &emptyObjectList
+	return mutatedObjects
+}
+
+func (cm *Player) AppendMutatingObject(object *generated.SingleObject) {
 	if constants.DebugMode {
 		println("Appending object with cellid ", object.CellId)
 	}
+	cm.queueMutex.Lock()
 	*cm.MutatingObjects = append(*cm.MutatingObjects, object)
+	cm.queueMutex.Unlock()
+}
+
+// TakeMutatingObjects empties the queue of requested mutations and returns what was in it, every mutation is
+// returned by exactly one call.
+func (cm *Player) TakeMutatingObjects() []*generated.SingleObject {
+	cm.queueMutex.Lock()
+	defer cm.queueMutex.Unlock()
+	mutatingObjects := *cm.MutatingObjects
+	emptyObjectList := make([]*generated.SingleObject, 0)
+	cm.MutatingObjects = &emptyObjectList
+	cm.mutationCount += len(mutatingObjects)
+	return mutatingObjects
+}
+
+// ReportCellLoad tells the cell manager how many mutations per second were taken since the last report, and how
+// close the owned cell is to the number of players that makes this cell master want to split it.
+func (cm *Player) ReportCellLoad(cellManager *cellmanager.CellManagerClient) error {
+	cell, owned := cm.OwnedCell()
+	if !owned {
+		return errors.New("not cell master of any cell")
+	}
+	subscribers, _ := cm.subscribersOfCell(cell.CellId)
+
+	cm.queueMutex.Lock()
+	now := time.Now()
+	mutationsPerSecond := 0.0
+	if elapsed := now.Sub(cm.lastLoadReport).Seconds(); !cm.lastLoadReport.IsZero() && elapsed > 0 {
+		mutationsPerSecond = float64(cm.mutationCount) / elapsed
+	}
+	cm.mutationCount = 0
+	cm.lastLoadReport = now
+	cm.queueMutex.Unlock()
+
+	load := 0.0
+	if cm.splitCellRequirement > 0 {
+		load = float64(len(subscribers)) / float64(cm.splitCellRequirement)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := (*cellManager).ReportCellLoad(ctx, &cellmanager.CellLoadReport{
+		CellId:             cell.CellId,
+		Ip:                 cm.Ip,
+		Port:               int32(cm.Port),
+		MutationsPerSecond: mutationsPerSecond,
+		Load:               load,
+		Epoch:              cell.Epoch,
+	})
+	return err
+}
+
+// RecordPosition remembers where a player object was moved to, it is sent with the next position report.
+func (cm *Player) RecordPosition(objectId string, posX int64, posY int64) {
+	cm.queueMutex.Lock()
+	defer cm.queueMutex.Unlock()
+	cm.movedPlayers[objectId] = &cellmanager.PlayerPosition{ObjectId: objectId, PosX: posX, PosY: posY}
+}
+
+// ReportPositions sends the positions recorded since the last report to the cell manager in a single batch. If the
+// report fails, the positions that have not been recorded again since are kept for the next report.
+func (cm *Player) ReportPositions(cellManager *cellmanager.CellManagerClient) error {
+	cm.queueMutex.Lock()
+	movedPlayers := cm.movedPlayers
+	cm.movedPlayers = make(map[string]*cellmanager.PlayerPosition, 0)
+	cm.queueMutex.Unlock()
+
+	if len(movedPlayers) == 0 {
+		return nil
+	}
+
+	report := &cellmanager.PositionReport{Positions: make([]*cellmanager.PlayerPosition, 0, len(movedPlayers))}
+	if cell, owned := cm.OwnedCell(); owned {
+		report.CellId = cell.CellId
+		report.Epoch = cell.Epoch
+	}
+	for _, position := range movedPlayers {
+		report.Positions = append(report.Positions, position)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := (*cellManager).ReportPositions(ctx, report)
+	if err != nil {
+		cm.queueMutex.Lock()
+		for objectId, position := range movedPlayers {
+			if _, ok := cm.movedPlayers[objectId]; !ok {
+				cm.movedPlayers[objectId] = position
+			}
+		}
+		cm.queueMutex.Unlock()
+	}
+	return err
+}
+
+// RenewCellMastership extends the lease on the owned cell. If the cell manager does not grant the lease, the cell has
+// been given away, split or merged, so it is given up and its subscribers are told to find their new cell master. A
+// cell manager that can not be reached is asked again until the lease runs out.
+func (cm *Player) RenewCellMastership(cellManager *cellmanager.CellManagerClient) error {
+	cm.CellMasterMutex.Lock()
+	if cm.Cells == nil {
+		cm.CellMasterMutex.Unlock()
+		return nil
+	}
+	cell := *cm.Cells
+	cm.CellMasterMutex.Unlock()
+
+	sent := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	reply, err := (*cellManager).RenewCellMastership(ctx, &cellmanager.LeaseRequest{
+		CellId: cell.CellId, Ip: cm.Ip, Port: int32(cm.Port), Epoch: cell.Epoch,
+	})
+	if err != nil {
+		return err
+	}
+
+	cm.CellMasterMutex.Lock()
+	// the cell may have been given up or granted again while the lease was renewed
+	if cm.Cells == nil || cm.Cells.CellId != cell.CellId || cm.Cells.Epoch != cell.Epoch {
+		cm.CellMasterMutex.Unlock()
+		return nil
+	}
+	if reply.Granted {
+		cm.leaseExpiry = leaseFor(sent, reply.LeaseMillis)
+		cm.CellMasterMutex.Unlock()
+		return nil
+	}
+	cm.Cells = nil
+	cm.state = make(map[string]*generated.SingleObject, 0)
+	cm.CellMasterMutex.Unlock()
+
+	cm.desubscribePlayers(cell)
+	return errors.New("lost the lease on cell " + cell.CellId)
+}
+
+// OwnedCell returns a copy of the cell this player is cell master for. A cell whose lease has run out is not owned, the
+// cell manager may already have given it to another player.
+func (cm *Player) OwnedCell() (Cell, bool) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	if cm.Cells == nil || !cm.holdsLease() {
+		return Cell{}, false
+	}
+	return *cm.Cells, true
+}
+
+// holdsLease reports whether the lease on the owned cell has not run out, CellMasterMutex must be held.
+func (cm *Player) holdsLease() bool {
+	return cm.leaseExpiry.IsZero() || time.Now().Before(cm.leaseExpiry)
+}
+
+// leaseFor returns when a lease granted at the time runs out, zero for a lease that does not.
+func leaseFor(granted time.Time, leaseMillis int64) time.Time {
+	if leaseMillis <= 0 {
+		return time.Time{}
+	}
+	return granted.Add(time.Duration(leaseMillis) * time.Millisecond)
 }
 
+// ReceiveCellMastership makes this player cell master of the cells under the lease they are granted with, a grant
+// with a lower epoch than that of the owned cell is stale and rejected. A cell this player has been primed for starts
+// out with the subscribers it was primed with, the subscribers of a cell given up for it are told to leave.
 func (cm *Player) ReceiveCellMastership(ctx context.Context, in *generated.CellList) (*generated.EmptyReply, error) {
+	received := time.Now()
 	for _, cell := range in.Cells {
 
 		println("Received cell mastership with (width, height)", cell.Width, ", ", cell.Height, " for cell: ", cell.CellId)
 
+		cm.CellMasterMutex.Lock()
+		if cm.Cells != nil && cm.Cells.CellId == cell.CellId && cell.Epoch < cm.Cells.Epoch {
+			cm.CellMasterMutex.Unlock()
+			return &generated.EmptyReply{}, errors.New("stale epoch for cell " + cell.CellId)
+		}
+
+		cm.leaseExpiry = leaseFor(received, in.LeaseMillis)
 		if cm.Cells != nil && cm.Cells.CellId == cell.CellId {
 			ownedCell := cm.Cells
 			ownedCell.PosX = cell.PosX
 			ownedCell.PosY = cell.PosY
 			ownedCell.Height = cell.Height
 			ownedCell.Width = cell.Width
+			ownedCell.Epoch = cell.Epoch
+			ownedCell.TopologyVersion = cell.TopologyVersion
+			cm.CellMasterMutex.Unlock()
 		} else {
-			cm.Cells = &Cell{CellId: cell.CellId, PosX: cell.PosX, PosY: cell.PosY, Width: cell.Width, Height: cell.Height}
+			left := cm.Cells
+			cm.Cells = &Cell{
+				CellId: cell.CellId, PosX: cell.PosX, PosY: cell.PosY, Width: cell.Width, Height: cell.Height,
+				Epoch: cell.Epoch, TopologyVersion: cell.TopologyVersion,
+			}
+			if handover := cm.handover; handover != nil && handover.matches(cell) {
+				(*cm.SubscribedPlayers)[cell.CellId] = handover.subscribers
+				cm.handover = nil
+			}
+			// the subscribers that move along with this player are redirected by the cell manager
+			leaving := make([]*PlayerInfoClient, 0)
+			if left != nil {
+				for key, subscriber := range (*cm.SubscribedPlayers)[left.CellId] {
+					if _, ok := (*cm.SubscribedPlayers)[cell.CellId][key]; !ok {
+						leaving = append(leaving, subscriber)
+					}
+				}
+				delete(*cm.SubscribedPlayers, left.CellId)
+				cm.releaseObjects(*left, cm.Cells)
+			}
+			cm.CellMasterMutex.Unlock()
+
+			for _, subscriber := range leaving {
+				tellToLeave(subscriber, *left)
+			}
 			cm.SubscribePlayer(ctx, &generated.PlayerInfo{Port: int32(cm.Port), Ip: cm.Ip, PosY: cm.PosY, PosX: cm.PosX, ObjectId: cm.ObjectId})
 		}
 	}
@@ -120,38 +385,131 @@ func (cm *Player) ReceiveCellMastership(ctx context.Context, in *generated.CellL
 	return &generated.EmptyReply{}, nil
 }
 
+// PrepareCellMastership primes this player for a cell it is about to be granted by a split or merge. The players of
+// the cell are connected to now, so that they are subscribed the moment the cell is granted. Nothing is served for the
+// cell before that.
+func (cm *Player) PrepareCellMastership(ctx context.Context, in *generated.CellHandover) (*generated.EmptyReply, error) {
+	if in.Cell == nil {
+		return &generated.EmptyReply{}, errors.New("no cell to prepare for")
+	}
+
+	handover := &cellHandover{
+		cell: Cell{
+			CellId: in.Cell.CellId, PosX: in.Cell.PosX, PosY: in.Cell.PosY, Width: in.Cell.Width, Height: in.Cell.Height,
+		},
+		subscribers: make(map[string]*PlayerInfoClient, len(in.Subscribers)),
+	}
+	for _, subscriber := range in.Subscribers {
+		subscriberConn, err := dialSubscriber(subscriber)
+		if err != nil {
+			return &generated.EmptyReply{}, err
+		}
+		handover.subscribers[subscriberKey(subscriber.Ip, subscriber.Port)] = subscriberConn
+	}
+
+	cm.CellMasterMutex.Lock()
+	cm.handover = handover
+	cm.CellMasterMutex.Unlock()
+	return &generated.EmptyReply{}, nil
+}
+
+// matches reports whether the granted cell is the one the handover was primed for.
+func (handover *cellHandover) matches(cell *generated.Cell) bool {
+	return handover.cell.CellId == cell.CellId && handover.cell.PosX == cell.PosX && handover.cell.PosY == cell.PosY &&
+		handover.cell.Width == cell.Width && handover.cell.Height == cell.Height
+}
+
+// RequestObjectMutation queues the mutation of an object, the object is given the id and topology version of the owned
+// cell if it is in it and no cell id if it has left it. An object addressed to a cell that has since been split or
+// merged is rejected.
 func (cm *Player) RequestObjectMutation(ctx context.Context, in *generated.SingleObject) (*generated.EmptyReply, error) {
+	cm.CellMasterMutex.Lock()
+	if stale := cm.staleAddress(in.CellId, in.TopologyVersion); stale != nil {
+		cm.CellMasterMutex.Unlock()
+		return &generated.EmptyReply{}, cm.rejectStale(stale, in.PosX, in.PosY)
+	}
 	if cm.Cells == nil {
+		cm.CellMasterMutex.Unlock()
 		return &generated.EmptyReply{}, errors.New("RequestObjectMutation: Cell is nil")
 	}
+	// a retried delivery of an object the cell master was already handed is acknowledged without queueing it again
+	if !cm.acceptDelivery(in) {
+		cm.CellMasterMutex.Unlock()
+		return &generated.EmptyReply{}, nil
+	}
 
+	in.CellId = ""
 	if cm.Cells.CollidesWith(&cellmanager.Position{PosY: in.PosY, PosX: in.PosX}) {
 		in.CellId = cm.Cells.CellId
+		in.TopologyVersion = cm.Cells.TopologyVersion
 	}
-
-	cm.AppendMutatingObject(*in)
+	// queued before unlocking, so that the mutation is either handed over with the cell or rejected
+	cm.AppendMutatingObject(in)
+	cm.CellMasterMutex.Unlock()
 	return &generated.EmptyReply{}, nil
 }
 
 func (cm *Player) RequestMutatingObjects(ctx context.Context, in *generated.Cell) (*generated.MultipleObjects, error) {
 	mutatingObjects := make([]*generated.SingleObject, 0)
 
-	for index, object := range *cm.MutatingObjects {
+	cm.queueMutex.Lock()
+	for _, object := range *cm.MutatingObjects {
 		if object.CellId == in.CellId {
-			mutatingObjects = append(mutatingObjects, &(*cm.MutatingObjects)[index])
+			mutatingObjects = append(mutatingObjects, object)
 		}
 	}
+	cm.queueMutex.Unlock()
 
 	return &generated.MultipleObjects{Objects: mutatingObjects}, nil
 }
 
+// subscribersOfCell returns a snapshot of the players subscribed to the cell.
+func (cm *Player) subscribersOfCell(cellId string) ([]*PlayerInfoClient, bool) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	playerMap, ok := (*cm.SubscribedPlayers)[cellId]
+	if !ok {
+		return nil, false
+	}
+
+	playerList := make([]*PlayerInfoClient, 0, len(playerMap))
+	for _, player := range playerMap {
+		playerList = append(playerList, player)
+	}
+	return playerList, true
+}
+
+// BroadcastMutatedObjects sends the objects to the subscribers of their cells with the epoch of the owned cell, a cell
+// master whose lease has run out does not send anything. Nothing is sent if any of the objects is addressed to a cell
+// that has since been split or merged.
 func (cm *Player) BroadcastMutatedObjects(ctx context.Context, in *generated.MultipleObjects) (*generated.EmptyReply, error) {
+	cm.CellMasterMutex.Lock()
+	if cm.Cells != nil && !cm.holdsLease() {
+		cm.CellMasterMutex.Unlock()
+		return nil, errors.New("the lease on cell " + cm.Cells.CellId + " has run out")
+	}
+	for _, object := range in.Objects {
+		if stale := cm.staleAddress(object.CellId, object.TopologyVersion); stale != nil {
+			cm.CellMasterMutex.Unlock()
+			return nil, cm.rejectStale(stale, object.PosX, object.PosY)
+		}
+	}
+	epoch := int64(0)
+	if cm.Cells != nil {
+		epoch = cm.Cells.Epoch
+		// stored in the order they are broadcast in, so that a snapshot never goes back behind a broadcast
+		for _, object := range in.Objects {
+			cm.applyMutation(object)
+		}
+	}
+	cm.CellMasterMutex.Unlock()
+
 	for objectIndex, object := range (*in).Objects {
 		if constants.DebugMode {
 			println("checking cell with id ", object.CellId)
 		}
 
-		if playerList, ok := (*cm.SubscribedPlayers)[object.CellId]; ok {
+		if playerList, ok := cm.subscribersOfCell(object.CellId); ok {
 			if constants.DebugMode {
 				println("checking playerlist of size ", len(playerList))
 				println("broadcasting to cell with id ", object.CellId)
@@ -160,7 +518,7 @@ func (cm *Player) BroadcastMutatedObjects(ctx context.Context, in *generated.Mul
 				if true {
 					println("sending updated objects to player: ", player.Port)
 				}
-				err := cm.SendObjectUpdateToPlayer(*player, ctx, (*in).Objects[objectIndex])
+				err := cm.SendObjectUpdateToPlayer(player, ctx, (*in).Objects[objectIndex], epoch)
 				if err != nil {
 					return nil, err
 				}
@@ -171,32 +529,143 @@ func (cm *Player) BroadcastMutatedObjects(ctx context.Context, in *generated.Mul
 	return &generated.EmptyReply{}, nil
 }
 
-func (cm *Player) SendObjectUpdateToPlayer(player generated.PlayerClient, ctx context.Context, object *generated.SingleObject) (error) {
+func (cm *Player) SendObjectUpdateToPlayer(player generated.PlayerClient, ctx context.Context, object *generated.SingleObject, epoch int64) (error) {
 	if constants.DebugMode {
 		println("Sending object update to player ")
 	}
-	_, err := player.ReceiveMutatedObjects(ctx, &generated.MultipleObjects{Objects: []*generated.SingleObject{object}})
+	_, err := player.ReceiveMutatedObjects(ctx, &generated.MultipleObjects{
+		Objects: []*generated.SingleObject{object}, Epoch: epoch,
+	})
 	return err
 }
 
-//TODO implement cell state
-//func (cm *Player) GetCellState(ctx context.Context, in *generated.Cell) (*generated.MultipleObjects, error) {
-//	return &generated.MultipleObjects{}, nil
-//}
-
 func (cm *Player) IsAlive(ctx context.Context, in *generated.EmptyRequest) (*generated.EmptyReply, error) {
 	return &generated.EmptyReply{}, nil
 }
 
+// ReportCellMastership tells a recovering cell manager which cell this player is cell master for, and who is
+// subscribed to it.
+func (cm *Player) ReportCellMastership(ctx context.Context, in *generated.EmptyRequest) (*generated.CellMastershipReport, error) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+
+	report := &generated.CellMastershipReport{Ip: cm.Ip, Port: int32(cm.Port), ObjectId: cm.ObjectId}
+	if cm.Cells == nil {
+		return report, nil
+	}
+
+	cell := cm.Cells.ToGeneratedCell()
+	report.OwnsCell = true
+	report.Cell = &cell
+	report.Epoch = cm.Cells.Epoch
+	for _, subscriber := range (*cm.SubscribedPlayers)[cm.Cells.CellId] {
+		report.Subscribers = append(report.Subscribers, &generated.PlayerInfo{
+			Ip:       subscriber.Ip,
+			Port:     int32(subscriber.Port),
+			ObjectId: subscriber.ObjectId,
+			PosX:     subscriber.PosX,
+			PosY:     subscriber.PosY,
+		})
+	}
+	return report, nil
+}
+
+// ChangedCellMaster makes this player leave its cell master. If the new cell master is given the player subscribes to
+// it straight away, otherwise it has to ask the cell manager for one. A notice for a cell this player has already left
+// is stale and ignored.
 func (cm *Player) ChangedCellMaster(ctx context.Context, in *generated.ChangedCellMasterRequest) (*generated.ChangedCellMasterReply, error) {
+	cm.CellMasterMutex.Lock()
+	if len(in.CellId) > 0 && len(cm.CellMasterConnection.CellId) > 0 &&
+		(in.CellId != cm.CellMasterConnection.CellId || in.TopologyVersion != cm.CellMasterConnection.TopologyVersion) {
+		cm.CellMasterMutex.Unlock()
+		return &generated.ChangedCellMasterReply{}, nil
+	}
+
 	println("Nilling cell master")
 	cm.CellMaster = nil
-	cm.Connection.Close()
+	if cm.Connection != nil {
+		cm.Connection.Close()
+	}
 	println("Cell master is nilled")
+	if len(in.Ip) == 0 {
+		cm.CellMasterMutex.Unlock()
+		return &generated.ChangedCellMasterReply{}, nil
+	}
+
+	conn, err := grpc.Dial(ToAddress(in.Ip, in.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		cm.CellMasterMutex.Unlock()
+		return &generated.ChangedCellMasterReply{}, err
+	}
+	cellMaster := generated.NewPlayerClient(conn)
+	cm.Connection = conn
+	cm.CellMasterConnection.CellId = in.NewCellId
+	cm.CellMasterConnection.TopologyVersion = in.NewTopologyVersion
+	subscription := &generated.PlayerInfo{
+		Ip: cm.Ip, Port: int32(cm.Port), PosX: cm.PosX, PosY: cm.PosY, ObjectId: cm.ObjectId,
+		CellId: in.NewCellId, TopologyVersion: in.NewTopologyVersion,
+	}
+	cm.CellMasterMutex.Unlock()
+
+	// the new cell master may be this player, so the mutex is not held while subscribing
+	_, err = cellMaster.SubscribePlayer(ctx, subscription)
+	if err != nil {
+		return &generated.ChangedCellMasterReply{}, err
+	}
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	// a later notice may have moved this player on while it subscribed
+	if cm.Connection == conn {
+		cm.CellMaster = &cellMaster
+	}
 	return &generated.ChangedCellMasterReply{}, nil
 }
 
+// CurrentCellMaster returns a copy of the connection of this player to its cell master, CellMaster is nil while the
+// player has none.
+func (cm *Player) CurrentCellMaster() CellMasterConnection {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	return *cm.CellMasterConnection
+}
+
+// SetCellMaster makes the cell master, connected to over the connection, the one this player found for the cell. The
+// connection to the previous cell master is closed.
+func (cm *Player) SetCellMaster(cellMaster *generated.PlayerClient, conn *grpc.ClientConn, cellId string, topologyVersion int64) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	if cm.Connection != nil && cm.Connection != conn {
+		cm.Connection.Close()
+	}
+	cm.CellMaster = cellMaster
+	cm.Connection = conn
+	cm.CellMasterConnection.CellId = cellId
+	cm.CellMasterConnection.TopologyVersion = topologyVersion
+}
+
+// DropCellMaster makes this player forget its cell master, so that it asks for a new one, unless it has already moved
+// on from the given one.
+func (cm *Player) DropCellMaster(cellMaster *generated.PlayerClient) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	if cm.CellMaster == cellMaster {
+		cm.CellMaster = nil
+	}
+}
+
+// SubscribePlayer subscribes a player in the owned cell to it, a subscription to a cell that has since been split or
+// merged is rejected.
 func (cm *Player) SubscribePlayer(ctx context.Context, in *generated.PlayerInfo) (*generated.SubscriptionReply, error) {
+	cm.CellMasterMutex.Lock()
+	stale := cm.staleAddress(in.CellId, in.TopologyVersion)
+	cm.CellMasterMutex.Unlock()
+	if stale != nil {
+		return &generated.SubscriptionReply{Succeeded: false}, cm.rejectStale(stale, in.PosX, in.PosY)
+	}
+
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+
 	subscribedToCell := false
 	cell := cm.Cells
 
@@ -212,26 +681,17 @@ func (cm *Player) SubscribePlayer(ctx context.Context, in *generated.PlayerInfo)
 
 		subscribers := (*cm.SubscribedPlayers)[cell.CellId]
 
-		if _, exists := (subscribers)[in.Ip+":"+strconv.Itoa(int(in.Port))]; !exists {
-
-			conn, err2 := grpc.Dial(ToAddress(in.Ip, in.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
-			if err2 != nil {
-				if constants.DebugMode {
-					println("did not connect to subscriber: %v", err2)
-				}
-				return &generated.SubscriptionReply{Succeeded: false}, errors.New("could not connect")
+		if subscriber, exists := (subscribers)[subscriberKey(in.Ip, in.Port)]; exists {
+			subscriber.PosX, subscriber.PosY = in.PosX, in.PosY
+		} else {
+			subscriberConn, err := dialSubscriber(in)
+			if err != nil {
+				return &generated.SubscriptionReply{Succeeded: false}, err
 			}
 			if true {
 				println("Actually subscribing player: ", in.Port)
 			}
-
-			subscriberConn := PlayerInfoClient{
-				PlayerClient: generated.NewPlayerClient(conn),
-				Port:         int(in.Port),
-				Ip:           in.Ip,
-				ObjectId:     in.ObjectId,
-			}
-			subscribers[in.Ip+":"+strconv.Itoa(int(in.Port))] = &subscriberConn
+			subscribers[subscriberKey(in.Ip, in.Port)] = subscriberConn
 		}
 		subscribedToCell = true
 	}
@@ -243,7 +703,32 @@ func (cm *Player) SubscribePlayer(ctx context.Context, in *generated.PlayerInfo)
 	}
 }
 
+func subscriberKey(ip string, port int32) string {
+	return ip + ":" + strconv.Itoa(int(port))
+}
+
+func dialSubscriber(in *generated.PlayerInfo) (*PlayerInfoClient, error) {
+	conn, err := grpc.Dial(ToAddress(in.Ip, in.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		if constants.DebugMode {
+			println("did not connect to subscriber: %v", err)
+		}
+		return nil, errors.New("could not connect")
+	}
+	return &PlayerInfoClient{
+		PlayerClient: generated.NewPlayerClient(conn),
+		Port:         int(in.Port),
+		Ip:           in.Ip,
+		ObjectId:     in.ObjectId,
+		PosX:         in.PosX,
+		PosY:         in.PosY,
+	}, nil
+}
+
 func (cm *Player) ShouldSplitCell() (shouldSplit bool, cellId string) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+
 	for cellId, playerList := range *cm.SubscribedPlayers {
 		// only split one cell at a time
 		return len(playerList) > cm.splitCellRequirement, cellId
@@ -252,32 +737,76 @@ func (cm *Player) ShouldSplitCell() (shouldSplit bool, cellId string) {
 	return false, ""
 }
 
+// NotifyOfSplitCell makes this player give up the cell and replies with the objects it held for it. A notification for
+// another cell, or for a cell this player has since been granted again, is stale and only collects the objects left
+// behind when the cell was given up.
 func (cm *Player) NotifyOfSplitCell(ctx context.Context, in *generated.Cell) (*generated.NotifyOfSplitCellReply, error) {
+	cm.CellMasterMutex.Lock()
+	if cm.Cells != nil && len(in.CellId) > 0 && (in.CellId != cm.Cells.CellId || in.Epoch < cm.Cells.Epoch) {
+		cm.CellMasterMutex.Unlock()
+		// a cell given up for another one left the objects that are not in the other one behind
+		return &generated.NotifyOfSplitCellReply{Objects: cm.takeReleasedObjects(in.CellId, in.Epoch)}, nil
+	}
+	cm.CellMasterMutex.Unlock()
+
 	cm.DesubscribePlayers()
-	newSubscribedPlayerMap := make(map[string]map[string]*PlayerInfoClient, 0)
-	cm.SubscribedPlayers = &newSubscribedPlayerMap
+	cm.CellMasterMutex.Lock()
+	cellId := in.CellId
+	if cm.Cells != nil {
+		cellId = cm.Cells.CellId
+		cm.releaseObjects(*cm.Cells, nil)
+	}
 	cm.Cells = nil
-	return &generated.NotifyOfSplitCellReply{}, nil
+	cm.CellMasterMutex.Unlock()
+	return &generated.NotifyOfSplitCellReply{Objects: cm.takeReleasedObjects(cellId, in.Epoch)}, nil
 }
 
+// DesubscribePlayers removes every subscriber and tells them to find a new cell master.
 func (cm *Player) DesubscribePlayers() {
-	for _, playerMap := range *cm.SubscribedPlayers {
+	cm.CellMasterMutex.Lock()
+	left := Cell{}
+	if cm.Cells != nil {
+		left = *cm.Cells
+	}
+	cm.CellMasterMutex.Unlock()
+	cm.desubscribePlayers(left)
+}
+
+// desubscribePlayers removes every subscriber and tells them to leave the cell, subscribers that have already been
+// redirected to the cell master of another cell ignore it.
+func (cm *Player) desubscribePlayers(left Cell) {
+	cm.CellMasterMutex.Lock()
+	subscribedPlayers := cm.SubscribedPlayers
+	newSubscribedPlayerMap := make(map[string]map[string]*PlayerInfoClient, 0)
+	cm.SubscribedPlayers = &newSubscribedPlayerMap
+	cm.CellMasterMutex.Unlock()
+
+	for _, playerMap := range *subscribedPlayers {
 		for _, player := range playerMap {
-			println("Desubscribing player ", player.Port)
-			ctx, _ := context.WithTimeout(context.Background(), time.Second)
-			(*player).ChangedCellMaster(ctx, &generated.ChangedCellMasterRequest{})
+			tellToLeave(player, left)
 		}
 	}
 }
 
-func (cm *Player) PlayerIsInOwnedCell(position cellmanager.Position) bool {
+func tellToLeave(player *PlayerInfoClient, left Cell) {
+	println("Desubscribing player ", player.Port)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	(*player).ChangedCellMaster(ctx, &generated.ChangedCellMasterRequest{
+		CellId: left.CellId, TopologyVersion: left.TopologyVersion,
+	})
+}
+
+func (cm *Player) PlayerIsInOwnedCell(position *cellmanager.Position) bool {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
 
 	cell := cm.Cells
 	if cell == nil {
 		return false
 	}
 
-	if cell.CollidesWith(&position) {
+	if cell.CollidesWith(position) {
 		println("player is out of cell with x: ", position.PosX, " y: ", position.PosY, " and cellX: ", cell.PosX, ", cellY: ", cell.PosY, ", width: ", cell.Width, ", height: ", cell.Height)
 		return true
 	}
@@ -285,64 +814,78 @@ func (cm *Player) PlayerIsInOwnedCell(position cellmanager.Position) bool {
 	return false
 }
 
-func (cm *Player) PlayerMightLeaveCellHandle(object generated.SingleObject, cellManager *cellmanager.CellManagerClient) {
-	//cm.CellMasterMutex.Lock()
-	keysAndIndexesToRemove := make(map[string]string, 0)
-
+func (cm *Player) PlayerMightLeaveCellHandle(object *generated.SingleObject, cellManager *cellmanager.CellManagerClient) {
 	println("player might leave cell! with cellid ", object.CellId, " and length: ", len(object.CellId), ", I am responsible for number of cells: 1")
 
 	if len(object.CellId) > 0 {
 		return
 	}
 
+	// remove the leaving player while holding the lock, the other players are contacted afterwards
+	leavingPlayers := make(map[string]*PlayerInfoClient, 0)
+	ownedCellId := ""
+	cm.CellMasterMutex.Lock()
+	if cm.Cells != nil {
+		ownedCellId = cm.Cells.CellId
+	}
 	for cellId, playerList := range *cm.SubscribedPlayers {
 		for playerKey, player := range playerList {
 			println("iteratedID: ", player.ObjectId, ", looking for ID: ", object.ObjectId)
 			if player.ObjectId == object.ObjectId {
-				ctx, _ := context.WithTimeout(context.Background(), time.Second)
-
-				clonedObject := object
-
-				clonedObject.UpdateKey = append(clonedObject.UpdateKey, constants.RemovedKey)
-				clonedObject.NewValue = append(clonedObject.NewValue, "")
-				clonedObject.CellId = cm.Cells.CellId
-
-				//TODO: this is ugly and should be boy scouted. V
-				cm.BroadcastMutatedObjects(ctx, &generated.MultipleObjects{Objects: []*generated.SingleObject{&clonedObject}})
-
-				println("Player left cell, kicking player ", player.Port)
-				ctx, _ = context.WithTimeout(context.Background(), time.Second)
-				_, err := player.ChangedCellMaster(ctx, &generated.ChangedCellMasterRequest{})
-				if err != nil {
-					println("failed to call ChangedCellMaster ", err.Error())
-				}
-
-				if player.ObjectId == cm.ObjectId {
-					cm.stopBeingCellMasterForCell(cellManager, cellId)
-				}
-				ctx, _ = context.WithTimeout(context.Background(), time.Second)
-				_, err = (*cellManager).PlayerLeftCell(ctx, &cellmanager.PlayerInCellRequest{Ip: player.Ip, Port: int32(player.Port), CellId: cellId})
-				if err != nil {
-					println("Failed to remove player from cell: ", cellId, ", ", err.Error())
-				}
-
-				keysAndIndexesToRemove[cellId] = playerKey
-
+				leavingPlayers[cellId] = player
+				delete(playerList, playerKey)
 			}
 		}
 	}
+	cm.CellMasterMutex.Unlock()
+
+	for cellId, player := range leavingPlayers {
+		clonedObject := proto.Clone(object).(*generated.SingleObject)
+		clonedObject.UpdateKey = append(clonedObject.UpdateKey, constants.RemovedKey)
+		clonedObject.NewValue = append(clonedObject.NewValue, "")
+		clonedObject.CellId = ownedCellId
+
+		//TODO: this is ugly and should be boy scouted. V
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		cm.BroadcastMutatedObjects(ctx, &generated.MultipleObjects{Objects: []*generated.SingleObject{clonedObject}})
+		cancel()
+
+		println("Player left cell, kicking player ", player.Port)
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		_, err := player.ChangedCellMaster(ctx, &generated.ChangedCellMasterRequest{})
+		cancel()
+		if err != nil {
+			println("failed to call ChangedCellMaster ", err.Error())
+		}
 
-	for cellKey, playerKey := range keysAndIndexesToRemove {
-		delete((*cm.SubscribedPlayers)[cellKey], playerKey)
+		if player.ObjectId == cm.ObjectId {
+			cm.stopBeingCellMasterForCell(cellManager, cellId)
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+		_, err = (*cellManager).PlayerLeftCell(ctx, &cellmanager.PlayerInCellRequest{Ip: player.Ip, Port: int32(player.Port), CellId: cellId})
+		cancel()
+		if err != nil {
+			println("Failed to remove player from cell: ", cellId, ", ", err.Error())
+		}
 	}
-	//cm.CellMasterMutex.Unlock()
 }
 
 func (cm *Player) stopBeingCellMasterForCell(cellManager *cellmanager.CellManagerClient, cellId string) {
-	ctx, _ := context.WithTimeout(context.Background(), time.Second)
-	(*cellManager).UnregisterCellMaster(ctx, &cellmanager.CellMasterRequest{CellId: cellId})
+	epoch := int64(0)
+	cm.CellMasterMutex.Lock()
+	if cm.Cells != nil && cm.Cells.CellId == cellId {
+		epoch = cm.Cells.Epoch
+	}
+	cm.CellMasterMutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	(*cellManager).UnregisterCellMaster(ctx, &cellmanager.CellMasterRequest{CellId: cellId, Epoch: epoch})
 
+	cm.CellMasterMutex.Lock()
 	if cm.Cells != nil && cellId == cm.Cells.CellId {
 		cm.Cells = nil
+		cm.state = make(map[string]*generated.SingleObject, 0)
 	}
+	cm.CellMasterMutex.Unlock()
 }
@@ -0,0 +1,111 @@
+package objects
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"github.com/golang/protobuf/proto"
+	"sort"
+)
+
+// A cell master keeps the latest value of every key of every object in its cell, updated as the mutations of the cell
+// are broadcast. New subscribers bootstrap from a snapshot of it with GetCellState, and it is handed over with the cell
+// when the cell is split or merged.
+
+// applyMutation stores the keys of the broadcast object. An object that was removed or is no longer in the owned cell
+// is forgotten. CellMasterMutex must be held.
+func (cm *Player) applyMutation(object *generated.SingleObject) {
+	cm.moveSubscriber(object)
+	if cm.Cells == nil || isRemoved(object) ||
+		!cm.Cells.CollidesWith(&cellmanager.Position{PosX: object.PosX, PosY: object.PosY}) {
+		delete(cm.state, object.ObjectId)
+		return
+	}
+
+	stored, ok := cm.state[object.ObjectId]
+	if !ok {
+		stored = &generated.SingleObject{ObjectId: object.ObjectId}
+		cm.state[object.ObjectId] = stored
+	}
+	stored.CellId = cm.Cells.CellId
+	stored.TopologyVersion = cm.Cells.TopologyVersion
+	stored.PosX, stored.PosY = object.PosX, object.PosY
+	if len(object.ObjectType) > 0 {
+		stored.ObjectType = object.ObjectType
+	}
+	for index, key := range object.UpdateKey {
+		if index >= len(object.NewValue) {
+			break
+		}
+		setValue(stored, key, object.NewValue[index])
+	}
+}
+
+// moveSubscriber records the position of the broadcast object as that of the subscriber it is the player object of,
+// so that it can be reported to a recovering cell manager. CellMasterMutex must be held.
+func (cm *Player) moveSubscriber(object *generated.SingleObject) {
+	if cm.Cells == nil || len(object.ObjectId) == 0 || isRemoved(object) {
+		return
+	}
+	for _, subscriber := range (*cm.SubscribedPlayers)[cm.Cells.CellId] {
+		if subscriber.ObjectId == object.ObjectId {
+			subscriber.PosX, subscriber.PosY = object.PosX, object.PosY
+		}
+	}
+}
+
+// setValue sets the value of the key of the stored object, adding the key if the object does not have it yet.
+func setValue(stored *generated.SingleObject, key string, value string) {
+	for index, storedKey := range stored.UpdateKey {
+		if storedKey == key {
+			stored.NewValue[index] = value
+			return
+		}
+	}
+	stored.UpdateKey = append(stored.UpdateKey, key)
+	stored.NewValue = append(stored.NewValue, value)
+}
+
+// isRemoved reports whether the object is broadcast to remove it from the cell.
+func isRemoved(object *generated.SingleObject) bool {
+	for _, key := range object.UpdateKey {
+		if key == constants.RemovedKey {
+			return true
+		}
+	}
+	return false
+}
+
+// storedObjects returns copies of the stored objects ordered by object id, CellMasterMutex must be held.
+func (cm *Player) storedObjects() []*generated.SingleObject {
+	ids := make([]string, 0, len(cm.state))
+	for id := range cm.state {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	stored := make([]*generated.SingleObject, len(ids))
+	for index, id := range ids {
+		stored[index] = proto.Clone(cm.state[id]).(*generated.SingleObject)
+	}
+	return stored
+}
+
+// GetCellState returns the latest value of every key of every object in the owned cell, with the epoch of the cell
+// master. A request addressed to a cell that has since been split or merged is rejected with the owner of the position
+// the request gives.
+func (cm *Player) GetCellState(ctx context.Context, in *generated.Cell) (*generated.MultipleObjects, error) {
+	cm.CellMasterMutex.Lock()
+	if stale := cm.staleAddress(in.CellId, in.TopologyVersion); stale != nil {
+		cm.CellMasterMutex.Unlock()
+		return &generated.MultipleObjects{}, cm.rejectStale(stale, in.PosX, in.PosY)
+	}
+	defer cm.CellMasterMutex.Unlock()
+
+	if cm.Cells == nil || !cm.holdsLease() {
+		return &generated.MultipleObjects{}, errors.New("not cell master of any cell")
+	}
+	return &generated.MultipleObjects{Objects: cm.storedObjects(), Epoch: cm.Cells.Epoch}, nil
+}
@@ -0,0 +1,84 @@
+package objects
+
+import (
+	"context"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// Messages to a cell master are addressed with a cell id and the topology version the sender knows the cell at. A
+// message addressed to a cell that has since been split or merged is rejected with codes.FailedPrecondition and a
+// StaleTopology detail that names the current owner of the position of the message, if it is known.
+
+// SetCellManager lets the cell master look up the current owner of a position when it rejects a stale message.
+func (cm *Player) SetCellManager(cellManager cellmanager.CellManagerClient) {
+	cm.CellMasterMutex.Lock()
+	defer cm.CellMasterMutex.Unlock()
+	cm.cellManager = cellManager
+}
+
+// StaleTopologyOf returns the details of an error a cell master rejected a stale message with, and false if the error
+// is of another kind.
+func StaleTopologyOf(err error) (*generated.StaleTopology, bool) {
+	if status.Code(err) != codes.FailedPrecondition {
+		return nil, false
+	}
+	for _, detail := range status.Convert(err).Details() {
+		if stale, ok := detail.(*generated.StaleTopology); ok {
+			return stale, true
+		}
+	}
+	return nil, false
+}
+
+// staleAddress returns the details of the rejection if a message addressed to the cell at the topology version is
+// not for the owned cell, and nil if it is. A message without a cell id is not addressed and a version of 0 is not
+// checked. CellMasterMutex must be held.
+func (cm *Player) staleAddress(cellId string, topologyVersion int64) *generated.StaleTopology {
+	if len(cellId) == 0 {
+		return nil
+	}
+	if cm.Cells != nil && cm.Cells.CellId == cellId &&
+		(topologyVersion == 0 || topologyVersion == cm.Cells.TopologyVersion) {
+		return nil
+	}
+	return &generated.StaleTopology{CellId: cellId, TopologyVersion: topologyVersion}
+}
+
+// rejectStale fills in the current owner of the position and returns the error to reject the message with. The owned
+// cell is checked first and the cell manager, if there is one, after that. CellMasterMutex must not be held.
+func (cm *Player) rejectStale(stale *generated.StaleTopology, posX int64, posY int64) error {
+	position := &cellmanager.Position{PosX: posX, PosY: posY}
+
+	cm.CellMasterMutex.Lock()
+	if cm.Cells != nil && cm.Cells.CollidesWith(position) {
+		stale.OwnerCellId = cm.Cells.CellId
+		stale.OwnerTopologyVersion = cm.Cells.TopologyVersion
+		stale.OwnerIp = cm.Ip
+		stale.OwnerPort = int32(cm.Port)
+	}
+	cellManager := cm.cellManager
+	cm.CellMasterMutex.Unlock()
+
+	if len(stale.OwnerCellId) == 0 && cellManager != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if owner, err := cellManager.LocateCell(ctx, position); err == nil {
+			stale.OwnerCellId = owner.CellId
+			stale.OwnerTopologyVersion = owner.TopologyVersion
+			stale.OwnerIp = owner.CellMasterIp
+			stale.OwnerPort = owner.CellMasterPort
+		}
+	}
+
+	message := fmt.Sprintf("cell %s at topology version %d is stale", stale.CellId, stale.TopologyVersion)
+	rejection, err := status.New(codes.FailedPrecondition, message).WithDetails(stale)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, message)
+	}
+	return rejection.Err()
+}
@@ -0,0 +1,104 @@
+package objects
+
+import (
+	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+)
+
+// The objects a cell master holds for its cell go with the cell when it is split or merged. A cell master that gives a
+// cell up sets the stored objects and the queued mutations aside and hands them to the cell manager when it is told to
+// release the cell, the cell manager passes them on to the cell masters of the cells that contain them. A cell master
+// that is granted a cell that overlaps the one it gives up keeps the objects that are in both. Every released object is
+// tagged with the cell, the epoch and its place in the release, the cell master that is handed the object drops it if
+// it was handed the same object before. Released objects are only kept in memory, they are lost if the cell master
+// stops before the cell manager collected them.
+
+// releasedCell holds the objects set aside for the cell manager when a cell was given up.
+type releasedCell struct {
+	epoch   int64
+	objects []*generated.SingleObject
+}
+
+// releaseKey identifies a released object, see generated.ReleaseTag.
+type releaseKey struct {
+	cellId   string
+	epoch    int64
+	sequence int32
+}
+
+// release adds the object to those set aside for the cell manager, tagged with where it was released.
+func (released *releasedCell) release(cellId string, object *generated.SingleObject) {
+	object.Released = &generated.ReleaseTag{CellId: cellId, Epoch: released.epoch, Sequence: int32(len(released.objects))}
+	released.objects = append(released.objects, object)
+}
+
+// releaseObjects sets the stored and queued objects that are not in the kept cell aside for the cell manager, the
+// objects that are in it are addressed to it. A nil kept cell releases every object. CellMasterMutex must be held.
+func (cm *Player) releaseObjects(left Cell, kept *Cell) {
+	cm.queueMutex.Lock()
+	defer cm.queueMutex.Unlock()
+
+	keptObjects := make([]*generated.SingleObject, 0)
+	// objects of an earlier grant of the cell that were never collected go with those of the latest one
+	released := cm.releasedObjects[left.CellId]
+	released.epoch = left.Epoch
+	// the stored objects go first, so that the queued mutations are applied on top of them by the next cell master
+	for _, object := range cm.storedObjects() {
+		if kept != nil && kept.CollidesWith(&cellmanager.Position{PosX: object.PosX, PosY: object.PosY}) {
+			stored := cm.state[object.ObjectId]
+			stored.CellId = kept.CellId
+			stored.TopologyVersion = kept.TopologyVersion
+		} else {
+			delete(cm.state, object.ObjectId)
+			released.release(left.CellId, object)
+		}
+	}
+	for _, object := range *cm.MutatingObjects {
+		if kept != nil && kept.CollidesWith(&cellmanager.Position{PosX: object.PosX, PosY: object.PosY}) {
+			object.CellId = kept.CellId
+			object.TopologyVersion = kept.TopologyVersion
+			keptObjects = append(keptObjects, object)
+		} else {
+			released.release(left.CellId, object)
+		}
+	}
+	cm.MutatingObjects = &keptObjects
+	// the objects handed to the cell master are only told apart while it holds the cell they were handed to
+	cm.deliveredObjects = make(map[releaseKey]bool, 0)
+	if len(released.objects) > 0 {
+		cm.releasedObjects[left.CellId] = released
+	}
+}
+
+// takeReleasedObjects returns and forgets the objects set aside when the cell was given up. Objects are only handed
+// out for the grant of the cell they were released from or a later one.
+func (cm *Player) takeReleasedObjects(cellId string, epoch int64) []*generated.SingleObject {
+	cm.queueMutex.Lock()
+	defer cm.queueMutex.Unlock()
+
+	released, ok := cm.releasedObjects[cellId]
+	if !ok || epoch < released.epoch {
+		return nil
+	}
+	delete(cm.releasedObjects, cellId)
+	return released.objects
+}
+
+// acceptDelivery reports whether the object was not handed to the cell master before and records it, objects that
+// were not released by another cell master are always accepted. CellMasterMutex must be held.
+func (cm *Player) acceptDelivery(object *generated.SingleObject) bool {
+	if object.Released == nil {
+		return true
+	}
+
+	cm.queueMutex.Lock()
+	defer cm.queueMutex.Unlock()
+
+	key := releaseKey{cellId: object.Released.CellId, epoch: object.Released.Epoch, sequence: object.Released.Sequence}
+	if cm.deliveredObjects[key] {
+		return false
+	}
+	cm.deliveredObjects[key] = true
+	object.Released = nil
+	return true
+}
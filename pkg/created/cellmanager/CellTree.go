@@ -1,16 +1,19 @@
 package cellmanager
 
 import (
-	"github.com/Frans-Lukas/checkerboard/cmd/constants"
 	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
 	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
 	"time"
 )
 
 type CellTreeNode struct {
-	Parent       *CellTreeNode
-	Children     *[4]*CellTreeNode
+	Parent *CellTreeNode
+	// empty for leaves, otherwise four quadrants or two halves that together cover the node
+	Children     []*CellTreeNode
 	CreationTime *time.Duration
+	// the number of players registered in the node and its descendants, kept up to date by addPlayer, removePlayer,
+	// splits and merges so that it never has to be counted
+	playerCount int
 	*objects.Cell
 }
 
@@ -21,10 +24,9 @@ func CreateCellTree(cell *objects.Cell) *CellTreeNode {
 }
 
 func CreateCellTreeNode(cell *objects.Cell) *CellTreeNode {
-	var children [4]*CellTreeNode
 	timeNow := timeNowInSeconds()
 
-	return &CellTreeNode{Cell: cell, Children: &children, Parent: nil, CreationTime: &timeNow}
+	return &CellTreeNode{Cell: cell, Children: nil, Parent: nil, CreationTime: &timeNow}
 }
 
 func (node *CellTreeNode) CreateChild(cell *objects.Cell) *CellTreeNode {
@@ -38,35 +40,108 @@ func (node *CellTreeNode) isRoot() bool {
 }
 
 func (node *CellTreeNode) isLeaf() bool {
-	return (*node.Children)[0] == nil
+	return len(node.Children) == 0
 }
 
-func (node *CellTreeNode) addChildren(c1 *objects.Cell, c2 *objects.Cell, c3 *objects.Cell, c4 *objects.Cell) {
+func (node *CellTreeNode) addChildren(cells ...*objects.Cell) {
 	node.resetTimer()
-	node.Children[0] = node.CreateChild(c1)
-	node.Children[1] = node.CreateChild(c2)
-	node.Children[2] = node.CreateChild(c3)
-	node.Children[3] = node.CreateChild(c4)
+	node.Children = make([]*CellTreeNode, 0, len(cells))
+	for _, cell := range cells {
+		node.Children = append(node.Children, node.CreateChild(cell))
+	}
 }
 
-func (node *CellTreeNode) findNode(CellId string) *CellTreeNode {
-	if node.CellId == CellId {
-		return node
+// distributePlayers moves the players of a freshly split node into the children at their last known positions.
+func (node *CellTreeNode) distributePlayers() {
+	node.placeInChildren(node.Players)
+	node.Players = make([]objects.Client, 0)
+}
+
+// redistributePlayers registers the players of the children, which must be leaves, in the child that holds their last
+// known position, after the borders between the children were moved.
+func (node *CellTreeNode) redistributePlayers() {
+	players := make([]objects.Client, 0)
+	for _, child := range node.Children {
+		players = append(players, child.Players...)
+		child.Players = make([]objects.Client, 0)
+		child.playerCount = 0
 	}
+	node.placeInChildren(players)
+}
 
-	if node.isLeaf() {
-		return nil
+// placeInChildren appends the players to the children that hold their positions. A position outside the node, such as
+// that of a player registered without one, counts as the closest position inside.
+func (node *CellTreeNode) placeInChildren(players []objects.Client) {
+	for _, player := range players {
+		position := &cellmanager.Position{
+			PosX: clamp(player.PosX, node.PosX, node.PosX+node.Width-1),
+			PosY: clamp(player.PosY, node.PosY, node.PosY+node.Height-1),
+		}
+		for _, child := range node.Children {
+			if child.CollidesWith(position) {
+				child.AppendPlayer(player)
+				child.playerCount++
+				break
+			}
+		}
 	}
+}
 
+// adjustPlayerCount adds the difference to the player count of the node and of every ancestor.
+func (node *CellTreeNode) adjustPlayerCount(difference int) {
+	for current := node; current != nil; current = current.Parent {
+		current.playerCount += difference
+	}
+}
+
+// recountPlayers counts the players of the node and all of its descendants again, it is only needed after building
+// a tree without addPlayer, such as when restoring a snapshot.
+func (node *CellTreeNode) recountPlayers() int {
+	node.playerCount = len(node.Players)
 	for _, child := range node.Children {
-		result := child.findNode(CellId)
+		node.playerCount += child.recountPlayers()
+	}
+	return node.playerCount
+}
 
-		if result != nil {
-			return result
-		}
+func clamp(value int64, min int64, max int64) int64 {
+	if value < min {
+		return min
 	}
+	if value > max {
+		return max
+	}
+	return value
+}
 
-	return nil
+// splitAxes reports along which axes the children of the node are cut, quadrants are cut along both.
+func (node *CellTreeNode) splitAxes() (alongX bool, alongY bool) {
+	for _, child := range node.Children {
+		alongX = alongX || child.PosX != node.PosX
+		alongY = alongY || child.PosY != node.PosY
+	}
+	return alongX, alongY
+}
+
+// moveChildBorders moves the borders between the children, which must be leaves, to the given coordinates. A
+// coordinate along an axis the node is not cut along is ignored.
+func (node *CellTreeNode) moveChildBorders(splitX int64, splitY int64) {
+	alongX, alongY := node.splitAxes()
+	for _, child := range node.Children {
+		if alongX && child.PosX == node.PosX {
+			child.Width = splitX - node.PosX
+		} else if alongX {
+			child.PosX = splitX
+			child.Width = node.PosX + node.Width - splitX
+		}
+
+		if alongY && child.PosY == node.PosY {
+			child.Height = splitY - node.PosY
+		} else if alongY {
+			child.PosY = splitY
+			child.Height = node.PosY + node.Height - splitY
+		}
+	}
 }
 
 func (node *CellTreeNode) findCollidingCell(position *cellmanager.Position) *CellTreeNode {
@@ -84,6 +159,85 @@ func (node *CellTreeNode) findCollidingCell(position *cellmanager.Position) *Cel
 	return nil
 }
 
+func (node *CellTreeNode) depth() int {
+	depth := 0
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		depth++
+	}
+	return depth
+}
+
+// collectNodes returns the nodes of the tree in depth first order, interior nodes are only included if asked for.
+func (node *CellTreeNode) collectNodes(includeInterior bool) []*CellTreeNode {
+	if node.isLeaf() {
+		return []*CellTreeNode{node}
+	}
+
+	nodes := make([]*CellTreeNode, 0)
+	if includeInterior {
+		nodes = append(nodes, node)
+	}
+
+	for _, child := range node.Children {
+		nodes = append(nodes, child.collectNodes(includeInterior)...)
+	}
+	return nodes
+}
+
+func (node *CellTreeNode) toCellInfo() *cellmanager.CellInfo {
+	info := &cellmanager.CellInfo{
+		CellId:      node.CellId,
+		PosX:        node.PosX,
+		PosY:        node.PosY,
+		Width:       node.Width,
+		Height:      node.Height,
+		Depth:       int32(node.depth()),
+		IsLeaf:      node.isLeaf(),
+		PlayerCount: int32(node.countPlayers()),
+		Locked:      node.Locked,
+		Lockee:      node.Lockee,
+		Epoch:       node.Epoch,
+
+		TopologyVersion: node.TopologyVersion,
+	}
+
+	if !node.isRoot() {
+		info.ParentId = node.Parent.CellId
+	}
+
+	if node.CellMaster != nil {
+		info.CellMasterIp = node.CellMaster.Ip
+		info.CellMasterPort = node.CellMaster.Port
+	} else {
+		info.CellMasterPort = -1
+	}
+	return info
+}
+
+// findTouchingLeaves returns every leaf bordering the cell along an edge or in a corner, regardless of depth.
+func (node *CellTreeNode) findTouchingLeaves(cell *objects.Cell) []*CellTreeNode {
+	if node.isLeaf() {
+		if node.Cell != cell && node.Cell.Touches(cell) {
+			return []*CellTreeNode{node}
+		}
+		return []*CellTreeNode{}
+	}
+
+	leaves := make([]*CellTreeNode, 0)
+	for _, child := range node.Children {
+		// a child can only contain neighbours if it touches or overlaps the cell
+		if child.Touches(cell) || child.overlaps(cell) {
+			leaves = append(leaves, child.findTouchingLeaves(cell)...)
+		}
+	}
+	return leaves
+}
+
+func (node *CellTreeNode) overlaps(cell *objects.Cell) bool {
+	return node.PosX < cell.PosX+cell.Width && cell.PosX < node.PosX+node.Width &&
+		node.PosY < cell.PosY+cell.Height && cell.PosY < node.PosY+node.Height
+}
+
 func (node *CellTreeNode) printTree(level int) {
 	for i := 0; i < level; i++ {
 		print("\t")
@@ -109,63 +263,46 @@ func (node *CellTreeNode) printTree(level int) {
 }
 
 func (node *CellTreeNode) countPlayers() int {
-	count := len(node.Players)
-	if node.isLeaf() {
-		return count
-	}
-
-	for _, child := range node.Children {
-		count += child.countPlayers()
-	}
-
-	return count
+	return node.playerCount
 }
 
-func (node *CellTreeNode) killChildren() {
-	node.Children[0] = nil
-	node.Children[1] = nil
-	node.Children[2] = nil
-	node.Children[3] = nil
-}
-
-func (node *CellTreeNode) findMergableCell() (bool, *CellTreeNode) {
-
+// collectPlayers returns the players registered in the node and all of its descendants.
+func (node *CellTreeNode) collectPlayers() []objects.Client {
+	players := make([]objects.Client, 0, len(node.Players))
+	players = append(players, node.Players...)
 	if node.isLeaf() {
-		return false, nil
+		return players
 	}
 
-	println("Checking to merge cell: ", node.CellId, ": ", )
-
-	if node.shouldMerge() {
-		return true, node
+	for _, child := range node.Children {
+		players = append(players, child.collectPlayers()...)
 	}
+	return players
+}
 
-	for _, child := range node.Children {
-		shouldMerge, node := child.findMergableCell()
-		if shouldMerge {
-			return shouldMerge, node
+// findPlayer returns the first node holding a player that matches, together with the matching player.
+func (node *CellTreeNode) findPlayer(matches func(objects.Client) bool) (*CellTreeNode, *objects.Client) {
+	for index, player := range node.Players {
+		if matches(player) {
+			return node, &node.Players[index]
 		}
 	}
 
-	// will never happen
-	return false, nil
-}
-func (node *CellTreeNode) findSplittableCell() (bool, *CellTreeNode) {
-	if node.shouldSplit() && node.isLeaf() {
-		return true, node
-	} else if node.isLeaf() {
-		return false, nil
+	if node.isLeaf() {
+		return nil, nil
 	}
 
 	for _, child := range node.Children {
-		shouldSplit, node := child.findSplittableCell()
-		if shouldSplit {
-			return shouldSplit, node
+		foundNode, player := child.findPlayer(matches)
+		if foundNode != nil {
+			return foundNode, player
 		}
 	}
+	return nil, nil
+}
 
-	// will never happen
-	return false, nil
+func (node *CellTreeNode) killChildren() {
+	node.Children = nil
 }
 
 func timeNowInSeconds() time.Duration {
@@ -182,14 +319,6 @@ func (node *CellTreeNode) resetTimer() {
 	}
 }
 
-func (node *CellTreeNode) shouldMerge() (bool) {
-	println(timeNowInSeconds() - *node.CreationTime)
-	return node.countPlayers() <= constants.MergeCellRequirement && (timeNowInSeconds()-*node.CreationTime) > constants.MergeAgeRequirement
-}
-func (node *CellTreeNode) shouldSplit() (bool) {
-	return node.countPlayers() >= constants.SplitCellRequirement
-}
-
 func (node *CellTreeNode) retrieveChildrenAndCellMasters(cell *objects.Cell) ([]*ClientCellRelation) {
 	for _, player := range node.Cell.Players {
 		if cell.ContainsPlayer(player) {
@@ -209,6 +338,19 @@ func (node *CellTreeNode) retrieveChildrenAndCellMasters(cell *objects.Cell) ([]
 	return childrensCellMasters
 }
 
+// retrieveLeafCellMasters returns the cell master of every leaf below the node, nil for leaves without one.
+func (node *CellTreeNode) retrieveLeafCellMasters() []*ClientCellRelation {
+	if node.isLeaf() {
+		return []*ClientCellRelation{{Client: node.CellMaster, cellId: node.CellId, epoch: node.Epoch}}
+	}
+
+	cellMasters := make([]*ClientCellRelation, 0)
+	for _, child := range node.Children {
+		cellMasters = append(cellMasters, child.retrieveLeafCellMasters()...)
+	}
+	return cellMasters
+}
+
 func (node *CellTreeNode) retrieveCellMasters() []*ClientCellRelation {
 	cms := make([]*ClientCellRelation, 0)
 	if node.CellMaster != nil {
@@ -224,6 +366,3 @@ func (node *CellTreeNode) retrieveCellMasters() []*ClientCellRelation {
 
 	return cms
 }
-
-// Leave cell decrement
-// Join cell increment
@@ -0,0 +1,80 @@
+package cellmanager
+
+import "strings"
+
+// The id of a cell is the path of splits leading to it from the initial cell, one key per split. Quadrants are keyed
+// like quadkeys: 0 is the top left, 1 the top right, 2 the bottom left and 3 the bottom right quadrant. The halves of
+// a cell cut along x are keyed w and e, those of a cell cut along y n and s. A cell keeps its id for as long as it
+// exists, and a tree split the same way always has the same ids.
+const (
+	initialCellId = "initialCell"
+	quadrantKeys  = "0123"
+	westKey       = 'w'
+	eastKey       = 'e'
+	northKey      = 'n'
+	southKey      = 's'
+	splitKeys     = quadrantKeys + "wens"
+)
+
+// childId returns the id of the child of the node with the key.
+func childId(parent *CellTreeNode, key byte) string {
+	if parent.isRoot() {
+		return string(key)
+	}
+	return parent.CellId + string(key)
+}
+
+// cellPath returns the keys of the splits leading to the cell, it returns false if the id is not a path.
+func cellPath(cellId string) (string, bool) {
+	if cellId == initialCellId {
+		return "", true
+	}
+	if len(cellId) == 0 {
+		return "", false
+	}
+	for index := 0; index < len(cellId); index++ {
+		if strings.IndexByte(splitKeys, cellId[index]) < 0 {
+			return "", false
+		}
+	}
+	return cellId, true
+}
+
+// findNode returns the node with the id, or nil if there is none, the tree must be locked.
+func (cellManager *CellManager) findNode(cellId string) *CellTreeNode {
+	return cellManager.cellIndex[cellId]
+}
+
+// indexTree replaces the indexes with ones of the whole tree, it is needed after building a tree without splits, such
+// as when restoring a snapshot. The tree must be write locked.
+func (cellManager *CellManager) indexTree() {
+	cellManager.cellIndex = make(map[string]*CellTreeNode, 0)
+	cellManager.playerIndex = make(map[string]*CellTreeNode, 0)
+	cellManager.objectIndex = make(map[string]*CellTreeNode, 0)
+	if cellManager.CellTree == nil {
+		return
+	}
+	for _, node := range cellManager.CellTree.collectNodes(true) {
+		cellManager.cellIndex[node.CellId] = node
+		cellManager.indexPlayers(node)
+	}
+}
+
+// indexChildren adds the children of a node and the players registered in them to the indexes, after the node was
+// split or players were moved between its children. The tree must be write locked.
+func (cellManager *CellManager) indexChildren(node *CellTreeNode) {
+	for _, child := range node.Children {
+		cellManager.cellIndex[child.CellId] = child
+		cellManager.indexPlayers(child)
+	}
+}
+
+// unindexDescendants removes everything below a node that is about to be merged from the index, the tree must be
+// write locked.
+func (cellManager *CellManager) unindexDescendants(node *CellTreeNode) {
+	for _, descendant := range node.collectNodes(true) {
+		if descendant != node {
+			delete(cellManager.cellIndex, descendant.CellId)
+		}
+	}
+}
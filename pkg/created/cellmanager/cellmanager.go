@@ -9,48 +9,95 @@ import (
 	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
 	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
 	"google.golang.org/grpc"
-	"log"
 	"strconv"
+	"sync"
 	"time"
 )
 
+// CellManager guards CellTree, and every Cell in it, with treeMutex. Handlers that only read the tree take the read
+// lock, everything else takes the write lock. The lock is never held while calling out to players or cell masters,
+// whatever is needed for those calls is copied out of the tree first.
+//
+// Every change to the tree goes through commit, which also writes it to the write-ahead log when persistence is
+// enabled. A replicated CellManager unlocks the tree while commit waits for the other replicas, so the tree may have
+// changed by the time commit returns. Entries are checked again when they are applied for that reason.
 type CellManager struct {
 	generated.CellManagerServer
-	WorldWidth   int64
-	WorldHeight  int64
-	CellIDNumber int64
-	CellTree     *CellTreeNode
+	WorldWidth  int64
+	WorldHeight int64
+	CellTree    *CellTreeNode
+	// every node of the tree by its id, and the leaf every registered player is in by its address and by its object id
+	cellIndex   map[string]*CellTreeNode
+	playerIndex map[string]*CellTreeNode
+	objectIndex map[string]*CellTreeNode
+	treeMutex   *sync.RWMutex
+	persistence *persistence
+	replica     *replica
+	watchers    topologyWatchers
+	policy      SplitMergePolicy
+	loadReports map[string]loadReport
+	splitMode   generated.SplitMode
+	minCellSize int64
+	// when the regions changed by UpdateTopology were changed, by the id of the split or merged cell
+	changedRegions   map[string]time.Time
+	topologyCooldown time.Duration
+	// a split cell is not merged again before its children have existed this long, whatever the policy says
+	minCellLifetime time.Duration
+	// the splits and merges made by UpdateTopology, by the id of the split or merged cell
+	regionMetrics map[string]*regionMetrics
+	flapWindow    time.Duration
+	// the highest epoch a cell master was granted, the highest one a grant was proposed with, and when the lease of
+	// every cell master runs out by cell id
+	lastEpoch     int64
+	proposedEpoch int64
+	leases        map[string]time.Time
+	// the version of the tree, raised whenever a split or merge creates cells. Every cell holds the version it was
+	// created at, so that messages addressed to a cell before it was split or merged can be told apart.
+	topologyVersion int64
+	// objects handed over by a split or merge that could not be delivered yet, they are neither persisted nor
+	// replicated, see transfer.go
+	heldObjects []*objects2.SingleObject
 }
 
 type ClientCellRelation struct {
 	*objects.Client
 	cellId string
+	// the epoch the client was granted the cell with
+	epoch int64
 }
 
 func NewCellManager() CellManager {
-	return CellManager{CellIDNumber: 0}
+	return CellManager{
+		treeMutex:   &sync.RWMutex{},
+		cellIndex:   make(map[string]*CellTreeNode, 0),
+		playerIndex: make(map[string]*CellTreeNode, 0),
+		objectIndex: make(map[string]*CellTreeNode, 0),
+		policy:      DefaultSplitMergePolicy(),
+		loadReports: make(map[string]loadReport, 0),
+		minCellSize: constants.MinCellSize,
+
+		changedRegions:   make(map[string]time.Time, 0),
+		topologyCooldown: time.Second * constants.TopologyCooldown,
+		minCellLifetime:  time.Second * constants.MinCellLifetime,
+		regionMetrics:    make(map[string]*regionMetrics, 0),
+		flapWindow:       time.Second * constants.FlapWindow,
+		leases:           make(map[string]time.Time, 0),
+	}
 }
 
 func (cellManager *CellManager) SetWorldSize(
 	ctx context.Context, in *generated.WorldSize,
 ) (*generated.TransactionSucceeded, error) {
-	cellManager.WorldWidth = in.Width
-	cellManager.WorldHeight = in.Height
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
 
-	if cellManager.CellTree != nil {
-		return &generated.TransactionSucceeded{Succeeded: false}, nil
-	}
+	treeExisted := cellManager.CellTree != nil
 
-	cellManager.CellTree = CreateCellTree(&objects.Cell{
-		CellId:  "initialCell",
-		Players: make([]objects.Client, 0),
-		PosY:    0,
-		PosX:    0,
-		Width:   in.Width,
-		Height:  in.Height,
-	})
+	if err := cellManager.commitContext(ctx, LogEntry{Type: SetWorldSizeEntry, Width: in.Width, Height: in.Height}); err != nil {
+		return &generated.TransactionSucceeded{Succeeded: false}, err
+	}
 
-	return &generated.TransactionSucceeded{Succeeded: true}, nil
+	return &generated.TransactionSucceeded{Succeeded: !treeExisted}, nil
 }
 
 //
@@ -78,6 +125,12 @@ func (cellManager *CellManager) SetWorldSize(
 func (cellManager *CellManager) AddPlayerToCellWithPositions(
 	ctx context.Context, in *generated.PlayerInCellRequestWithPositions,
 ) (*generated.TransactionSucceeded, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.TransactionSucceeded{Succeeded: false}, errors.New("world size has not been set")
+	}
 
 	collidingCell := cellManager.CellTree.findCollidingCell(&generated.Position{PosY: in.PosY, PosX: in.PosX})
 
@@ -92,45 +145,74 @@ func (cellManager *CellManager) AddPlayerToCellWithPositions(
 		Ip:         in.Ip,
 		Port:       in.Port,
 		TrustLevel: 0,
+		ObjectId:   in.ObjectId,
+		PosX:       in.PosX,
+		PosY:       in.PosY,
 	}
 
-	if collidingCell.ContainsPlayer(playerToAdd) {
+	// a registered player is only logged again if it has moved within the cell
+	_, registered := collidingCell.findPlayer(func(client objects.Client) bool {
+		return client.Ip == playerToAdd.Ip && client.Port == playerToAdd.Port
+	})
+	if registered != nil && registered.PosX == playerToAdd.PosX && registered.PosY == playerToAdd.PosY {
 		return &generated.TransactionSucceeded{Succeeded: true}, nil
 	}
+	err := cellManager.commitContext(ctx, LogEntry{
+		Type: AddPlayerEntry, CellId: collidingCell.CellId, Ip: in.Ip, Port: in.Port, ObjectId: in.ObjectId,
+		PosX: in.PosX, PosY: in.PosY,
+	})
+	if err != nil {
+		return &generated.TransactionSucceeded{Succeeded: false}, err
+	}
 	println("Added player successfully")
-	collidingCell.AppendPlayer(playerToAdd)
 	return &generated.TransactionSucceeded{Succeeded: true}, nil
 }
 
 func (cellManager *CellManager) RequestCellMasterWithPositions(
 	ctx context.Context, in *generated.Position,
 ) (*generated.CellMasterReply, error) {
+	cellManager.treeMutex.Lock()
+
+	if cellManager.CellTree == nil {
+		cellManager.treeMutex.Unlock()
+		return &generated.CellMasterReply{Ip: "INVALID POSITION", Port: -1}, errors.New("world size has not been set")
+	}
 
 	collidingCell := cellManager.CellTree.findCollidingCell(in)
 
 	if collidingCell == nil {
+		cellManager.treeMutex.Unlock()
 		println("request cell master: invalid position ")
 		return &generated.CellMasterReply{Ip: "INVALID POSITION", Port: -1}, errors.New("Invalid position: x: " + strconv.FormatInt(in.PosX, 10) + ", y: " + strconv.FormatInt(in.PosY, 10))
 	}
 
 	cm, err := cellManager.selectCellMaster(*collidingCell.Cell)
+	// the cell is copied after selecting, so that it holds the epoch of the cell master
+	cell := *collidingCell.Cell
+	cellManager.treeMutex.Unlock()
+
 	if err != nil {
 		println("request cell master: no player")
 		return &generated.CellMasterReply{Ip: "no player", Port: - 1}, errors.New("no player in cell")
 	}
 	go func() {
-		NotifyOfCellMastership(*cm, *collidingCell.Cell)
+		NotifyOfCellMastership(*cm, cell)
+		// objects held for the cell since a split or merge are handed to its new cell master
+		cellManager.placeObjects(nil)
 	}()
 
 	println("request cell master: found cell master ", cm.Ip, ":", cm.Port)
-	return &generated.CellMasterReply{Ip: cm.Ip, Port: cm.Port}, nil
+	return cm, nil
 }
 
+// NotifyOfCellMastership grants the cell to its cell master under a lease. A cell master that can not be reached
+// keeps the cell until its lease runs out.
 func NotifyOfCellMastership(reply generated.CellMasterReply, cell objects.Cell) {
 	address := fmt.Sprintf(reply.Ip + ":" + strconv.Itoa(int(reply.Port)))
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		println("could not notify of cell mastership: ", err.Error())
+		return
 	}
 	defer conn.Close()
 	c := objects2.NewPlayerClient(conn)
@@ -139,7 +221,9 @@ func NotifyOfCellMastership(reply generated.CellMasterReply, cell objects.Cell)
 	defer cancel()
 
 	newCell := cell.ToGeneratedCell()
-	c.ReceiveCellMastership(ctx, &objects2.CellList{Cells: []*objects2.Cell{&newCell}})
+	c.ReceiveCellMastership(ctx, &objects2.CellList{
+		Cells: []*objects2.Cell{&newCell}, LeaseMillis: int64(cellMasterLease / time.Millisecond),
+	})
 }
 
 //func (cellManager *CellManager) DeleteCell(
@@ -163,41 +247,112 @@ func NotifyOfCellMastership(reply generated.CellMasterReply, cell objects.Cell)
 //	}
 //}
 
-//func (cellManager *CellManager) ListCells(
-//	ctx context.Context, in *generated.ListCellsRequest,
-//) (*generated.ListCellsReply, error) {
-//	cellIds := make([]string, len(*cellManager.Cells))
-//	for index, cell := range *cellManager.Cells {
-//		cellIds[index] = cell.CellId
-//	}
-//	cells := generated.ListCellsReply{CellId: cellIds}
-//	return &cells, nil
-//}
-//
-//func (cellManager *CellManager) ListPlayersInCell(
-//	ctx context.Context, in *generated.ListPlayersRequest,
-//) (*generated.PlayersReply, error) {
-//	playerIps := make([]string, 0)
-//	playerPorts := make([]int32, 0)
-//
-//
-//
-//	for _, cell := range *cellManager.Cells {
-//		if cell.CellId == in.CellId {
-//			for _, player := range cell.Players {
-//				playerIps = append(playerIps, player.Ip)
-//				playerPorts = append(playerPorts, player.Port)
-//			}
-//		}
-//	}
-//	return &generated.PlayersReply{Port: playerPorts, Ip: playerIps}, nil
-//}
+func (cellManager *CellManager) ListCells(
+	ctx context.Context, in *generated.ListCellsRequest,
+) (*generated.ListCellsReply, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.ListCellsReply{}, errors.New("world size has not been set")
+	}
+
+	nodes := cellManager.CellTree.collectNodes(in.IncludeInterior)
+	cellIds := make([]string, len(nodes))
+	cells := make([]*generated.CellInfo, len(nodes))
+	for index, node := range nodes {
+		cellIds[index] = node.CellId
+		cells[index] = node.toCellInfo()
+	}
+	return &generated.ListCellsReply{CellId: cellIds, Cells: cells}, nil
+}
+
+func (cellManager *CellManager) ListPlayersInCell(
+	ctx context.Context, in *generated.ListPlayersRequest,
+) (*generated.PlayersReply, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.PlayersReply{}, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(in.CellId)
+
+	if node == nil {
+		return &generated.PlayersReply{}, errors.New("invalid cell: " + in.CellId)
+	}
+
+	players := node.collectPlayers()
+	playerIps := make([]string, len(players))
+	playerPorts := make([]int32, len(players))
+	playerObjectIds := make([]string, len(players))
+	playerPosX := make([]int64, len(players))
+	playerPosY := make([]int64, len(players))
+	for index, player := range players {
+		playerIps[index] = player.Ip
+		playerPorts[index] = player.Port
+		playerObjectIds[index] = player.ObjectId
+		playerPosX[index] = player.PosX
+		playerPosY[index] = player.PosY
+	}
+	return &generated.PlayersReply{
+		Port: playerPorts, Ip: playerIps, ObjectId: playerObjectIds, PosX: playerPosX, PosY: playerPosY,
+	}, nil
+}
+
+func (cellManager *CellManager) LocatePlayer(
+	ctx context.Context, in *generated.LocatePlayerRequest,
+) (*generated.PlayerLocationReply, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.PlayerLocationReply{Found: false}, errors.New("world size has not been set")
+	}
+
+	var node *CellTreeNode
+	var player *objects.Client
+	if len(in.Ip) > 0 {
+		node, player = cellManager.findPlayerByAddress(in.Ip, in.Port)
+	} else if len(in.ObjectId) > 0 {
+		node, player = cellManager.findPlayerByObjectId(in.ObjectId)
+	} else {
+		return &generated.PlayerLocationReply{Found: false}, errors.New("either an address or an object id is required")
+	}
+
+	if node == nil {
+		return &generated.PlayerLocationReply{Found: false}, nil
+	}
+
+	reply := &generated.PlayerLocationReply{
+		Found:          true,
+		CellId:         node.CellId,
+		CellMasterPort: -1,
+		Ip:             player.Ip,
+		Port:           player.Port,
+		ObjectId:       player.ObjectId,
+		PosX:           player.PosX,
+		PosY:           player.PosY,
+	}
+	if node.CellMaster != nil {
+		reply.CellMasterIp = node.CellMaster.Ip
+		reply.CellMasterPort = node.CellMaster.Port
+	}
+	return reply, nil
+}
 
 func (cellManager *CellManager) RequestCellMaster(
 	ctx context.Context, in *generated.CellMasterRequest,
 ) (*generated.CellMasterReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.CellMasterReply{}, errors.New("world size has not been set")
+	}
 
-	node := cellManager.CellTree.findNode(in.CellId)
+	node := cellManager.findNode(in.CellId)
 
 	if node == nil {
 		return &generated.CellMasterReply{}, errors.New("invalid cell")
@@ -206,6 +361,7 @@ func (cellManager *CellManager) RequestCellMaster(
 	return cellManager.selectCellMaster(*node.Cell)
 }
 
+// selectCellMaster must be called with the tree write locked.
 func (cellManager *CellManager) selectCellMaster(cell objects.Cell) (*generated.CellMasterReply, error) {
 
 	if cell.CellMaster == nil {
@@ -217,32 +373,77 @@ func (cellManager *CellManager) selectCellMaster(cell objects.Cell) (*generated.
 
 		newCM := cell.Players[cmIndex]
 
-		cellToAddTo := cellManager.CellTree.findNode(cell.CellId)
+		cellToAddTo := cellManager.findNode(cell.CellId)
 
 		if cellToAddTo == nil {
 			return &generated.CellMasterReply{Ip: "", Port: -1}, errors.New("empty cell requested a cell master")
 		}
 
-		cellToAddTo.CellMaster = &newCM
+		if err := cellManager.grantCellMastership(cellToAddTo, newCM); err != nil {
+			// the cell may have been granted to another player while the grant was replicated
+			if granted := cellManager.findNode(cell.CellId); granted != nil && granted.CellMaster != nil {
+				return &generated.CellMasterReply{
+					Ip: granted.CellMaster.Ip, Port: granted.CellMaster.Port, CellId: granted.CellId,
+					TopologyVersion: granted.TopologyVersion,
+				}, nil
+			}
+			return &generated.CellMasterReply{Ip: "", Port: -1}, err
+		}
 
-		return &generated.CellMasterReply{Ip: newCM.Ip, Port: newCM.Port}, nil
+		return &generated.CellMasterReply{
+			Ip: newCM.Ip, Port: newCM.Port, CellId: cell.CellId, TopologyVersion: cell.TopologyVersion,
+		}, nil
 	} else {
-		return &generated.CellMasterReply{Ip: cell.CellMaster.Ip, Port: cell.CellMaster.Port}, nil
+		return &generated.CellMasterReply{
+			Ip: cell.CellMaster.Ip, Port: cell.CellMaster.Port, CellId: cell.CellId, TopologyVersion: cell.TopologyVersion,
+		}, nil
+	}
+
+}
+
+// grantCellMastership makes the player cell master of the node with the next epoch in place of the cell master the
+// node has now, the tree must be write locked.
+func (cellManager *CellManager) grantCellMastership(node *CellTreeNode, player objects.Client) error {
+	entry := LogEntry{
+		Type: SetCellMasterEntry, CellId: node.CellId, Ip: player.Ip, Port: player.Port, ObjectId: player.ObjectId,
+		TrustLevel: player.TrustLevel, PosX: player.PosX, PosY: player.PosY, Epoch: cellManager.proposeEpoch(),
 	}
+	if node.CellMaster != nil {
+		entry.ReplacedEpoch = node.Epoch
+	}
+	return cellManager.commit(entry)
+}
 
+// proposeEpoch returns the next epoch to grant a cell with, the tree must be write locked.
+func (cellManager *CellManager) proposeEpoch() int64 {
+	// a replicated grant is only applied later on, the next grant must not reuse its epoch in the meantime
+	cellManager.proposedEpoch = maxInt64(cellManager.proposedEpoch, cellManager.lastEpoch) + 1
+	return cellManager.proposedEpoch
 }
 
 func (cellManager *CellManager) UnregisterCellMaster(
 	ctx context.Context, in *generated.CellMasterRequest,
 ) (*generated.CellMasterStatusReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.CellMasterStatusReply{WasUnregistered: false}, errors.New("world size has not been set")
+	}
 
-	cellToUnregister := cellManager.CellTree.findNode(in.CellId)
+	cellToUnregister := cellManager.findNode(in.CellId)
 
 	if cellToUnregister == nil {
 		return &generated.CellMasterStatusReply{WasUnregistered: false}, errors.New("invalid cell to unregister from")
 	}
 
-	cellToUnregister.Cell.CellMaster = nil
+	if err := checkEpoch(cellToUnregister, in.Epoch); err != nil {
+		return &generated.CellMasterStatusReply{WasUnregistered: false}, err
+	}
+
+	if err := cellManager.commitContext(ctx, LogEntry{Type: UnsetCellMasterEntry, CellId: in.CellId, Epoch: cellToUnregister.Epoch}); err != nil {
+		return &generated.CellMasterStatusReply{WasUnregistered: false}, err
+	}
 	return &generated.CellMasterStatusReply{WasUnregistered: true}, nil
 }
 
@@ -250,38 +451,158 @@ func (cellManager *CellManager) PlayerLeftCell(
 	ctx context.Context, in *generated.PlayerInCellRequest,
 ) (*generated.PlayerStatusReply, error) {
 
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	return cellManager.playerLeftCell(in)
+}
+
+// playerLeftCell must be called with the tree write locked.
+func (cellManager *CellManager) playerLeftCell(in *generated.PlayerInCellRequest) (*generated.PlayerStatusReply, error) {
 	println("Player: ", in.Port, " left cell", in.CellId)
 
-	cellToLeave := cellManager.CellTree.findNode(in.CellId)
+	if cellManager.CellTree == nil {
+		return &generated.PlayerStatusReply{PlayerLeft: false}, errors.New("world size has not been set")
+	}
+
+	cellToLeave := cellManager.findNode(in.CellId)
 
 	if cellToLeave == nil {
 		return &generated.PlayerStatusReply{PlayerLeft: false}, errors.New("invalid cell to delete from")
 	}
 
-	cellToLeave.Cell.DeletePlayer(objects.Client{Port: in.Port, Ip: in.Ip})
+	if err := cellManager.commit(LogEntry{Type: RemovePlayerEntry, CellId: in.CellId, Ip: in.Ip, Port: in.Port}); err != nil {
+		return &generated.PlayerStatusReply{PlayerLeft: false}, err
+	}
 	return &generated.PlayerStatusReply{PlayerLeft: true}, nil
 }
 
 func (cellManager *CellManager) RequestCellNeighbours(
 	ctx context.Context, in *generated.CellNeighbourRequest,
 ) (*generated.CellNeighboursReply, error) {
-	return &generated.CellNeighboursReply{}, nil
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.CellNeighboursReply{}, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(in.CellId)
+
+	if node == nil {
+		return &generated.CellNeighboursReply{}, errors.New("invalid cell: " + in.CellId)
+	}
+
+	if !node.isLeaf() {
+		return &generated.CellNeighboursReply{}, errors.New("cell has been split: " + in.CellId)
+	}
+
+	neighbours := cellManager.CellTree.findTouchingLeaves(node.Cell)
+	reply := &generated.CellNeighboursReply{
+		CellId:     make([]string, len(neighbours)),
+		Neighbours: make([]*generated.CellInfo, len(neighbours)),
+		SharesEdge: make([]bool, len(neighbours)),
+	}
+	for index, neighbour := range neighbours {
+		reply.CellId[index] = neighbour.CellId
+		reply.Neighbours[index] = neighbour.toCellInfo()
+		reply.SharesEdge[index] = neighbour.SharesEdgeWith(node.Cell)
+	}
+	return reply, nil
 }
 
 func (cellManager *CellManager) RequestCellSizeChange(
 	ctx context.Context, in *generated.CellChangeSizeRequest,
 ) (*generated.CellChangeStatusReply, error) {
-	return &generated.CellChangeStatusReply{}, nil
+	cellManager.treeMutex.Lock()
+	cellMastersToInform, cellsToInform, err := cellManager.changeCellSize(in)
+	cellManager.treeMutex.Unlock()
+
+	if err != nil {
+		return &generated.CellChangeStatusReply{Succeeded: false}, err
+	}
+
+	for index, cellMaster := range cellMastersToInform {
+		cellManager.InformCellMasterOfCellChange(cellMaster, cellsToInform[index])
+	}
+
+	return &generated.CellChangeStatusReply{Succeeded: true}, nil
+}
+
+// changeCellSize must be called with the tree write locked, it returns the cell masters that have to be told about
+// their new cells.
+func (cellManager *CellManager) changeCellSize(in *generated.CellChangeSizeRequest) ([]objects.Client, []objects.Cell, error) {
+	if cellManager.CellTree == nil {
+		return nil, nil, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(in.CellId)
+
+	if node == nil {
+		return nil, nil, errors.New("cellId does not match an existing cell")
+	}
+
+	if node.isRoot() {
+		return nil, nil, errors.New("the world cell can not change size")
+	}
+
+	parent := node.Parent
+	alongX, alongY := parent.splitAxes()
+	minSize := cellManager.minCellSize
+	if alongX && (in.NewWidth < minSize || parent.Width-in.NewWidth < minSize) ||
+		alongY && (in.NewHeight < minSize || parent.Height-in.NewHeight < minSize) {
+		return nil, nil, errors.New("new size does not leave room for the neighbouring cells")
+	}
+
+	// halves only have a neighbour across the cut, along the cut they keep the size of their parent
+	if !alongX && in.NewWidth != parent.Width || !alongY && in.NewHeight != parent.Height {
+		return nil, nil, errors.New("a half can only change size across the cut")
+	}
+
+	for _, sibling := range parent.Children {
+		if !sibling.isLeaf() {
+			return nil, nil, errors.New("a neighbouring cell is split")
+		}
+
+		// the write lock keeps the siblings from being locked while they change, but not from being locked already
+		if sibling.Locked {
+			return nil, nil, errors.New("at least one cell is already locked")
+		}
+	}
+
+	if err := cellManager.commit(LogEntry{Type: ResizeCellEntry, CellId: in.CellId, Width: in.NewWidth, Height: in.NewHeight}); err != nil {
+		return nil, nil, err
+	}
+
+	cellMasters := make([]objects.Client, 0)
+	cells := make([]objects.Cell, 0)
+	for _, sibling := range parent.Children {
+		if sibling.CellMaster != nil {
+			cellMasters = append(cellMasters, *sibling.CellMaster)
+			cells = append(cells, *sibling.Cell)
+		}
+	}
+
+	return cellMasters, cells, nil
 }
 
 func (cellManager *CellManager) LockCells(
 	ctx context.Context, in *generated.LockCellsRequest,
 ) (*generated.CellLockStatusReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	return cellManager.lockCells(in)
+}
 
-	var cellsToLock []*CellTreeNode
+// lockCells must be called with the tree write locked.
+func (cellManager *CellManager) lockCells(in *generated.LockCellsRequest) (*generated.CellLockStatusReply, error) {
+	if cellManager.CellTree == nil {
+		return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, errors.New("world size has not been set")
+	}
 
 	for _, cellId := range in.CellId {
-		storedCell := cellManager.CellTree.findNode(cellId)
+		storedCell := cellManager.findNode(cellId)
 		if storedCell == nil {
 			return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, errors.New("invalid cellid given")
 		}
@@ -289,13 +610,10 @@ func (cellManager *CellManager) LockCells(
 		if storedCell.Locked {
 			return &generated.CellLockStatusReply{Locked: true, Lockee: "TODO"}, errors.New("at least one cell is already locked")
 		}
-		cellsToLock = append(cellsToLock, storedCell)
 	}
 
-	for _, treeNode := range cellsToLock {
-
-		treeNode.Cell.Locked = true
-		treeNode.Cell.Lockee = in.SenderCellId
+	if err := cellManager.commit(LogEntry{Type: LockCellsEntry, CellIds: in.CellId, Sender: in.SenderCellId}); err != nil {
+		return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, err
 	}
 
 	return &generated.CellLockStatusReply{Locked: true, Lockee: "TODO"}, nil
@@ -304,11 +622,20 @@ func (cellManager *CellManager) LockCells(
 func (cellManager *CellManager) UnlockCells(
 	ctx context.Context, in *generated.LockCellsRequest,
 ) (*generated.CellLockStatusReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	return cellManager.unlockCells(in)
+}
 
-	var cellsToUnlock []*CellTreeNode
+// unlockCells must be called with the tree write locked.
+func (cellManager *CellManager) unlockCells(in *generated.LockCellsRequest) (*generated.CellLockStatusReply, error) {
+	if cellManager.CellTree == nil {
+		return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, errors.New("world size has not been set")
+	}
 
 	for _, cellId := range in.CellId {
-		storedCell := cellManager.CellTree.findNode(cellId)
+		storedCell := cellManager.findNode(cellId)
 		if storedCell == nil {
 			return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, errors.New("invalid cellid given")
 		}
@@ -316,12 +643,10 @@ func (cellManager *CellManager) UnlockCells(
 		if !storedCell.Locked || storedCell.Lockee != in.SenderCellId {
 			return &generated.CellLockStatusReply{Locked: true, Lockee: "TODO"}, errors.New("at least one cell is already locked")
 		}
-		cellsToUnlock = append(cellsToUnlock, storedCell)
 	}
 
-	for _, j := range cellsToUnlock {
-		j.Cell.Locked = false
-		j.Cell.Lockee = ""
+	if err := cellManager.commit(LogEntry{Type: UnlockCellsEntry, CellIds: in.CellId, Sender: in.SenderCellId}); err != nil {
+		return &generated.CellLockStatusReply{Locked: true, Lockee: "TODO"}, err
 	}
 
 	return &generated.CellLockStatusReply{Locked: false, Lockee: "TODO"}, nil
@@ -330,34 +655,44 @@ func (cellManager *CellManager) UnlockCells(
 func (cellManager *CellManager) DivideCell(
 	ctx context.Context, in *generated.CellRequest,
 ) (*generated.CellChangeStatusReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	return cellManager.divideCell(in)
+}
+
+// divideCell must be called with the tree write locked.
+func (cellManager *CellManager) divideCell(in *generated.CellRequest) (*generated.CellChangeStatusReply, error) {
+	if cellManager.CellTree == nil {
+		return &generated.CellChangeStatusReply{Succeeded: false}, errors.New("world size has not been set")
+	}
 
-	node := cellManager.CellTree.findNode(in.CellId)
+	node := cellManager.findNode(in.CellId)
 
 	if node == nil {
 		return &generated.CellChangeStatusReply{Succeeded: false}, errors.New("cellId does not match an existing cell")
 	}
 
-	cell := &node.Cell
+	if !node.isLeaf() {
+		return &generated.CellChangeStatusReply{Succeeded: false}, errors.New("cell has already been split")
+	}
 
-	if (*cell).Locked {
+	if node.Locked {
 		return &generated.CellChangeStatusReply{Succeeded: false}, errors.New("cell is locked")
 	}
 
-	newWidth := int64(UpDiv(int((*cell).Width), 2))
-	newHeight := int64(UpDiv(int((*cell).Height), 2))
-
-	cell1 := objects.Cell{CellId: strconv.Itoa(int(cellManager.CellIDNumber)), PosX: (*cell).PosX, PosY: (*cell).PosY, Width: newWidth, Height: newHeight, Players: make([]objects.Client, 0)}
-	cellManager.CellIDNumber++
-	cell2 := objects.Cell{CellId: strconv.Itoa(int(cellManager.CellIDNumber)), PosX: (*cell).PosX, PosY: (*cell).PosY + (*cell).Height/2, Width: newWidth, Height: newHeight, Players: make([]objects.Client, 0)}
-	cellManager.CellIDNumber++
-	cell3 := objects.Cell{CellId: strconv.Itoa(int(cellManager.CellIDNumber)), PosX: (*cell).PosX + (*cell).Width/2, PosY: (*cell).PosY, Width: newWidth, Height: newHeight, Players: make([]objects.Client, 0)}
-	cellManager.CellIDNumber++
-	cell4 := objects.Cell{CellId: strconv.Itoa(int(cellManager.CellIDNumber)), PosX: (*cell).PosX + (*cell).Width/2, PosY: (*cell).PosY + (*cell).Height/2, Width: newWidth, Height: newHeight, Players: make([]objects.Client, 0)}
-	cellManager.CellIDNumber++
+	if !cellManager.canDivide(node, in.SplitMode) {
+		return &generated.CellChangeStatusReply{Succeeded: false}, errors.New("cell is too small to be split")
+	}
 
-	node.Players = make([]objects.Client, 0)
+	entry := LogEntry{Type: DivideCellEntry, CellId: in.CellId}
+	if in.SplitMode == generated.SplitMode_BALANCED_HALVES {
+		entry.SplitAxis, entry.SplitAt, _ = node.balancedCut(cellManager.minCellSize)
+	}
 
-	node.addChildren(&cell1, &cell2, &cell3, &cell4)
+	if err := cellManager.commit(entry); err != nil {
+		return &generated.CellChangeStatusReply{Succeeded: false}, err
+	}
 
 	//println("TREEE IS SPLIT, PRINTING: ")
 	//cellManager.CellTree.printTree(0)
@@ -365,6 +700,43 @@ func (cellManager *CellManager) DivideCell(
 	return &generated.CellChangeStatusReply{Succeeded: true}, nil
 }
 
+// addQuadrants splits the leaf into four new cells that tile it exactly, it must be called with the tree write locked.
+func (cellManager *CellManager) addQuadrants(node *CellTreeNode) {
+	node.addChildren(quadrantCells(node)...)
+	node.distributePlayers()
+	cellManager.indexChildren(node)
+}
+
+// quadrantCells returns the four quadrants of the leaf, on odd sizes the right and bottom quadrants get the extra
+// column or row.
+func quadrantCells(node *CellTreeNode) []*objects.Cell {
+	cell := node.Cell
+
+	leftWidth, topHeight := cell.Width/2, cell.Height/2
+	rightWidth, bottomHeight := cell.Width-leftWidth, cell.Height-topHeight
+
+	cell1 := objects.Cell{CellId: childId(node, quadrantKeys[0]), PosX: cell.PosX, PosY: cell.PosY, Width: leftWidth, Height: topHeight, Players: make([]objects.Client, 0)}
+	cell2 := objects.Cell{CellId: childId(node, quadrantKeys[2]), PosX: cell.PosX, PosY: cell.PosY + topHeight, Width: leftWidth, Height: bottomHeight, Players: make([]objects.Client, 0)}
+	cell3 := objects.Cell{CellId: childId(node, quadrantKeys[1]), PosX: cell.PosX + leftWidth, PosY: cell.PosY, Width: rightWidth, Height: topHeight, Players: make([]objects.Client, 0)}
+	cell4 := objects.Cell{CellId: childId(node, quadrantKeys[3]), PosX: cell.PosX + leftWidth, PosY: cell.PosY + topHeight, Width: rightWidth, Height: bottomHeight, Players: make([]objects.Client, 0)}
+
+	return []*objects.Cell{&cell1, &cell2, &cell3, &cell4}
+}
+
+// resizeLeaf gives the leaf its new size by moving the borders it shares with its siblings.
+func resizeLeaf(node *CellTreeNode, newWidth int64, newHeight int64) {
+	parent := node.Parent
+	splitX := parent.PosX + newWidth
+	if node.PosX != parent.PosX {
+		splitX = parent.PosX + parent.Width - newWidth
+	}
+	splitY := parent.PosY + newHeight
+	if node.PosY != parent.PosY {
+		splitY = parent.PosY + parent.Height - newHeight
+	}
+	parent.moveChildBorders(splitX, splitY)
+}
+
 func (cellManager *CellManager) InformCellMasterOfCellChange(cellMaster objects.Client, cell objects.Cell) {
 	address := fmt.Sprintf(cellMaster.Ip + ":" + strconv.Itoa(int(cellMaster.Port)))
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
@@ -383,16 +755,22 @@ func (cellManager *CellManager) InformCellMasterOfCellChange(cellMaster objects.
 		PosY:   cell.PosY,
 		Width:  cell.Width,
 		Height: cell.Height,
+		Epoch:  cell.Epoch,
+
+		TopologyVersion: cell.TopologyVersion,
 	}
 
-	_, err = c.ReceiveCellMastership(ctx, &objects2.CellList{Cells: []*objects2.Cell{&objectCell}})
+	_, err = c.ReceiveCellMastership(ctx, &objects2.CellList{
+		Cells: []*objects2.Cell{&objectCell}, LeaseMillis: int64(cellMasterLease / time.Millisecond),
+	})
 	if err != nil {
 		println("did not succeed to request receiveCellMasterChip with upated cell: %v", err)
 	}
 	return
 }
 
-func (cellManager *CellManager) InformClientOfCellMasterChange(client objects.Client) {
+// InformClientOfCellMasterChange sends the notice to a player whose cell master changed.
+func (cellManager *CellManager) InformClientOfCellMasterChange(client objects.Client, change *objects2.ChangedCellMasterRequest) {
 	address := fmt.Sprintf(client.Ip + ":" + strconv.Itoa(int(client.Port)))
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
 	if err != nil {
@@ -403,70 +781,104 @@ func (cellManager *CellManager) InformClientOfCellMasterChange(client objects.Cl
 	c := objects2.NewPlayerClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	_, err = c.ChangedCellMaster(ctx, &objects2.ChangedCellMasterRequest{})
+	_, err = c.ChangedCellMaster(ctx, change)
 	if err != nil {
 		println("did not succeed to request changedCellMaster: %v", err)
 	}
 	return
 }
 
-func UpDiv(divident int, divisor int) int {
-	return (divident + divisor - 1) / divisor
-}
-
 func (cellManager *CellManager) MergeLoop() {
 	for {
-
-		if cellManager.CellTree != nil {
-
-			println("Printing Tree: ")
-			cellManager.CellTree.printTree(0)
-			println()
-
-			//println("root has count: ", *cellManager.CellTree.count)
-			shouldMerge, cellToMerge := cellManager.CellTree.findMergableCell()
-			shouldSplit, cellToSplit := cellManager.CellTree.findSplittableCell()
-
-			if shouldMerge {
-				println("Merging cell with player count: ", cellToMerge.countPlayers())
-				cellManager.performMerge(cellToMerge.CellId)
-			}
-
-			if shouldSplit {
-				println("Splitting cell, ", cellToSplit.CellId)
-				cellManager.performSplit(cellToSplit.CellId)
-			}
+		if cellManager.IsLeader() {
+			cellManager.UpdateTopology()
 		}
-
 		time.Sleep(time.Second * constants.SplitCellInterval * 2)
 	}
 }
 
+// IsAliveLoop takes the cells away from cell masters whose lease has run out, a cell master that does not answer
+// either is removed from its cell.
 func (cellManager *CellManager) IsAliveLoop() {
-
+	wasLeader := false
 	for {
-		if cellManager.CellTree != nil {
-			cellMasters := cellManager.CellTree.retrieveCellMasters()
-
-			println("Checking cellmasters alive status")
-			for _, cellMaster := range cellMasters {
-				if !isAlive(cellMaster) {
-					println("cellMaster: ", cellMaster.Port, " is dead!")
-					nodeWithDeadCm := cellManager.CellTree.findNode(cellMaster.cellId)
-					cellManager.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{
-						Ip:     cellMaster.Ip,
-						Port:   cellMaster.Port,
-						CellId: cellMaster.cellId,
-					})
-					nodeWithDeadCm.CellMaster = nil
-					cellManager.notifyCellSubscribersOfNewCellMaster(nodeWithDeadCm)
+		// only the leader checks the cell masters, followers could not remove them anyway
+		isLeader := cellManager.IsLeader()
+		cellManager.treeMutex.Lock()
+		if isLeader && !wasLeader {
+			// the renewals were sent to the previous leader
+			cellManager.refreshLeases()
+		}
+		wasLeader = isLeader
+		cellMasters := make([]*ClientCellRelation, 0)
+		if cellManager.CellTree != nil && isLeader {
+			for _, cellMaster := range cellManager.CellTree.retrieveCellMasters() {
+				if !cellManager.leaseExpired(cellMaster.cellId) {
+					continue
 				}
+				client := *cellMaster.Client
+				cellMasters = append(cellMasters, &ClientCellRelation{Client: &client, cellId: cellMaster.cellId})
+			}
+		}
+		cellManager.treeMutex.Unlock()
+
+		println("Checking cellmasters alive status")
+		for _, cellMaster := range cellMasters {
+			if !isAlive(cellMaster) {
+				println("cellMaster: ", cellMaster.Port, " is dead!")
+				cellManager.removeDeadCellMaster(cellMaster)
+			} else {
+				println("cellMaster: ", cellMaster.Port, " let its lease run out")
+				cellManager.revokeExpiredLease(cellMaster)
 			}
 		}
 		time.Sleep(time.Second * constants.AliveCheckInterval)
 	}
 
 }
+
+func (cellManager *CellManager) removeDeadCellMaster(cellMaster *ClientCellRelation) {
+	cellManager.treeMutex.Lock()
+	nodeWithDeadCm := cellManager.findNode(cellMaster.cellId)
+	// the cell may have been merged away or gotten a new cell master while the old one was checked
+	if !isCellMasterOf(nodeWithDeadCm, cellMaster) {
+		cellManager.treeMutex.Unlock()
+		return
+	}
+	epoch := nodeWithDeadCm.Epoch
+
+	_, err := cellManager.playerLeftCell(&generated.PlayerInCellRequest{
+		Ip:     cellMaster.Ip,
+		Port:   cellMaster.Port,
+		CellId: cellMaster.cellId,
+	})
+	if err != nil {
+		println("could not remove dead cell master from its cell: ", err.Error())
+	}
+	// a replicated commit unlocks the tree, the cell may have been changed or granted again in the meantime
+	nodeWithDeadCm = cellManager.findNode(cellMaster.cellId)
+	if !isCellMasterOf(nodeWithDeadCm, cellMaster) || nodeWithDeadCm.Epoch != epoch {
+		cellManager.treeMutex.Unlock()
+		return
+	}
+	err = cellManager.commit(LogEntry{Type: UnsetCellMasterEntry, CellId: cellMaster.cellId, Epoch: epoch})
+	if err != nil {
+		cellManager.treeMutex.Unlock()
+		println("could not remove dead cell master: ", err.Error())
+		return
+	}
+	playersToNotify := nodeWithDeadCm.collectPlayers()
+	cellManager.treeMutex.Unlock()
+
+	cellManager.notifyCellSubscribersOfNewCellMaster(playersToNotify)
+}
+
+// isCellMasterOf reports whether the node exists and has the client as its cell master.
+func isCellMasterOf(node *CellTreeNode, cellMaster *ClientCellRelation) bool {
+	return node != nil && node.CellMaster != nil &&
+		node.CellMaster.Ip == cellMaster.Ip && node.CellMaster.Port == cellMaster.Port
+}
+
 func isAlive(cm *ClientCellRelation) bool {
 	address := fmt.Sprintf(cm.Ip + ":" + strconv.Itoa(int(cm.Port)))
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
@@ -481,60 +893,58 @@ func isAlive(cm *ClientCellRelation) bool {
 	return err == nil && ctx.Err() == nil
 }
 
-func (cellManager *CellManager) performSplit(cellId string) {
-	cellToSplit := cellManager.CellTree.findNode(cellId)
-	cellManager.DivideCell(context.Background(), &generated.CellRequest{CellId: cellId})
-	(cellToSplit).resetTimer()
-
-	cm := cellToSplit.CellMaster
-
-	if cm == nil {
-		return
+// PerformSplit splits the leaf in the split mode and hands its players over to new cell masters of the children, it
+// reports whether the leaf was split.
+func (cellManager *CellManager) PerformSplit(cellId string) bool {
+	cellManager.treeMutex.Lock()
+	plan, err := cellManager.planSplit(cellId)
+	cellManager.treeMutex.Unlock()
+	if err != nil {
+		println("performSplit: ", err.Error())
+		return false
 	}
-
-	cellManager.removeCellMastership(cm, cellId)
+	return cellManager.performHandover(plan)
 }
 
-func (cellManager *CellManager) removeCellMastership(cm *objects.Client, cellId string) {
+// removeCellMastership tells the cell master that the cell was split or merged and returns the objects it held for the
+// cell. A cell master that can not be reached gives the cell up when it fails to renew its lease, and until then its
+// messages carry a stale epoch, its objects are lost.
+func (cellManager *CellManager) removeCellMastership(cm *objects.Client, cellId string, epoch int64) []*objects2.SingleObject {
 	address := fmt.Sprintf(cm.Ip + ":" + strconv.Itoa(int(cm.Port)))
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
 	if err != nil {
-		log.Fatalf("did not connect: %v", err)
+		println("could not remove cell mastership: ", err.Error())
+		return nil
 	}
 	defer conn.Close()
 	cmConn := objects2.NewPlayerClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	_, err = cmConn.NotifyOfSplitCell(ctx, &objects2.Cell{CellId: cellId})
+	reply, err := cmConn.NotifyOfSplitCell(ctx, &objects2.Cell{CellId: cellId, Epoch: epoch})
 	if err != nil {
-		log.Fatalf("failed to removeCellMastership: %v", err)
+		println("could not remove cell mastership: ", err.Error())
+		return nil
 	}
+	return reply.Objects
 }
 
-func (cellManager *CellManager) performMerge(cellId string) {
-	cellToMerge := cellManager.CellTree.findNode(cellId)
-	if cellToMerge.isLeaf() {
-		return
-	}
-
-	cmList := cellToMerge.retrieveChildrenAndCellMasters(cellToMerge.Cell)
-	cellToMerge.killChildren()
-	cellToMerge.resetTimer()
-
-	println("performMerge: removing cellMastership")
-	for _, clientCell := range cmList {
-		if clientCell.Client != nil {
-			cellManager.removeCellMastership(clientCell.Client, clientCell.cellId)
-		}
+// PerformMerge merges the node into a leaf and hands its players over to a new cell master of the leaf, it reports
+// whether the node was merged. Nodes with leaves locked by cell masters are not merged.
+func (cellManager *CellManager) PerformMerge(cellId string) bool {
+	cellManager.treeMutex.Lock()
+	plan, err := cellManager.planMerge(cellId)
+	cellManager.treeMutex.Unlock()
+	if err != nil {
+		println("performMerge: ", err.Error())
+		return false
 	}
-
-	cellManager.notifyCellSubscribersOfNewCellMaster(cellToMerge)
+	return cellManager.performHandover(plan)
 }
 
-func (cellManager *CellManager) notifyCellSubscribersOfNewCellMaster(cellToMerge *CellTreeNode) {
+func (cellManager *CellManager) notifyCellSubscribersOfNewCellMaster(players []objects.Client) {
 	println("informing clients of cellmaster change")
-	for _, player := range cellToMerge.Players {
-		cellManager.InformClientOfCellMasterChange(player)
+	for _, player := range players {
+		cellManager.InformClientOfCellMasterChange(player, &objects2.ChangedCellMasterRequest{})
 	}
 	println("finished")
 }
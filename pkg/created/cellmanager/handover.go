@@ -0,0 +1,307 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// Splits and merges hand their cells over make-before-break. The new cell masters are picked and primed while the
+// changed cells are locked, then the change and the grants to the new cell masters are committed as a single log
+// entry, which also takes the locks of the cells over. No other grant can come between the change and the grants to
+// the cells it creates. Only once the new cell masters hold their cells are the players redirected to them and the old
+// cell masters told to give their cells up, so the old cell masters serve the players until the new ones do. A new
+// cell master that can not be primed is not granted its cell, the players of the cell are told to find their cell
+// master themselves.
+
+// handoverLockee is who the changed cells are locked by while the new cell masters are primed.
+const handoverLockee = "handover"
+
+// handover is a split or merge with the cell masters picked for the cells it creates.
+type handover struct {
+	entry LogEntry
+	// the cells that are locked while the new cell masters are primed
+	lockedIds []string
+	// the cells the change creates, holding the players they will have
+	cells []*objects.Cell
+	// the new cell master of every created cell that has players, by cell id
+	picked map[string]objects.Client
+	// the leaf every player is in before the change, by player address
+	left map[string]objects.Cell
+	// the cell masters of the leaves that are replaced
+	oldCellMasters []*ClientCellRelation
+}
+
+// grant is a created cell committed to its new cell master, with the players to redirect to it.
+type grant struct {
+	cellMaster objects.Client
+	cell       objects.Cell
+	players    []objects.Client
+}
+
+// planSplit picks the cell masters of the children the leaf is split into and locks the leaf, the tree must be write
+// locked.
+func (cellManager *CellManager) planSplit(cellId string) (*handover, error) {
+	if cellManager.CellTree == nil {
+		return nil, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(cellId)
+	if node == nil {
+		return nil, errors.New("cellId does not match an existing cell")
+	}
+
+	// a cell reserved by the planner is unlocked again just before it is split
+	if err := cellManager.releaseReservations([]*CellTreeNode{node}); err != nil {
+		return nil, err
+	}
+
+	if !node.isLeaf() {
+		return nil, errors.New("cell has already been split")
+	}
+
+	if node.Locked {
+		return nil, errors.New("cell is locked")
+	}
+
+	if !cellManager.canDivide(node, cellManager.splitMode) {
+		return nil, errors.New("cell is too small to be split")
+	}
+
+	entry := LogEntry{Type: DivideCellEntry, CellId: cellId}
+	cells := quadrantCells(node)
+	if cellManager.splitMode == generated.SplitMode_BALANCED_HALVES {
+		entry.SplitAxis, entry.SplitAt, _ = node.balancedCut(cellManager.minCellSize)
+		cells = halfCells(node, entry.SplitAxis, entry.SplitAt)
+	}
+
+	// the players are distributed over the children the way the split will, but on a copy of the leaf
+	preview := &CellTreeNode{Cell: &objects.Cell{
+		PosX: node.PosX, PosY: node.PosY, Width: node.Width, Height: node.Height, Players: node.Players,
+	}}
+	for _, cell := range cells {
+		preview.Children = append(preview.Children, CreateCellTreeNode(cell))
+	}
+	preview.distributePlayers()
+
+	plan := newHandover(entry, cells, []*CellTreeNode{node})
+	return plan, cellManager.reserveHandover(plan)
+}
+
+// planMerge picks the cell master of the leaf the node is merged into and locks the leaves of the node, the tree must
+// be write locked.
+func (cellManager *CellManager) planMerge(cellId string) (*handover, error) {
+	if cellManager.CellTree == nil {
+		return nil, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(cellId)
+	if node == nil || node.isLeaf() {
+		return nil, errors.New("cell is not an interior cell")
+	}
+
+	if len(node.lockedLeaves()) > 0 {
+		return nil, errors.New("cell is locked")
+	}
+
+	leaves := node.collectNodes(false)
+	if err := cellManager.releaseReservations(leaves); err != nil {
+		return nil, err
+	}
+
+	// players registered in several of the merged cells are only kept once
+	cell := objects.Cell{
+		CellId: node.CellId, PosX: node.PosX, PosY: node.PosY, Width: node.Width, Height: node.Height,
+		Players: make([]objects.Client, 0),
+	}
+	node.retrieveChildrenAndCellMasters(&cell)
+
+	plan := newHandover(LogEntry{Type: MergeCellEntry, CellId: cellId}, []*objects.Cell{&cell}, leaves)
+	return plan, cellManager.reserveHandover(plan)
+}
+
+// newHandover picks the player with the highest trust level of every created cell as its cell master, the way
+// selectCellMaster would.
+func newHandover(entry LogEntry, cells []*objects.Cell, replaced []*CellTreeNode) *handover {
+	plan := &handover{
+		entry:          entry,
+		cells:          cells,
+		picked:         make(map[string]objects.Client, len(cells)),
+		left:           make(map[string]objects.Cell, 0),
+		oldCellMasters: make([]*ClientCellRelation, 0, len(replaced)),
+	}
+	for _, cell := range cells {
+		if index := cell.SelectNewCellMaster(); index >= 0 {
+			plan.picked[cell.CellId] = cell.Players[index]
+		}
+	}
+	for _, leaf := range replaced {
+		plan.lockedIds = append(plan.lockedIds, leaf.CellId)
+		plan.oldCellMasters = append(plan.oldCellMasters, leaf.retrieveLeafCellMasters()...)
+		for _, player := range leaf.Players {
+			plan.left[objects.ToAddress(player.Ip, player.Port)] = objects.Cell{
+				CellId: leaf.CellId, TopologyVersion: leaf.TopologyVersion,
+			}
+		}
+	}
+	return plan
+}
+
+// reserveHandover locks the cells of the handover, the tree must be write locked.
+func (cellManager *CellManager) reserveHandover(plan *handover) error {
+	_, err := cellManager.lockCells(&generated.LockCellsRequest{CellId: plan.lockedIds, SenderCellId: handoverLockee})
+	return err
+}
+
+// performHandover primes the picked cell masters, makes the change, grants the created cells to the primed cell
+// masters and redirects the players to them, and only then takes the replaced cells from their old cell masters and
+// passes their objects on. It reports whether the change was made.
+func (cellManager *CellManager) performHandover(plan *handover) bool {
+	primed := make(map[string]bool, len(plan.picked))
+	for _, cell := range plan.cells {
+		if cellMaster, ok := plan.picked[cell.CellId]; ok {
+			if err := primeCellMaster(cellMaster, cell); err != nil {
+				println("could not prime cell master of cell ", cell.CellId, ": ", err.Error())
+				continue
+			}
+			primed[cell.CellId] = true
+		}
+	}
+
+	cellManager.treeMutex.Lock()
+	grants, unserved, err := cellManager.commitHandover(plan, primed)
+	cellManager.treeMutex.Unlock()
+	if err != nil {
+		println("could not hand over cell ", plan.entry.CellId, ": ", err.Error())
+		return false
+	}
+
+	for _, grant := range grants {
+		NotifyOfCellMastership(generated.CellMasterReply{Ip: grant.cellMaster.Ip, Port: grant.cellMaster.Port}, grant.cell)
+	}
+	for _, grant := range grants {
+		for _, player := range grant.players {
+			left := plan.left[objects.ToAddress(player.Ip, player.Port)]
+			cellManager.InformClientOfCellMasterChange(player, &objects2.ChangedCellMasterRequest{
+				CellId:             left.CellId,
+				TopologyVersion:    left.TopologyVersion,
+				Ip:                 grant.cellMaster.Ip,
+				Port:               grant.cellMaster.Port,
+				NewCellId:          grant.cell.CellId,
+				NewTopologyVersion: grant.cell.TopologyVersion,
+			})
+		}
+	}
+
+	released := make([]*objects2.SingleObject, 0)
+	for _, oldCellMaster := range plan.oldCellMasters {
+		if oldCellMaster.Client != nil {
+			released = append(released, cellManager.removeCellMastership(
+				oldCellMaster.Client, oldCellMaster.cellId, oldCellMaster.epoch,
+			)...)
+		}
+	}
+	cellManager.placeObjects(released)
+	cellManager.notifyCellSubscribersOfNewCellMaster(unserved)
+	return true
+}
+
+// commitHandover makes the change and grants the created cells to their primed cell masters in one entry, it returns
+// the grants and the players of the created cells that were not granted. The tree must be write locked.
+func (cellManager *CellManager) commitHandover(plan *handover, primed map[string]bool) ([]grant, []objects.Client, error) {
+	changed := false
+	nodes := make([]*CellTreeNode, 0, len(plan.lockedIds))
+	for _, cellId := range plan.lockedIds {
+		node := cellManager.findNode(cellId)
+		if node == nil {
+			changed = true
+			continue
+		}
+		changed = changed || !node.Locked || node.Lockee != handoverLockee
+		nodes = append(nodes, node)
+	}
+	if changed {
+		// the cells still locked for the handover would otherwise stay locked until the planner reserves cells again
+		if err := cellManager.releaseLocks(nodes, handoverLockee); err != nil {
+			println("could not release handover locks: ", err.Error())
+		}
+		return nil, nil, errors.New("the cells were changed while the new cell masters were primed")
+	}
+
+	entry := plan.entry
+	entry.Sender = handoverLockee
+	for _, cell := range plan.cells {
+		// the picked cell master may have moved out of the cell while it was primed, the grant is then skipped
+		if cellMaster, picked := plan.picked[cell.CellId]; picked && primed[cell.CellId] {
+			entry.Grants = append(entry.Grants, CellMasterGrant{
+				CellId: cell.CellId, CellMaster: cellMaster, Epoch: cellManager.proposeEpoch(),
+			})
+		}
+	}
+	if err := cellManager.commit(entry); err != nil {
+		// a replicated commit unlocks the tree, the locked cells are looked up again
+		locked := make([]*CellTreeNode, 0, len(plan.lockedIds))
+		for _, cellId := range plan.lockedIds {
+			if node := cellManager.findNode(cellId); node != nil {
+				locked = append(locked, node)
+			}
+		}
+		if err := cellManager.releaseLocks(locked, handoverLockee); err != nil {
+			println("could not release handover locks: ", err.Error())
+		}
+		return nil, nil, err
+	}
+
+	grants := make([]grant, 0, len(entry.Grants))
+	unserved := make([]objects.Client, 0)
+	for _, cell := range plan.cells {
+		node := cellManager.findNode(cell.CellId)
+		if node == nil {
+			continue
+		}
+		if !isGrantedTo(node, entry.Grants) {
+			unserved = append(unserved, node.Players...)
+			continue
+		}
+		grants = append(grants, grant{
+			cellMaster: *node.CellMaster, cell: *node.Cell, players: append([]objects.Client{}, node.Players...),
+		})
+	}
+	return grants, unserved, nil
+}
+
+// isGrantedTo reports whether the node is still held by the cell master one of the grants gave it to.
+func isGrantedTo(node *CellTreeNode, grants []CellMasterGrant) bool {
+	for _, grant := range grants {
+		if grant.CellId == node.CellId {
+			return node.CellMaster != nil && node.Epoch == grant.Epoch
+		}
+	}
+	return false
+}
+
+// primeCellMaster readies the player to become cell master of the cell, with the players of the cell as subscribers.
+func primeCellMaster(cellMaster objects.Client, cell *objects.Cell) error {
+	conn, err := grpc.Dial(objects.ToAddress(cellMaster.Ip, cellMaster.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	handedOver := cell.ToGeneratedCell()
+	prepared := &objects2.CellHandover{Cell: &handedOver}
+	for _, player := range cell.Players {
+		prepared.Subscribers = append(prepared.Subscribers, &objects2.PlayerInfo{
+			Ip: player.Ip, Port: player.Port, PosX: player.PosX, PosY: player.PosY, ObjectId: player.ObjectId,
+		})
+	}
+	_, err = objects2.NewPlayerClient(conn).PrepareCellMastership(ctx, prepared)
+	return err
+}
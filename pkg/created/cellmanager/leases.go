@@ -0,0 +1,105 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"time"
+)
+
+// Cell mastership is granted as a lease. A cell master gives up its cell when it is told to or can not renew the lease
+// in time, and the cell manager does not replace a cell master that stops answering before its lease has run out.
+// Every grant of a cell gets a higher epoch than the grants before it, cell masters attach their epoch to what they
+// send so that messages from a replaced cell master can be told apart and rejected.
+const cellMasterLease = time.Millisecond * constants.CellMasterLeaseMilli
+
+// leaseGrace is how much longer the cell manager waits for a lease to run out than the cell master, it covers the time
+// the grant takes to reach the cell master.
+const leaseGrace = time.Second
+
+// RenewCellMastership extends the lease of a cell master on its cell. The lease is not granted if the cell has been
+// split, merged or given to another cell master since, the cell master must then give the cell up.
+func (cellManager *CellManager) RenewCellMastership(
+	ctx context.Context, in *generated.LeaseRequest,
+) (*generated.LeaseReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.LeaseReply{Granted: false}, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(in.CellId)
+	if node == nil || !node.isLeaf() || node.CellMaster == nil ||
+		node.CellMaster.Ip != in.Ip || node.CellMaster.Port != in.Port || node.Epoch != in.Epoch {
+		return &generated.LeaseReply{Granted: false}, nil
+	}
+
+	cellManager.grantLease(node)
+	return &generated.LeaseReply{Granted: true, Epoch: node.Epoch, LeaseMillis: int64(cellMasterLease / time.Millisecond)}, nil
+}
+
+// checkEpoch returns an error if a request sent with the epoch is from a replaced cell master of the node. An epoch
+// of 0 is sent by callers that are not cell masters and is not checked. The tree must be locked.
+func checkEpoch(node *CellTreeNode, epoch int64) error {
+	if epoch != 0 && epoch != node.Epoch {
+		return errors.New(fmt.Sprintf("stale epoch %d for cell %s, the current epoch is %d", epoch, node.CellId, node.Epoch))
+	}
+	return nil
+}
+
+// grantLease starts or extends the lease of the cell master of the node, the tree must be write locked.
+func (cellManager *CellManager) grantLease(node *CellTreeNode) {
+	cellManager.leases[node.CellId] = time.Now().Add(cellMasterLease + leaseGrace)
+}
+
+// dropLeases forgets the leases on the leaves of the node, the tree must be write locked.
+func (cellManager *CellManager) dropLeases(node *CellTreeNode) {
+	for _, leaf := range node.collectNodes(false) {
+		delete(cellManager.leases, leaf.CellId)
+	}
+}
+
+// refreshLeases grants every cell master a full lease. Renewals are not replicated, so this is needed whenever the
+// cell manager takes over cell masters it has not heard from, such as after a restart or an election. The tree must
+// be write locked.
+func (cellManager *CellManager) refreshLeases() {
+	cellManager.leases = make(map[string]time.Time, 0)
+	if cellManager.CellTree == nil {
+		return
+	}
+	for _, cellMaster := range cellManager.CellTree.retrieveCellMasters() {
+		cellManager.leases[cellMaster.cellId] = time.Now().Add(cellMasterLease + leaseGrace)
+	}
+}
+
+// leaseExpired reports whether the cell master of the cell has let its lease run out, the tree must be locked.
+func (cellManager *CellManager) leaseExpired(cellId string) bool {
+	expiry, ok := cellManager.leases[cellId]
+	return ok && time.Now().After(expiry)
+}
+
+// revokeExpiredLease takes the cell away from a cell master that has not renewed its lease, its subscribers are told
+// to find the new cell master.
+func (cellManager *CellManager) revokeExpiredLease(cellMaster *ClientCellRelation) {
+	cellManager.treeMutex.Lock()
+	node := cellManager.findNode(cellMaster.cellId)
+	// the lease may have been renewed or the cell given away while the leases were checked
+	if node == nil || node.CellMaster == nil || node.CellMaster.Ip != cellMaster.Ip ||
+		node.CellMaster.Port != cellMaster.Port || !cellManager.leaseExpired(cellMaster.cellId) {
+		cellManager.treeMutex.Unlock()
+		return
+	}
+
+	if err := cellManager.commit(LogEntry{Type: UnsetCellMasterEntry, CellId: cellMaster.cellId, Epoch: node.Epoch}); err != nil {
+		cellManager.treeMutex.Unlock()
+		println("could not revoke expired lease: ", err.Error())
+		return
+	}
+	playersToNotify := node.collectPlayers()
+	cellManager.treeMutex.Unlock()
+
+	cellManager.notifyCellSubscribersOfNewCellMaster(playersToNotify)
+}
@@ -0,0 +1,77 @@
+package cellmanager
+
+import (
+	"context"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"sort"
+	"time"
+)
+
+// regionMetrics counts the splits and merges UpdateTopology made to one cell.
+type regionMetrics struct {
+	splits     int
+	merges     int
+	flaps      int
+	lastType   generated.TopologyEventType
+	lastChange time.Time
+}
+
+// SetFlapWindow sets how soon a change has to undo the previous change of a cell to count as a flap.
+func (cellManager *CellManager) SetFlapWindow(window time.Duration) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.flapWindow = window
+}
+
+// TopologyMetrics returns how often UpdateTopology split, merged and flapped every cell it changed, sorted by id.
+func (cellManager *CellManager) TopologyMetrics(
+	ctx context.Context, in *generated.TopologyMetricsRequest,
+) (*generated.TopologyMetricsReply, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	cellIds := make([]string, 0, len(cellManager.regionMetrics))
+	for cellId := range cellManager.regionMetrics {
+		cellIds = append(cellIds, cellId)
+	}
+	sort.Strings(cellIds)
+
+	reply := &generated.TopologyMetricsReply{Regions: make([]*generated.RegionMetrics, 0, len(cellIds))}
+	for _, cellId := range cellIds {
+		metrics := cellManager.regionMetrics[cellId]
+		reply.Regions = append(reply.Regions, &generated.RegionMetrics{
+			CellId:             cellId,
+			Splits:             int32(metrics.splits),
+			Merges:             int32(metrics.merges),
+			Flaps:              int32(metrics.flaps),
+			SecondsSinceChange: int64(time.Since(metrics.lastChange) / time.Second),
+		})
+		reply.Flaps += int32(metrics.flaps)
+	}
+	return reply, nil
+}
+
+// countChange adds a split or merge of the cell to its metrics, a change that undoes the previous one within the flap
+// window is a flap. The tree must be write locked.
+func (cellManager *CellManager) countChange(cellId string, changeType generated.TopologyEventType) {
+	metrics, ok := cellManager.regionMetrics[cellId]
+	if !ok {
+		metrics = &regionMetrics{}
+		cellManager.regionMetrics[cellId] = metrics
+	}
+
+	if ok && metrics.lastType != changeType && time.Since(metrics.lastChange) < cellManager.flapWindow {
+		metrics.flaps++
+		if constants.DebugMode {
+			println("cell ", cellId, " flapped ", metrics.flaps, " times")
+		}
+	}
+	if changeType == generated.TopologyEventType_SPLIT {
+		metrics.splits++
+	} else {
+		metrics.merges++
+	}
+	metrics.lastType = changeType
+	metrics.lastChange = time.Now()
+}
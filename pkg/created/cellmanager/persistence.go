@@ -0,0 +1,709 @@
+package cellmanager
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const logFileName = "wal.log"
+const snapshotFileName = "snapshot.json"
+
+// The kinds of changes written to the write-ahead log, every change to the tree or to its players is one of these.
+const (
+	SetWorldSizeEntry    = "setWorldSize"
+	AddPlayerEntry       = "addPlayer"
+	RemovePlayerEntry    = "removePlayer"
+	SetCellMasterEntry   = "setCellMaster"
+	UnsetCellMasterEntry = "unsetCellMaster"
+	LockCellsEntry       = "lockCells"
+	UnlockCellsEntry     = "unlockCells"
+	DivideCellEntry      = "divideCell"
+	MergeCellEntry       = "mergeCell"
+	ResizeCellEntry      = "resizeCell"
+	MovePlayersEntry     = "movePlayers"
+)
+
+// LogEntry describes a single change to the CellManager state. Entries only hold the outcome of a decision, such as
+// which player became cell master, so applying the same entries in the same order always gives the same state.
+type LogEntry struct {
+	Index      int64
+	Type       string
+	CellId     string   `json:",omitempty"`
+	CellIds    []string `json:",omitempty"`
+	Ip         string   `json:",omitempty"`
+	Port       int32    `json:",omitempty"`
+	ObjectId   string   `json:",omitempty"`
+	TrustLevel uint32   `json:",omitempty"`
+	PosX       int64    `json:",omitempty"`
+	PosY       int64    `json:",omitempty"`
+	Width      int64    `json:",omitempty"`
+	Height     int64    `json:",omitempty"`
+	Sender     string   `json:",omitempty"`
+	// a divided cell without a split axis is split into quadrants
+	SplitAxis string `json:",omitempty"`
+	SplitAt   int64  `json:",omitempty"`
+	// the registered players and their new positions, a player that has left its leaf is moved to the new one
+	Players []objects.Client `json:",omitempty"`
+	// the epoch a cell master is granted its cell with, or that the removed cell master had
+	Epoch int64 `json:",omitempty"`
+	// the epoch of the cell master a grant replaces, a cell that has a cell master is only granted in its place
+	ReplacedEpoch int64 `json:",omitempty"`
+	// the cells a split or merge grants to their new cell masters, committed together with the change
+	Grants []CellMasterGrant `json:",omitempty"`
+	// the term of the leader that added a replicated entry, and the vote of a replica in its term entries
+	Term     int64  `json:",omitempty"`
+	VotedFor string `json:",omitempty"`
+}
+
+// CellMasterGrant is a cell created by a split or merge that is granted to its new cell master with the epoch.
+type CellMasterGrant struct {
+	CellId     string
+	CellMaster objects.Client
+	Epoch      int64
+}
+
+type snapshotNode struct {
+	objects.Cell
+	Children []*snapshotNode `json:",omitempty"`
+}
+
+type snapshot struct {
+	LastIndex int64
+	// the term of the entry at LastIndex, only replicas have terms
+	LastTerm    int64 `json:",omitempty"`
+	WorldWidth  int64
+	WorldHeight int64
+	LastEpoch   int64
+	// the version of the tree, the versions of the cells are stored with the cells
+	TopologyVersion int64
+	CellTree        *snapshotNode
+}
+
+// persistence keeps the write-ahead log and the snapshots of a CellManager in a data directory. The log only holds
+// the entries committed after the latest snapshot. The log of a replica also holds its term and vote, and the entries
+// it has not applied yet.
+type persistence struct {
+	dataDir              string
+	logFile              *os.File
+	lastIndex            int64
+	lastTerm             int64
+	entriesSinceSnapshot int
+	snapshotInterval     int
+	// what a replica stored besides its state, kept until replication is enabled
+	restored replicaRecords
+}
+
+// EnablePersistence restores the state stored in dataDir, if there is any, and from then on writes every change to
+// it. A snapshot is taken every snapshotInterval changes. It must be called before the CellManager starts serving, and
+// before replication is enabled for a replica that keeps its state.
+func (cellManager *CellManager) EnablePersistence(dataDir string, snapshotInterval int) error {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.persistence != nil {
+		return errors.New("persistence is already enabled")
+	}
+
+	if cellManager.replica != nil {
+		return errors.New("persistence must be enabled before replication")
+	}
+
+	if snapshotInterval <= 0 {
+		return errors.New("snapshot interval must be positive")
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+
+	store := &persistence{dataDir: dataDir, snapshotInterval: snapshotInterval}
+	if err := cellManager.loadSnapshot(store); err != nil {
+		return err
+	}
+
+	tornTail, err := cellManager.replayLog(store)
+	if err != nil {
+		return err
+	}
+
+	store.logFile, err = os.OpenFile(filepath.Join(dataDir, logFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	cellManager.persistence = store
+
+	// a torn last entry would corrupt whatever is appended after it, a snapshot replaces the log
+	if tornTail {
+		return cellManager.takeSnapshot()
+	}
+	return nil
+}
+
+func (cellManager *CellManager) loadSnapshot(store *persistence) error {
+	data, err := ioutil.ReadFile(filepath.Join(store.dataDir, snapshotFileName))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	stored := snapshot{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return errors.New("corrupt snapshot: " + err.Error())
+	}
+
+	cellManager.restoreSnapshot(stored)
+	store.lastIndex = stored.LastIndex
+	store.lastTerm = stored.LastTerm
+	return nil
+}
+
+// restoreSnapshot replaces the state by the stored one, the tree must be write locked.
+func (cellManager *CellManager) restoreSnapshot(stored snapshot) {
+	cellManager.WorldWidth = stored.WorldWidth
+	cellManager.WorldHeight = stored.WorldHeight
+	cellManager.CellTree = nil
+	if stored.CellTree != nil {
+		cellManager.CellTree = restoreNode(stored.CellTree, nil)
+		cellManager.CellTree.recountPlayers()
+	}
+	cellManager.indexTree()
+	cellManager.lastEpoch = stored.LastEpoch
+	cellManager.topologyVersion = stored.TopologyVersion
+	cellManager.refreshLeases()
+}
+
+// replayLog applies the logged entries that are newer than the snapshot, it reports whether the last entry was only
+// partly written.
+func (cellManager *CellManager) replayLog(store *persistence) (bool, error) {
+	logFile, err := os.Open(filepath.Join(store.dataDir, logFileName))
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer logFile.Close()
+
+	reader := bufio.NewReader(logFile)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// only a crash in the middle of a write leaves a line without a newline
+			return len(line) > 0, nil
+		} else if err != nil {
+			return false, err
+		}
+
+		entry := LogEntry{}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return false, errors.New("corrupt log entry after index " + strconv.FormatInt(store.lastIndex, 10) + ": " + err.Error())
+		}
+
+		if entry.Type == termEntry {
+			store.restored.currentTerm = entry.Term
+			store.restored.votedFor = entry.VotedFor
+			continue
+		}
+
+		// entries already in the snapshot are left behind if the log could not be cleared after taking it
+		if entry.Index <= store.lastIndex {
+			continue
+		}
+
+		// a replica applies its entries once it learns that they are committed. An entry replaces the logged entries at
+		// its index and after it, which a newer leader did not have.
+		if entry.Term > 0 {
+			kept := entry.Index - store.lastIndex - 1
+			if kept > int64(len(store.restored.entries)) {
+				return false, errors.New("the replicated entries before index " + strconv.FormatInt(entry.Index, 10) + " are missing")
+			}
+			store.restored.entries = append(store.restored.entries[:kept], entry)
+			continue
+		}
+
+		if err := cellManager.apply(entry); err != nil {
+			return false, errors.New("could not replay log entry " + strconv.FormatInt(entry.Index, 10) + ": " + err.Error())
+		}
+		store.lastIndex = entry.Index
+		store.entriesSinceSnapshot++
+	}
+}
+
+// commit writes the entry to the log and then applies it, it must be called with the tree write locked. Replicated
+// CellManagers apply the entry once a majority of the replicas has it instead, and unlock the tree until then.
+func (cellManager *CellManager) commit(entry LogEntry) error {
+	return cellManager.commitContext(context.Background(), entry)
+}
+
+// commitContext commits the entry like commit, a replicated CellManager stops waiting for the other replicas once ctx
+// is done.
+func (cellManager *CellManager) commitContext(ctx context.Context, entry LogEntry) error {
+	if cellManager.replica != nil {
+		return cellManager.replica.replicate(ctx, entry)
+	}
+
+	store := cellManager.persistence
+	if store == nil {
+		return cellManager.apply(entry)
+	}
+
+	entry.Index = store.lastIndex + 1
+	if err := store.appendRecords(entry); err != nil {
+		return err
+	}
+	store.lastIndex = entry.Index
+	store.entriesSinceSnapshot++
+
+	if err := cellManager.apply(entry); err != nil {
+		return err
+	}
+
+	if store.entriesSinceSnapshot >= store.snapshotInterval {
+		if err := cellManager.takeSnapshot(); err != nil {
+			println("could not take snapshot: ", err.Error())
+		}
+	}
+	return nil
+}
+
+// appendRecords writes the records to the log and syncs it once for all of them.
+func (store *persistence) appendRecords(records ...LogEntry) error {
+	data, err := encodeRecords(records)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+
+	if _, err := store.logFile.Write(data); err != nil {
+		return err
+	}
+	return store.logFile.Sync()
+}
+
+func encodeRecords(records []LogEntry) ([]byte, error) {
+	data := make([]byte, 0)
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		data = append(append(data, line...), '\n')
+	}
+	return data, nil
+}
+
+// snapshotOf describes the whole state as the state after applying the entries up to lastIndex, the tree must be
+// locked.
+func (cellManager *CellManager) snapshotOf(lastIndex int64, lastTerm int64) snapshot {
+	stored := snapshot{
+		LastIndex:   lastIndex,
+		LastTerm:    lastTerm,
+		WorldWidth:  cellManager.WorldWidth,
+		WorldHeight: cellManager.WorldHeight,
+		LastEpoch:   cellManager.lastEpoch,
+
+		TopologyVersion: cellManager.topologyVersion,
+	}
+	if cellManager.CellTree != nil {
+		stored.CellTree = cellManager.CellTree.toSnapshotNode()
+	}
+	return stored
+}
+
+// takeSnapshot stores the whole state and clears the log of what the snapshot holds, it must be called with the tree
+// write locked.
+func (cellManager *CellManager) takeSnapshot() error {
+	store := cellManager.persistence
+	data, err := json.Marshal(cellManager.snapshotOf(store.lastIndex, store.lastTerm))
+	if err != nil {
+		return err
+	}
+
+	// the snapshot replaces the old one in a single rename, so there always is a complete snapshot on disk
+	temporaryFile := filepath.Join(store.dataDir, snapshotFileName+".tmp")
+	if err := writeFileSynced(temporaryFile, data); err != nil {
+		return err
+	}
+
+	if err := os.Rename(temporaryFile, filepath.Join(store.dataDir, snapshotFileName)); err != nil {
+		return err
+	}
+
+	if err := cellManager.rewriteLog(); err != nil {
+		return err
+	}
+	store.entriesSinceSnapshot = 0
+	return nil
+}
+
+// rewriteLog leaves only the records in the log that the snapshot does not hold, which only replicas have. It must be
+// called with the tree write locked.
+func (cellManager *CellManager) rewriteLog() error {
+	store := cellManager.persistence
+	if r := cellManager.replica; r != nil {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		return store.replaceLog(r.durableRecords())
+	}
+	return store.replaceLog(store.restored.records())
+}
+
+// replaceLog swaps the log for one that only holds the records. The new log replaces the old one in a single rename,
+// so that the term, vote and entries of a replica are never lost in between.
+func (store *persistence) replaceLog(records []LogEntry) error {
+	if len(records) == 0 {
+		return store.logFile.Truncate(0)
+	}
+
+	data, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+
+	logPath := filepath.Join(store.dataDir, logFileName)
+	if err := writeFileSynced(logPath+".tmp", data); err != nil {
+		return err
+	}
+
+	if err := os.Rename(logPath+".tmp", logPath); err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	store.logFile.Close()
+	store.logFile = logFile
+	return nil
+}
+
+func writeFileSynced(fileName string, data []byte) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ClosePersistence takes a final snapshot and closes the log. Replication must be stopped first.
+func (cellManager *CellManager) ClosePersistence() error {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.persistence == nil {
+		return nil
+	}
+
+	if cellManager.replica != nil {
+		return errors.New("replication must be stopped before persistence")
+	}
+
+	err := cellManager.takeSnapshot()
+	if closeErr := cellManager.persistence.logFile.Close(); err == nil {
+		err = closeErr
+	}
+	cellManager.persistence = nil
+	return err
+}
+
+func (node *CellTreeNode) toSnapshotNode() *snapshotNode {
+	cell := *node.Cell
+	cell.Players = append([]objects.Client{}, node.Players...)
+	if node.CellMaster != nil {
+		cellMaster := *node.CellMaster
+		cell.CellMaster = &cellMaster
+	}
+
+	stored := &snapshotNode{Cell: cell}
+	if node.isLeaf() {
+		return stored
+	}
+
+	for _, child := range node.Children {
+		stored.Children = append(stored.Children, child.toSnapshotNode())
+	}
+	return stored
+}
+
+func restoreNode(stored *snapshotNode, parent *CellTreeNode) *CellTreeNode {
+	cell := stored.Cell
+	if cell.Players == nil {
+		cell.Players = make([]objects.Client, 0)
+	}
+
+	node := CreateCellTreeNode(&cell)
+	node.Parent = parent
+	for _, child := range stored.Children {
+		node.Children = append(node.Children, restoreNode(child, node))
+	}
+	return node
+}
+
+// apply performs the change described by the entry, it must be called with the tree write locked. Entries are
+// checked before they are committed, but a replicated entry may be applied after changes that were committed while it
+// was replicated. An entry that no longer fits the state is rejected before anything is changed, so that every replica
+// skips it alike.
+func (cellManager *CellManager) apply(entry LogEntry) error {
+	if entry.Type == NoOpEntry {
+		return nil
+	}
+
+	if entry.Type == SetWorldSizeEntry {
+		cellManager.WorldWidth = entry.Width
+		cellManager.WorldHeight = entry.Height
+
+		if cellManager.CellTree == nil {
+			cellManager.CellTree = CreateCellTree(&objects.Cell{
+				CellId:  initialCellId,
+				Players: make([]objects.Client, 0),
+				PosY:    0,
+				PosX:    0,
+				Width:   entry.Width,
+				Height:  entry.Height,
+
+				TopologyVersion: cellManager.nextTopologyVersion(),
+			})
+			cellManager.indexTree()
+			cellManager.publishTopologyChange(generated.TopologyEventType_WORLD_CREATED, cellManager.CellTree.CellId,
+				[]*CellTreeNode{cellManager.CellTree})
+		}
+		return nil
+	}
+
+	if cellManager.CellTree == nil {
+		return errors.New("world size has not been set")
+	}
+
+	if entry.Type == LockCellsEntry || entry.Type == UnlockCellsEntry {
+		nodes := make([]*CellTreeNode, 0, len(entry.CellIds))
+		for _, cellId := range entry.CellIds {
+			node := cellManager.findNode(cellId)
+			if node == nil {
+				return errors.New("invalid cellid given")
+			}
+			if entry.Type == LockCellsEntry && node.Locked {
+				return errors.New("at least one cell is already locked")
+			}
+			if entry.Type == UnlockCellsEntry && (!node.Locked || node.Lockee != entry.Sender) {
+				return errors.New("at least one cell is not locked by " + entry.Sender)
+			}
+			nodes = append(nodes, node)
+		}
+		for _, node := range nodes {
+			node.Locked = entry.Type == LockCellsEntry
+			node.Lockee = ""
+			if node.Locked {
+				node.Lockee = entry.Sender
+			}
+		}
+		if entry.Type == LockCellsEntry {
+			cellManager.publishTopologyChange(generated.TopologyEventType_LOCKED, "", nodes)
+		} else {
+			cellManager.publishTopologyChange(generated.TopologyEventType_UNLOCKED, "", nodes)
+		}
+		return nil
+	}
+
+	if entry.Type == MovePlayersEntry {
+		// a player that left while the entry was replicated is skipped, the others are still moved
+		for _, player := range entry.Players {
+			if err := cellManager.movePlayer(player); err != nil {
+				println("could not move player: ", err.Error())
+			}
+		}
+		return nil
+	}
+
+	node := cellManager.findNode(entry.CellId)
+	if node == nil {
+		return errors.New("invalid cell: " + entry.CellId)
+	}
+
+	if err := cellManager.checkEntry(node, entry); err != nil {
+		return err
+	}
+
+	switch entry.Type {
+	case AddPlayerEntry:
+		cellManager.addPlayer(node, entry.client())
+	case RemovePlayerEntry:
+		cellManager.removePlayer(node, entry.client())
+	case SetCellMasterEntry:
+		cellManager.setCellMaster(node, entry.client(), entry.Epoch)
+	case UnsetCellMasterEntry:
+		node.CellMaster = nil
+		cellManager.dropLeases(node)
+		cellManager.forgetLoadReports(node)
+		cellManager.publishTopologyChange(generated.TopologyEventType_CELL_MASTER_CHANGED, node.CellId, []*CellTreeNode{node})
+	case DivideCellEntry:
+		// the cell master of the split cell is told to give it up, the children get cell masters of their own
+		cellManager.takeOverLocks([]*CellTreeNode{node}, entry.Sender)
+		node.CellMaster = nil
+		cellManager.dropLeases(node)
+		cellManager.forgetLoadReports(node)
+		if entry.SplitAxis == "" {
+			cellManager.addQuadrants(node)
+		} else {
+			cellManager.addHalves(node, entry.SplitAxis, entry.SplitAt)
+		}
+		version := cellManager.nextTopologyVersion()
+		for _, child := range node.Children {
+			child.TopologyVersion = version
+		}
+		cellManager.publishTopologyChange(generated.TopologyEventType_SPLIT, node.CellId, append([]*CellTreeNode{node}, node.Children...))
+		cellManager.applyGrants(entry.Grants)
+	case MergeCellEntry:
+		// the merged cell gets a new cell master, the one it had before it was split has given it up
+		cellManager.takeOverLocks(node.collectNodes(false), entry.Sender)
+		node.CellMaster = nil
+		cellManager.dropLeases(node)
+		cellManager.forgetLoadReports(node)
+		cellManager.unindexDescendants(node)
+		node.retrieveChildrenAndCellMasters(node.Cell)
+		node.killChildren()
+		cellManager.indexPlayers(node)
+		// players registered in several of the merged cells are only kept once
+		node.adjustPlayerCount(len(node.Players) - node.playerCount)
+		node.resetTimer()
+		node.TopologyVersion = cellManager.nextTopologyVersion()
+		cellManager.publishTopologyChange(generated.TopologyEventType_MERGE, node.CellId, []*CellTreeNode{node})
+		cellManager.applyGrants(entry.Grants)
+	case ResizeCellEntry:
+		resizeLeaf(node, entry.Width, entry.Height)
+		node.Parent.redistributePlayers()
+		cellManager.indexChildren(node.Parent)
+		cellManager.publishTopologyChange(generated.TopologyEventType_RESIZE, node.CellId, node.Parent.Children)
+	default:
+		return errors.New("unknown log entry type: " + entry.Type)
+	}
+	return nil
+}
+
+// checkEntry returns an error if the entry no longer fits the node it changes, the tree must be locked.
+func (cellManager *CellManager) checkEntry(node *CellTreeNode, entry LogEntry) error {
+	switch entry.Type {
+	case AddPlayerEntry, SetCellMasterEntry:
+		if !node.isLeaf() {
+			return errors.New("cell has been split: " + node.CellId)
+		}
+		if entry.Type == SetCellMasterEntry && entry.Epoch <= cellManager.lastEpoch {
+			return errors.New("epoch " + strconv.FormatInt(entry.Epoch, 10) + " has already been granted")
+		}
+		// a grant for a cell without a cell master must not override one made while it was replicated
+		if entry.Type == SetCellMasterEntry && node.CellMaster != nil && entry.ReplacedEpoch != node.Epoch {
+			return errors.New("cell " + node.CellId + " has been granted to another cell master")
+		}
+	case UnsetCellMasterEntry:
+		// an entry without an epoch removes whichever cell master the cell has
+		if entry.Epoch != 0 && entry.Epoch != node.Epoch {
+			return errors.New("the cell master of " + node.CellId + " has been replaced")
+		}
+	case DivideCellEntry:
+		if !node.isLeaf() || isLockedAgainst(node, entry.Sender) {
+			return errors.New("cell has been split or locked: " + node.CellId)
+		}
+		return cellManager.checkGrants(entry.Grants)
+	case MergeCellEntry:
+		if node.isLeaf() {
+			return errors.New("cell has been merged: " + node.CellId)
+		}
+		for _, leaf := range node.lockedLeaves() {
+			if isLockedAgainst(leaf, entry.Sender) {
+				return errors.New("cell has been locked: " + leaf.CellId)
+			}
+		}
+		return cellManager.checkGrants(entry.Grants)
+	case ResizeCellEntry:
+		if node.isRoot() {
+			return errors.New("the world cell can not change size")
+		}
+		for _, sibling := range node.Parent.Children {
+			if !sibling.isLeaf() || sibling.Locked {
+				return errors.New("a neighbouring cell has been split or locked")
+			}
+		}
+	}
+	return nil
+}
+
+// isLockedAgainst reports whether the node is locked by someone other than the sender of an entry, a split or merge
+// made by whoever locked its cells takes their locks over.
+func isLockedAgainst(node *CellTreeNode, sender string) bool {
+	return node.Locked && (sender == "" || node.Lockee != sender)
+}
+
+// takeOverLocks unlocks those of the nodes that are locked by the sender of a split or merge before it changes them,
+// the tree must be write locked.
+func (cellManager *CellManager) takeOverLocks(nodes []*CellTreeNode, sender string) {
+	unlocked := make([]*CellTreeNode, 0, len(nodes))
+	for _, node := range nodes {
+		if sender != "" && node.Locked && node.Lockee == sender {
+			node.Locked = false
+			node.Lockee = ""
+			unlocked = append(unlocked, node)
+		}
+	}
+	if len(unlocked) > 0 {
+		cellManager.publishTopologyChange(generated.TopologyEventType_UNLOCKED, "", unlocked)
+	}
+}
+
+// checkGrants returns an error if a grant of a split or merge reuses an epoch that has already been granted.
+func (cellManager *CellManager) checkGrants(grants []CellMasterGrant) error {
+	for _, grant := range grants {
+		if grant.Epoch <= cellManager.lastEpoch {
+			return errors.New("epoch " + strconv.FormatInt(grant.Epoch, 10) + " has already been granted")
+		}
+	}
+	return nil
+}
+
+// applyGrants grants the cells created by a split or merge to their cell masters, the tree must be write locked. A
+// cell master that is no longer a player of its cell is not granted the cell, the way every replica skips it alike.
+func (cellManager *CellManager) applyGrants(grants []CellMasterGrant) {
+	for _, grant := range grants {
+		node := cellManager.findNode(grant.CellId)
+		if node == nil || !node.isLeaf() || !node.ContainsPlayer(grant.CellMaster) {
+			continue
+		}
+		cellManager.setCellMaster(node, grant.CellMaster, grant.Epoch)
+	}
+}
+
+// setCellMaster makes the player cell master of the node with the epoch, the tree must be write locked.
+func (cellManager *CellManager) setCellMaster(node *CellTreeNode, cellMaster objects.Client, epoch int64) {
+	node.CellMaster = &cellMaster
+	node.Epoch = epoch
+	if epoch > cellManager.lastEpoch {
+		cellManager.lastEpoch = epoch
+	}
+	cellManager.grantLease(node)
+	cellManager.forgetLoadReports(node)
+	cellManager.publishTopologyChange(generated.TopologyEventType_CELL_MASTER_CHANGED, node.CellId, []*CellTreeNode{node})
+}
+
+func (entry LogEntry) client() objects.Client {
+	return objects.Client{
+		Ip: entry.Ip, Port: entry.Port, ObjectId: entry.ObjectId, TrustLevel: entry.TrustLevel, PosX: entry.PosX,
+		PosY: entry.PosY,
+	}
+}
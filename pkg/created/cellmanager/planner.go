@@ -0,0 +1,262 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"strings"
+	"time"
+)
+
+// plannerLockee is who the cells reserved for a planned split or merge are locked by.
+const plannerLockee = "topologyPlanner"
+
+// topologyPlan holds the splits and merges for one round of UpdateTopology. No cell is part of more than one change:
+// only the topmost nodes are merged and nothing below a merged node is split.
+type topologyPlan struct {
+	// leaves to split
+	Splits []string
+	// interior nodes to merge into leaves
+	Merges []string
+	// what the policy was told about each planned cell
+	stats map[string]CellStats
+}
+
+// SetTopologyCooldown sets how long a region that was split or merged by UpdateTopology is left alone, so that it is
+// not changed straight back.
+func (cellManager *CellManager) SetTopologyCooldown(cooldown time.Duration) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.topologyCooldown = cooldown
+}
+
+// SetMinCellLifetime sets how long the children of a split cell exist before the cell may be merged again.
+func (cellManager *CellManager) SetMinCellLifetime(lifetime time.Duration) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.minCellLifetime = lifetime
+}
+
+// PlanTopology returns the splits and merges UpdateTopology would make now and why, without making them.
+func (cellManager *CellManager) PlanTopology(
+	ctx context.Context, in *generated.PlanTopologyRequest,
+) (*generated.PlanTopologyReply, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.PlanTopologyReply{}, errors.New("world size has not been set")
+	}
+
+	plan := newTopologyPlan()
+	cellManager.planNode(cellManager.CellTree, &plan)
+
+	reply := &generated.PlanTopologyReply{Policy: fmt.Sprintf("%T%+v", cellManager.policy, cellManager.policy)}
+	for _, cellId := range plan.Merges {
+		reply.Changes = append(reply.Changes, cellManager.plannedChange(generated.TopologyEventType_MERGE, plan.stats[cellId]))
+	}
+	for _, cellId := range plan.Splits {
+		reply.Changes = append(reply.Changes, cellManager.plannedChange(generated.TopologyEventType_SPLIT, plan.stats[cellId]))
+	}
+	return reply, nil
+}
+
+func newTopologyPlan() topologyPlan {
+	return topologyPlan{stats: make(map[string]CellStats, 0)}
+}
+
+// plannedChange describes a change to the cell with the given stats, the tree must be locked.
+func (cellManager *CellManager) plannedChange(changeType generated.TopologyEventType, stats CellStats) *generated.PlannedChange {
+	reason := fmt.Sprintf("%T asks for it", cellManager.policy)
+	if explainer, ok := cellManager.policy.(SplitMergeExplainer); ok {
+		reason = explainer.Explain(stats)
+	}
+
+	return &generated.PlannedChange{
+		Type:               changeType,
+		CellId:             stats.CellId,
+		Reason:             reason,
+		PlayerCount:        int32(stats.PlayerCount),
+		AgeSeconds:         int64(stats.Age / time.Second),
+		MutationsPerSecond: stats.MutationsPerSecond,
+		Load:               stats.Load,
+		Reported:           stats.Reported,
+	}
+}
+
+// planTopology returns every split and merge the policy asks for that can be made now. Locked cells are left alone, a
+// leaf merged within the cooldown is not split and a node is not merged while anything in it was split within the
+// cooldown.
+func (cellManager *CellManager) planTopology() topologyPlan {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	plan := newTopologyPlan()
+	if cellManager.CellTree != nil {
+		cellManager.planNode(cellManager.CellTree, &plan)
+	}
+	return plan
+}
+
+// planNode adds the changes for the node and everything below it to the plan, the tree must be locked.
+func (cellManager *CellManager) planNode(node *CellTreeNode, plan *topologyPlan) {
+	if node.isLeaf() {
+		if !node.Locked && !cellManager.coolingDown(node, false) && cellManager.shouldSplit(node) {
+			plan.Splits = append(plan.Splits, node.CellId)
+			plan.stats[node.CellId] = cellManager.cellStats(node)
+		}
+		return
+	}
+
+	if len(node.lockedLeaves()) == 0 && !cellManager.coolingDown(node, true) && cellManager.shouldMerge(node) {
+		plan.Merges = append(plan.Merges, node.CellId)
+		plan.stats[node.CellId] = cellManager.cellStats(node)
+		return
+	}
+
+	for _, child := range node.Children {
+		cellManager.planNode(child, plan)
+	}
+}
+
+// coolingDown reports whether the node, or if asked anything below it, was changed within the cooldown. The tree must
+// be locked.
+func (cellManager *CellManager) coolingDown(node *CellTreeNode, includeDescendants bool) bool {
+	for cellId, changed := range cellManager.changedRegions {
+		if time.Since(changed) >= cellManager.topologyCooldown {
+			continue
+		}
+		if cellId == node.CellId || includeDescendants && (node.isRoot() || strings.HasPrefix(cellId, node.CellId)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockedLeaves returns the leaves of the node that are locked by anyone but the planner.
+func (node *CellTreeNode) lockedLeaves() []*CellTreeNode {
+	locked := make([]*CellTreeNode, 0)
+	for _, leaf := range node.collectNodes(false) {
+		if leaf.Locked && leaf.Lockee != plannerLockee {
+			locked = append(locked, leaf)
+		}
+	}
+	return locked
+}
+
+// UpdateTopology makes every split and merge of the current plan. The cells of all changes are locked first, so that
+// cell masters can not resize them while the changes are made one by one.
+func (cellManager *CellManager) UpdateTopology() {
+	if constants.DebugMode {
+		cellManager.treeMutex.RLock()
+		if cellManager.CellTree != nil {
+			println("Printing Tree: ")
+			cellManager.CellTree.printTree(0)
+			println()
+		}
+		cellManager.treeMutex.RUnlock()
+	}
+
+	plan := cellManager.reserve(cellManager.planTopology())
+
+	for _, cellId := range plan.Merges {
+		if cellManager.PerformMerge(cellId) {
+			cellManager.markChanged(cellId, generated.TopologyEventType_MERGE)
+		}
+	}
+	for _, cellId := range plan.Splits {
+		if cellManager.PerformSplit(cellId) {
+			cellManager.markChanged(cellId, generated.TopologyEventType_SPLIT)
+		}
+	}
+
+	// objects a cell master could not take are offered again every tick, not only once a cell is granted
+	cellManager.placeObjects(nil)
+}
+
+// reserve locks the cells of every change in the plan and returns the changes whose cells could be locked. The tree
+// may have changed since the plan was made, so every change is checked again.
+func (cellManager *CellManager) reserve(plan topologyPlan) topologyPlan {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	reserved := topologyPlan{}
+	if cellManager.CellTree == nil {
+		return reserved
+	}
+
+	// reservations and handover locks are only left behind if the cell manager stopped while changing the tree
+	for _, lockee := range []string{plannerLockee, handoverLockee} {
+		if err := cellManager.releaseLocks(cellManager.CellTree.collectNodes(true), lockee); err != nil {
+			println("could not release reservations: ", err.Error())
+			return reserved
+		}
+	}
+
+	for _, cellId := range plan.Merges {
+		node := cellManager.findNode(cellId)
+		if node == nil || node.isLeaf() {
+			continue
+		}
+		if err := cellManager.reserveCells(node.collectNodes(false)); err == nil {
+			reserved.Merges = append(reserved.Merges, cellId)
+		}
+	}
+	for _, cellId := range plan.Splits {
+		node := cellManager.findNode(cellId)
+		if node == nil || !node.isLeaf() {
+			continue
+		}
+		if err := cellManager.reserveCells([]*CellTreeNode{node}); err == nil {
+			reserved.Splits = append(reserved.Splits, cellId)
+		}
+	}
+	return reserved
+}
+
+// reserveCells locks the cells for the planner, the tree must be write locked.
+func (cellManager *CellManager) reserveCells(nodes []*CellTreeNode) error {
+	cellIds := make([]string, len(nodes))
+	for index, node := range nodes {
+		cellIds[index] = node.CellId
+	}
+	_, err := cellManager.lockCells(&generated.LockCellsRequest{CellId: cellIds, SenderCellId: plannerLockee})
+	return err
+}
+
+// releaseReservations unlocks those of the nodes that are locked by the planner, the tree must be write locked.
+func (cellManager *CellManager) releaseReservations(nodes []*CellTreeNode) error {
+	return cellManager.releaseLocks(nodes, plannerLockee)
+}
+
+// releaseLocks unlocks those of the nodes that are locked by the lockee, the tree must be write locked.
+func (cellManager *CellManager) releaseLocks(nodes []*CellTreeNode, lockee string) error {
+	cellIds := make([]string, 0)
+	for _, node := range nodes {
+		if node.Locked && node.Lockee == lockee {
+			cellIds = append(cellIds, node.CellId)
+		}
+	}
+	if len(cellIds) == 0 {
+		return nil
+	}
+	_, err := cellManager.unlockCells(&generated.LockCellsRequest{CellId: cellIds, SenderCellId: lockee})
+	return err
+}
+
+// markChanged starts the cooldown of the region of the cell and counts the change, and forgets regions that have
+// cooled down.
+func (cellManager *CellManager) markChanged(cellId string, changeType generated.TopologyEventType) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	for changedId, changed := range cellManager.changedRegions {
+		if time.Since(changed) >= cellManager.topologyCooldown {
+			delete(cellManager.changedRegions, changedId)
+		}
+	}
+	cellManager.changedRegions[cellId] = time.Now()
+	cellManager.countChange(cellId, changeType)
+}
@@ -0,0 +1,265 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadReportMaxAge is how long a load report is used, a cell master that stops reporting is treated as unreported.
+const loadReportMaxAge = time.Second * constants.LoadReportInterval * 3
+
+// CellStats is what a SplitMergePolicy is told about a cell. For an interior node the players, mutations and load
+// are those of all of its leaves together.
+type CellStats struct {
+	CellId             string
+	IsLeaf             bool
+	PlayerCount        int
+	Age                time.Duration
+	MutationsPerSecond float64
+	Load               float64
+	// Reported is true if the cell master of every leaf has recently reported its load
+	Reported bool
+}
+
+// A SplitMergePolicy decides which cells are split and merged. It is asked with the tree locked, so it must not call
+// the cell manager.
+type SplitMergePolicy interface {
+	// ShouldSplit is asked for leaves.
+	ShouldSplit(cell CellStats) bool
+	// ShouldMerge is asked for interior nodes, merging one makes it a leaf again.
+	ShouldMerge(cell CellStats) bool
+}
+
+// A SplitMergeExplainer is a SplitMergePolicy that can say why it splits a leaf or merges an interior node, PlanTopology
+// reports the explanations.
+type SplitMergeExplainer interface {
+	Explain(cell CellStats) string
+}
+
+// PlayerCountPolicy splits cells with many players and merges cells with few players.
+type PlayerCountPolicy struct {
+	SplitAt int
+	MergeAt int
+	MinAge  time.Duration
+}
+
+func (policy PlayerCountPolicy) ShouldSplit(cell CellStats) bool {
+	return cell.PlayerCount >= policy.SplitAt
+}
+
+func (policy PlayerCountPolicy) ShouldMerge(cell CellStats) bool {
+	return cell.PlayerCount <= policy.MergeAt && cell.Age > policy.MinAge
+}
+
+func (policy PlayerCountPolicy) Explain(cell CellStats) string {
+	if cell.IsLeaf {
+		return fmt.Sprintf("%d players, splitting at %d", cell.PlayerCount, policy.SplitAt)
+	}
+	return fmt.Sprintf("%d players, merging at %d after %v, %v old", cell.PlayerCount, policy.MergeAt, policy.MinAge, cell.Age)
+}
+
+// MutationRatePolicy splits cells whose cell masters handle many mutations per second and merges quiet cells. Cells
+// that have not been reported on are left as they are.
+type MutationRatePolicy struct {
+	SplitAbove float64
+	MergeBelow float64
+	MinAge     time.Duration
+}
+
+func (policy MutationRatePolicy) ShouldSplit(cell CellStats) bool {
+	return cell.Reported && cell.MutationsPerSecond >= policy.SplitAbove
+}
+
+func (policy MutationRatePolicy) ShouldMerge(cell CellStats) bool {
+	return cell.Reported && cell.MutationsPerSecond <= policy.MergeBelow && cell.Age > policy.MinAge
+}
+
+func (policy MutationRatePolicy) Explain(cell CellStats) string {
+	if cell.IsLeaf {
+		return fmt.Sprintf("%.1f mutations per second, splitting at %.1f", cell.MutationsPerSecond, policy.SplitAbove)
+	}
+	return fmt.Sprintf("%.1f mutations per second, merging at %.1f after %v, %v old",
+		cell.MutationsPerSecond, policy.MergeBelow, policy.MinAge, cell.Age)
+}
+
+// CellMasterLoadPolicy splits cells whose cell masters report a high load and merges cells whose cell masters
+// together would not be busy. Cells that have not been reported on are left as they are.
+type CellMasterLoadPolicy struct {
+	SplitAbove float64
+	MergeBelow float64
+	MinAge     time.Duration
+}
+
+func (policy CellMasterLoadPolicy) ShouldSplit(cell CellStats) bool {
+	return cell.Reported && cell.Load >= policy.SplitAbove
+}
+
+func (policy CellMasterLoadPolicy) ShouldMerge(cell CellStats) bool {
+	return cell.Reported && cell.Load <= policy.MergeBelow && cell.Age > policy.MinAge
+}
+
+func (policy CellMasterLoadPolicy) Explain(cell CellStats) string {
+	if cell.IsLeaf {
+		return fmt.Sprintf("load %.2f, splitting at %.2f", cell.Load, policy.SplitAbove)
+	}
+	return fmt.Sprintf("load %.2f, merging at %.2f after %v, %v old", cell.Load, policy.MergeBelow, policy.MinAge, cell.Age)
+}
+
+// DefaultSplitMergePolicy splits and merges on the player counts in constants.
+func DefaultSplitMergePolicy() SplitMergePolicy {
+	return PlayerCountPolicy{
+		SplitAt: constants.SplitCellRequirement,
+		MergeAt: constants.MergeCellRequirement,
+		MinAge:  time.Second * constants.MergeAgeRequirement,
+	}
+}
+
+// ParseSplitMergePolicy reads a policy written as "<kind>:<split>:<merge>:<min age>", where kind is players,
+// mutations or load, for example "mutations:200:20:30s".
+func ParseSplitMergePolicy(spec string) (SplitMergePolicy, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 4 {
+		return nil, errors.New("policy must be written as <kind>:<split>:<merge>:<min age>")
+	}
+
+	split, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, errors.New("invalid split threshold: " + parts[1])
+	}
+	merge, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return nil, errors.New("invalid merge threshold: " + parts[2])
+	}
+	minAge, err := time.ParseDuration(parts[3])
+	if err != nil {
+		return nil, errors.New("invalid minimum age: " + parts[3])
+	}
+	if merge >= split {
+		return nil, errors.New("the merge threshold must be below the split threshold")
+	}
+
+	switch parts[0] {
+	case "players":
+		return PlayerCountPolicy{SplitAt: int(split), MergeAt: int(merge), MinAge: minAge}, nil
+	case "mutations":
+		return MutationRatePolicy{SplitAbove: split, MergeBelow: merge, MinAge: minAge}, nil
+	case "load":
+		return CellMasterLoadPolicy{SplitAbove: split, MergeBelow: merge, MinAge: minAge}, nil
+	}
+	return nil, errors.New("unknown policy: " + parts[0])
+}
+
+type loadReport struct {
+	mutationsPerSecond float64
+	load               float64
+	received           time.Time
+}
+
+// SetSplitMergePolicy replaces the policy used by UpdateTopology.
+func (cellManager *CellManager) SetSplitMergePolicy(policy SplitMergePolicy) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.policy = policy
+}
+
+// ReportCellLoad stores how busy a cell is, only the cell master of a leaf can report on it. Reports are dropped
+// when the cell is split, merged or gets another cell master.
+func (cellManager *CellManager) ReportCellLoad(
+	ctx context.Context, in *generated.CellLoadReport,
+) (*generated.CellLoadReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.CellLoadReply{Accepted: false}, errors.New("world size has not been set")
+	}
+
+	node := cellManager.findNode(in.CellId)
+	if node == nil || !node.isLeaf() {
+		return &generated.CellLoadReply{Accepted: false}, errors.New("invalid cell: " + in.CellId)
+	}
+	if node.CellMaster == nil || node.CellMaster.Ip != in.Ip || node.CellMaster.Port != in.Port {
+		return &generated.CellLoadReply{Accepted: false}, errors.New("only the cell master can report the load of a cell")
+	}
+	if err := checkEpoch(node, in.Epoch); err != nil {
+		return &generated.CellLoadReply{Accepted: false}, err
+	}
+	if in.MutationsPerSecond < 0 || in.Load < 0 {
+		return &generated.CellLoadReply{Accepted: false}, errors.New("load can not be negative")
+	}
+
+	if cellManager.loadReports == nil {
+		cellManager.loadReports = make(map[string]loadReport, 0)
+	}
+	cellManager.loadReports[in.CellId] = loadReport{
+		mutationsPerSecond: in.MutationsPerSecond,
+		load:               in.Load,
+		received:           time.Now(),
+	}
+	return &generated.CellLoadReply{Accepted: true}, nil
+}
+
+// cellStats describes the node to the policy, the tree must be locked.
+func (cellManager *CellManager) cellStats(node *CellTreeNode) CellStats {
+	stats := CellStats{
+		CellId:      node.CellId,
+		IsLeaf:      node.isLeaf(),
+		PlayerCount: node.countPlayers(),
+		Age:         (timeNowInSeconds() - *node.CreationTime) * time.Second,
+		Reported:    true,
+	}
+
+	for _, leaf := range node.collectNodes(false) {
+		report, ok := cellManager.loadReports[leaf.CellId]
+		if !ok || time.Since(report.received) > loadReportMaxAge {
+			stats.Reported = false
+			continue
+		}
+		stats.MutationsPerSecond += report.mutationsPerSecond
+		stats.Load += report.load
+	}
+	return stats
+}
+
+// forgetLoadReports drops the reports on the leaves of the node, the tree must be write locked.
+func (cellManager *CellManager) forgetLoadReports(node *CellTreeNode) {
+	for _, leaf := range node.collectNodes(false) {
+		delete(cellManager.loadReports, leaf.CellId)
+	}
+}
+
+// shouldSplit skips cells that are too small to be split, so that they do not keep other cells from being split. A
+// split the policy would undo straight away is not made.
+func (cellManager *CellManager) shouldSplit(node *CellTreeNode) bool {
+	if !cellManager.canDivide(node, cellManager.splitMode) {
+		return false
+	}
+	stats := cellManager.cellStats(node)
+	return cellManager.policy.ShouldSplit(stats) && !cellManager.policy.ShouldMerge(afterChange(stats))
+}
+
+// shouldMerge skips nodes that were split, or had something below them split, within the minimum lifetime. A merge
+// the policy would undo straight away is not made.
+func (cellManager *CellManager) shouldMerge(node *CellTreeNode) bool {
+	stats := cellManager.cellStats(node)
+	if stats.Age < cellManager.minCellLifetime {
+		return false
+	}
+	return cellManager.policy.ShouldMerge(stats) && !cellManager.policy.ShouldSplit(afterChange(stats))
+}
+
+// afterChange is what the policy would be told about a cell right after splitting or merging it, as far as that is
+// known beforehand. The age is left out, so that a policy whose split and merge watermarks overlap is caught even
+// while its minimum age hides the overlap.
+func afterChange(stats CellStats) CellStats {
+	stats.IsLeaf = !stats.IsLeaf
+	stats.Age = time.Duration(math.MaxInt64)
+	return stats
+}
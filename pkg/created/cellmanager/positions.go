@@ -0,0 +1,174 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+)
+
+// ReportPositions stores the positions of registered players, cell masters use it to report all of their subscribers
+// at once. A player that has moved out of the leaf it is registered in is registered in the leaf it is in now. Players
+// that are not registered and positions outside the world are skipped and counted as unknown. A report naming a cell
+// is rejected if it is not from the current cell master of that cell.
+func (cellManager *CellManager) ReportPositions(
+	ctx context.Context, in *generated.PositionReport,
+) (*generated.PositionReportReply, error) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.PositionReportReply{}, errors.New("world size has not been set")
+	}
+
+	if len(in.CellId) > 0 {
+		node := cellManager.findNode(in.CellId)
+		if node == nil || !node.isLeaf() {
+			return &generated.PositionReportReply{}, errors.New("invalid cell: " + in.CellId)
+		}
+		if err := checkEpoch(node, in.Epoch); err != nil {
+			return &generated.PositionReportReply{}, err
+		}
+	}
+
+	reply := &generated.PositionReportReply{}
+	entry := LogEntry{Type: MovePlayersEntry}
+	for _, position := range in.Positions {
+		node, player := cellManager.findReportedPlayer(position)
+		target := cellManager.CellTree.findCollidingCell(&generated.Position{PosX: position.PosX, PosY: position.PosY})
+		if node == nil || target == nil {
+			reply.Unknown++
+			continue
+		}
+
+		if player.PosX == position.PosX && player.PosY == position.PosY && target == node {
+			continue
+		}
+
+		moved := *player
+		moved.PosX = position.PosX
+		moved.PosY = position.PosY
+		entry.Players = append(entry.Players, moved)
+		reply.Updated++
+		if target != node {
+			reply.Moved++
+		}
+	}
+
+	if len(entry.Players) == 0 {
+		return reply, nil
+	}
+	if err := cellManager.commitContext(ctx, entry); err != nil {
+		return &generated.PositionReportReply{}, err
+	}
+	return reply, nil
+}
+
+// findReportedPlayer finds a player by its address or, if the report has no address, by its object id.
+func (cellManager *CellManager) findReportedPlayer(position *generated.PlayerPosition) (*CellTreeNode, *objects.Client) {
+	if len(position.Ip) > 0 {
+		return cellManager.findPlayerByAddress(position.Ip, position.Port)
+	}
+	if len(position.ObjectId) > 0 {
+		return cellManager.findPlayerByObjectId(position.ObjectId)
+	}
+	return nil, nil
+}
+
+// findPlayerByAddress returns the leaf the player with the address is registered in, together with the player. The
+// tree must be locked.
+func (cellManager *CellManager) findPlayerByAddress(ip string, port int32) (*CellTreeNode, *objects.Client) {
+	node, ok := cellManager.playerIndex[objects.ToAddress(ip, port)]
+	if !ok {
+		return nil, nil
+	}
+	for index, player := range node.Players {
+		if player.Ip == ip && player.Port == port {
+			return node, &node.Players[index]
+		}
+	}
+	return nil, nil
+}
+
+// findPlayerByObjectId returns the leaf the player with the object id is registered in, together with the player. The
+// tree must be locked.
+func (cellManager *CellManager) findPlayerByObjectId(objectId string) (*CellTreeNode, *objects.Client) {
+	node, ok := cellManager.objectIndex[objectId]
+	if !ok {
+		return nil, nil
+	}
+	for index, player := range node.Players {
+		if player.ObjectId == objectId {
+			return node, &node.Players[index]
+		}
+	}
+	return nil, nil
+}
+
+// indexPlayers points the index entries of the players registered in the node at it, the tree must be write locked.
+func (cellManager *CellManager) indexPlayers(node *CellTreeNode) {
+	for _, player := range node.Players {
+		cellManager.indexPlayer(node, player)
+	}
+}
+
+// indexPlayer points the index entries of the player at the node, the tree must be write locked.
+func (cellManager *CellManager) indexPlayer(node *CellTreeNode, player objects.Client) {
+	cellManager.playerIndex[objects.ToAddress(player.Ip, player.Port)] = node
+	if len(player.ObjectId) > 0 {
+		cellManager.objectIndex[player.ObjectId] = node
+	}
+}
+
+// addPlayer registers the player in the node, or updates its position if it already is registered there. The tree
+// must be write locked.
+func (cellManager *CellManager) addPlayer(node *CellTreeNode, player objects.Client) {
+	if node.MovePlayer(player) {
+		cellManager.playerIndex[objects.ToAddress(player.Ip, player.Port)] = node
+		return
+	}
+	node.AppendPlayer(player)
+	node.adjustPlayerCount(1)
+	cellManager.indexPlayer(node, player)
+}
+
+// removePlayer unregisters the player from the node, the tree must be write locked.
+func (cellManager *CellManager) removePlayer(node *CellTreeNode, player objects.Client) {
+	for _, registered := range node.Players {
+		if registered.Ip != player.Ip || registered.Port != player.Port {
+			continue
+		}
+		address := objects.ToAddress(registered.Ip, registered.Port)
+		if cellManager.playerIndex[address] == node {
+			delete(cellManager.playerIndex, address)
+		}
+		if cellManager.objectIndex[registered.ObjectId] == node {
+			delete(cellManager.objectIndex, registered.ObjectId)
+		}
+	}
+	before := len(node.Players)
+	node.DeletePlayer(player)
+	node.adjustPlayerCount(len(node.Players) - before)
+}
+
+// movePlayer sets the position of a registered player and registers it in the leaf at that position if it has left
+// its own, the tree must be write locked.
+func (cellManager *CellManager) movePlayer(player objects.Client) error {
+	registered, _ := cellManager.findPlayerByAddress(player.Ip, player.Port)
+	if registered == nil {
+		return errors.New("player is not registered: " + objects.ToAddress(player.Ip, player.Port))
+	}
+
+	target := cellManager.CellTree.findCollidingCell(&generated.Position{PosX: player.PosX, PosY: player.PosY})
+	if target == nil {
+		return errors.New("position is outside of the world")
+	}
+
+	if target == registered {
+		registered.MovePlayer(player)
+		return nil
+	}
+	cellManager.removePlayer(registered, player)
+	cellManager.addPlayer(target, player)
+	return nil
+}
@@ -0,0 +1,336 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cellClaim is a cell master's report of the cell it owns.
+type cellClaim struct {
+	cell        objects.Cell
+	cellMaster  objects.Client
+	subscribers []objects.Client
+}
+
+// RecoverFromPeers rebuilds the cell tree from the cells that the given cell masters report that they own, it is
+// meant for a cell manager that has lost its state. A world size of zero is replaced by the smallest world holding
+// every reported cell.
+//
+// Claims are placed by the split path in their cell ids, deepest cell first. A claim is rejected if its cell overlaps
+// an accepted one, reuses an accepted id or does not lie where its id says, and its cell master is told to give the
+// cell up. Returns the number of accepted claims.
+func (cellManager *CellManager) RecoverFromPeers(peerAddresses []string, worldWidth int64, worldHeight int64) (int, error) {
+	claims := make([]*cellClaim, 0)
+	for _, address := range peerAddresses {
+		claim, err := requestCellClaim(address)
+		if err != nil {
+			println("could not get cell mastership from ", address, ": ", err.Error())
+			continue
+		}
+		if claim != nil {
+			claims = append(claims, claim)
+		}
+	}
+	sortClaims(claims)
+
+	if worldWidth <= 0 || worldHeight <= 0 {
+		worldWidth, worldHeight = 0, 0
+		for _, claim := range claims {
+			worldWidth = maxInt64(worldWidth, claim.cell.PosX+claim.cell.Width)
+			worldHeight = maxInt64(worldHeight, claim.cell.PosY+claim.cell.Height)
+		}
+	}
+
+	if worldWidth <= 0 || worldHeight <= 0 {
+		return 0, errors.New("no cells were reported and no world size was given")
+	}
+
+	cellManager.treeMutex.Lock()
+	if cellManager.CellTree != nil {
+		cellManager.treeMutex.Unlock()
+		return 0, errors.New("the cell manager already has a cell tree")
+	}
+
+	rejectedClaims := cellManager.rebuildTree(claims, worldWidth, worldHeight)
+	cellManager.publishSnapshot()
+
+	var err error
+	if cellManager.persistence != nil {
+		// the rebuilt tree can not be described by log entries, so it is stored as a snapshot instead
+		err = cellManager.takeSnapshot()
+	}
+	cellManager.treeMutex.Unlock()
+
+	for _, claim := range rejectedClaims {
+		println("rejected claim of ", claim.cellMaster.Port, " on cell ", claim.cell.CellId)
+		if err := revokeCellMastership(claim.cellMaster, claim.cell.CellId, claim.cell.Epoch); err != nil {
+			println("could not revoke cell mastership: ", err.Error())
+		}
+	}
+
+	return len(claims) - len(rejectedClaims), err
+}
+
+func requestCellClaim(address string) (*cellClaim, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	report, err := objects2.NewPlayerClient(conn).ReportCellMastership(ctx, &objects2.EmptyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.OwnsCell || report.Cell == nil {
+		return nil, nil
+	}
+
+	claim := &cellClaim{
+		cell: objects.Cell{
+			CellId: report.Cell.CellId,
+			PosX:   report.Cell.PosX,
+			PosY:   report.Cell.PosY,
+			Width:  report.Cell.Width,
+			Height: report.Cell.Height,
+			Epoch:  report.Cell.Epoch,
+
+			TopologyVersion: report.Cell.TopologyVersion,
+		},
+		cellMaster: objects.Client{Ip: report.Ip, Port: report.Port, ObjectId: report.ObjectId},
+	}
+	for _, subscriber := range report.Subscribers {
+		claim.subscribers = append(claim.subscribers, objects.Client{
+			Ip: subscriber.Ip, Port: subscriber.Port, ObjectId: subscriber.ObjectId, PosX: subscriber.PosX,
+			PosY: subscriber.PosY,
+		})
+	}
+	return claim, nil
+}
+
+// sortClaims orders the claims by descending depth of their cells, then by cell id, descending epoch and cell master
+// address, so that the same reports always give the same tree. A cell deeper in the tree was usually split off after
+// its ancestors were claimed, so the claims of its ancestors are the stale ones.
+func sortClaims(claims []*cellClaim) {
+	sort.SliceStable(claims, func(i, j int) bool {
+		first, second := claimDepth(claims[i]), claimDepth(claims[j])
+		if first != second {
+			return first > second
+		}
+		if claims[i].cell.CellId != claims[j].cell.CellId {
+			return claims[i].cell.CellId < claims[j].cell.CellId
+		}
+		// of two cell masters claiming the same cell, the one granted it last is the real one
+		if claims[i].cell.Epoch != claims[j].cell.Epoch {
+			return claims[i].cell.Epoch > claims[j].cell.Epoch
+		}
+		return objects.ToAddress(claims[i].cellMaster.Ip, claims[i].cellMaster.Port) <
+			objects.ToAddress(claims[j].cellMaster.Ip, claims[j].cellMaster.Port)
+	})
+}
+
+// claimDepth returns the depth of the claimed cell, and -1 if its id is not a path.
+func claimDepth(claim *cellClaim) int {
+	path, ok := cellPath(claim.cell.CellId)
+	if !ok {
+		return -1
+	}
+	return len(path)
+}
+
+// rebuildTree replaces the tree with one holding the accepted claims and returns the rejected ones, it must be
+// called with the tree write locked.
+func (cellManager *CellManager) rebuildTree(claims []*cellClaim, worldWidth int64, worldHeight int64) []*cellClaim {
+	cellManager.WorldWidth = worldWidth
+	cellManager.WorldHeight = worldHeight
+	cellManager.CellTree = CreateCellTree(&objects.Cell{
+		CellId:  initialCellId,
+		Players: make([]objects.Client, 0),
+		Width:   worldWidth,
+		Height:  worldHeight,
+	})
+	cellManager.indexTree()
+
+	claimedNodes := make(map[*CellTreeNode]bool, 0)
+	claimedIds := make(map[string]bool, 0)
+	rejectedClaims := make([]*cellClaim, 0)
+	for _, claim := range claims {
+		var node *CellTreeNode
+		if !claimedIds[claim.cell.CellId] {
+			node = cellManager.placeClaim(claim.cell, claimedNodes)
+		}
+
+		if node == nil {
+			rejectedClaims = append(rejectedClaims, claim)
+			continue
+		}
+
+		claimedNodes[node] = true
+		claimedIds[claim.cell.CellId] = true
+		cellMaster := claim.cellMaster
+		node.CellMaster = &cellMaster
+		node.Epoch = claim.cell.Epoch
+		if node.Epoch > cellManager.lastEpoch {
+			cellManager.lastEpoch = node.Epoch
+		}
+		node.TopologyVersion = claim.cell.TopologyVersion
+		if node.TopologyVersion > cellManager.topologyVersion {
+			cellManager.topologyVersion = node.TopologyVersion
+		}
+		for _, subscriber := range claim.subscribers {
+			// a player subscribed to several cells stays in the first of them to be accepted, the deepest one
+			if foundNode, _ := cellManager.findPlayerByAddress(subscriber.Ip, subscriber.Port); foundNode == nil {
+				cellManager.addPlayer(node, subscriber)
+			}
+		}
+	}
+
+	cellManager.CellTree.pruneUnclaimed(claimedNodes)
+	// the cells nobody claimed are new, messages addressed to cells of the same id before the recovery are stale
+	version := cellManager.nextTopologyVersion()
+	for _, node := range cellManager.CellTree.collectNodes(true) {
+		if !claimedNodes[node] {
+			node.TopologyVersion = version
+		}
+	}
+	cellManager.CellTree.recountPlayers()
+	cellManager.indexTree()
+	cellManager.refreshLeases()
+
+	return rejectedClaims
+}
+
+// placeClaim follows the path in the id of the claimed cell, splitting unclaimed leaves on the way, and returns the
+// unclaimed leaf covering exactly the claimed cell, or nil if there can be no such leaf.
+func (cellManager *CellManager) placeClaim(cell objects.Cell, claimedNodes map[*CellTreeNode]bool) *CellTreeNode {
+	path, ok := cellPath(cell.CellId)
+	if !ok {
+		return nil
+	}
+
+	node := cellManager.CellTree
+	for index := 0; index < len(path); index++ {
+		if claimedNodes[node] || !node.contains(&cell) {
+			return nil
+		}
+		if node.isLeaf() && !cellManager.splitFor(node, path[index], &cell, index == len(path)-1) {
+			return nil
+		}
+
+		// a node that is split another way than the path says has no child with the id
+		node = cellManager.findNode(childId(node, path[index]))
+		if node == nil {
+			return nil
+		}
+	}
+
+	if claimedNodes[node] || !node.isLeaf() ||
+		node.PosX != cell.PosX || node.PosY != cell.PosY || node.Width != cell.Width || node.Height != cell.Height {
+		return nil
+	}
+	return node
+}
+
+// splitFor splits the unclaimed leaf the way the key says. The borders are put where the child with the key holds the
+// cell, or is the cell if it is the last key of its path, and as close to the middle of the leaf as that allows. It
+// returns false if the leaf can not be split like that.
+func (cellManager *CellManager) splitFor(node *CellTreeNode, key byte, cell *objects.Cell, last bool) bool {
+	switch key {
+	case westKey, eastKey:
+		at, ok := cutFor(node.PosX, node.Width, cell.PosX, cell.Width, key == eastKey, last)
+		if !ok || last && (cell.PosY != node.PosY || cell.Height != node.Height) {
+			return false
+		}
+		cellManager.addHalves(node, SplitAxisX, at)
+	case northKey, southKey:
+		at, ok := cutFor(node.PosY, node.Height, cell.PosY, cell.Height, key == southKey, last)
+		if !ok || last && (cell.PosX != node.PosX || cell.Width != node.Width) {
+			return false
+		}
+		cellManager.addHalves(node, SplitAxisY, at)
+	default:
+		quadrant := strings.IndexByte(quadrantKeys, key)
+		splitX, okX := cutFor(node.PosX, node.Width, cell.PosX, cell.Width, quadrant%2 == 1, last)
+		splitY, okY := cutFor(node.PosY, node.Height, cell.PosY, cell.Height, quadrant >= 2, last)
+		if !okX || !okY {
+			return false
+		}
+		cellManager.addQuadrants(node)
+		node.moveChildBorders(splitX, splitY)
+	}
+	return true
+}
+
+// cutFor returns where to cut the range starting at start so that the part before the cut, or after it if second is
+// true, holds the cell's range. If exact is true that part must be the cell's range, otherwise the cut is as close to
+// the middle as possible. It returns false if there is no such cut.
+func cutFor(start int64, size int64, cellStart int64, cellSize int64, second bool, exact bool) (int64, bool) {
+	end, cellEnd, middle := start+size, cellStart+cellSize, start+size/2
+	at := cellEnd
+	if second {
+		at = cellStart
+	}
+
+	if exact && (second && cellEnd != end || !second && cellStart != start) {
+		return 0, false
+	}
+	if !exact && second && middle < at || !exact && !second && middle > at {
+		at = middle
+	}
+	return at, start < at && at < end
+}
+
+func (node *CellTreeNode) contains(cell *objects.Cell) bool {
+	return node.PosX <= cell.PosX && cell.PosX+cell.Width <= node.PosX+node.Width &&
+		node.PosY <= cell.PosY && cell.PosY+cell.Height <= node.PosY+node.Height
+}
+
+// pruneUnclaimed merges every subtree without claimed cells back into a leaf, and reports whether the node holds a
+// claimed cell.
+func (node *CellTreeNode) pruneUnclaimed(claimedNodes map[*CellTreeNode]bool) bool {
+	if node.isLeaf() {
+		return claimedNodes[node]
+	}
+
+	holdsClaim := false
+	for _, child := range node.Children {
+		if child.pruneUnclaimed(claimedNodes) {
+			holdsClaim = true
+		}
+	}
+
+	if !holdsClaim {
+		node.killChildren()
+	}
+	return holdsClaim || claimedNodes[node]
+}
+
+func revokeCellMastership(cellMaster objects.Client, cellId string, epoch int64) error {
+	conn, err := grpc.Dial(objects.ToAddress(cellMaster.Ip, cellMaster.Port), grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err = objects2.NewPlayerClient(conn).NotifyOfSplitCell(ctx, &objects2.Cell{CellId: cellId, Epoch: epoch})
+	return err
+}
+
+func maxInt64(a int64, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,139 @@
+package cellmanager
+
+import (
+	"context"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeaderMetadataKey is the trailer in which a replica that is not the leader tells where the leader is.
+const LeaderMetadataKey = "cell-manager-leader"
+
+const cellManagerMethodPrefix = "/cellmanager.CellManager/"
+
+// LeaderOnlyInterceptor rejects CellManager calls on replicas that are not the leader with codes.Unavailable, and
+// tells the caller where the leader is. Replication calls always go through.
+func (cellManager *CellManager) LeaderOnlyInterceptor(
+	ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	if !strings.HasPrefix(info.FullMethod, cellManagerMethodPrefix) {
+		return handler(ctx, req)
+	}
+
+	if !cellManager.IsLeader() {
+		cellManager.setLeaderTrailer(ctx)
+		return nil, errNotLeader
+	}
+
+	reply, err := handler(ctx, req)
+	if status.Code(err) == codes.Unavailable {
+		// leadership may have been lost while handling the call
+		cellManager.setLeaderTrailer(ctx)
+	}
+	return reply, err
+}
+
+func (cellManager *CellManager) setLeaderTrailer(ctx context.Context) {
+	if leaderAddress := cellManager.LeaderAddress(); len(leaderAddress) > 0 {
+		grpc.SetTrailer(ctx, metadata.Pairs(LeaderMetadataKey, leaderAddress))
+	}
+}
+
+// DialCellManager connects to a cell manager that may be replicated on the given addresses. Calls on the returned
+// connection are sent on to the current leader, also when the leader changes.
+func DialCellManager(addresses []string) (*grpc.ClientConn, error) {
+	redirector := &leaderRedirector{
+		addresses:   addresses,
+		connections: make(map[string]*grpc.ClientConn, 0),
+		mutex:       &sync.Mutex{},
+	}
+	return grpc.Dial(addresses[0], grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli),
+		grpc.WithUnaryInterceptor(redirector.intercept))
+}
+
+type leaderRedirector struct {
+	addresses   []string
+	connections map[string]*grpc.ClientConn
+	mutex       *sync.Mutex
+	leader      string
+}
+
+// intercept retries a call on the leader until it is no longer turned away, or until the redirect timeout has
+// passed. While there is no leader, the replicas are tried in turn.
+func (redirector *leaderRedirector) intercept(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	deadline := time.Now().Add(time.Millisecond * constants.ReplicationTimeoutMilli * 2)
+	target := redirector.currentLeader()
+	for {
+		var trailer metadata.MD
+		callOptions := append(append([]grpc.CallOption{}, opts...), grpc.Trailer(&trailer))
+
+		var err error
+		if len(target) == 0 {
+			err = invoker(ctx, method, req, reply, cc, callOptions...)
+		} else {
+			err = redirector.connection(target).Invoke(ctx, method, req, reply, callOptions...)
+		}
+
+		if status.Code(err) != codes.Unavailable || time.Now().After(deadline) || ctx.Err() != nil {
+			return err
+		}
+
+		if leaders := trailer.Get(LeaderMetadataKey); len(leaders) > 0 && leaders[0] != target {
+			target = leaders[0]
+		} else {
+			target = redirector.nextAddress(target)
+			time.Sleep(time.Millisecond * constants.HeartbeatIntervalMilli)
+		}
+		redirector.setLeader(target)
+	}
+}
+
+func (redirector *leaderRedirector) currentLeader() string {
+	redirector.mutex.Lock()
+	defer redirector.mutex.Unlock()
+	return redirector.leader
+}
+
+func (redirector *leaderRedirector) setLeader(leader string) {
+	redirector.mutex.Lock()
+	defer redirector.mutex.Unlock()
+	redirector.leader = leader
+}
+
+// nextAddress returns the address after the given one, the first address comes after the dialled one.
+func (redirector *leaderRedirector) nextAddress(address string) string {
+	if len(address) == 0 {
+		address = redirector.addresses[0]
+	}
+
+	for index, candidate := range redirector.addresses {
+		if candidate == address {
+			return redirector.addresses[(index+1)%len(redirector.addresses)]
+		}
+	}
+	return redirector.addresses[0]
+}
+
+// connection returns a connection to the address, these connections do not redirect on their own.
+func (redirector *leaderRedirector) connection(address string) *grpc.ClientConn {
+	redirector.mutex.Lock()
+	defer redirector.mutex.Unlock()
+
+	if conn, ok := redirector.connections[address]; ok {
+		return conn
+	}
+
+	// without WithBlock dialling only fails on invalid options, never on an unreachable address
+	conn, _ := grpc.Dial(address, grpc.WithInsecure())
+	redirector.connections[address] = conn
+	return conn
+}
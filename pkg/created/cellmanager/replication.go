@@ -0,0 +1,908 @@
+package cellmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	follower = iota
+	candidate
+	leader
+)
+
+// NoOpEntry is committed by every new leader, committing it also commits the entries left by earlier leaders.
+const NoOpEntry = "noOp"
+
+// termEntry records the term and the vote of a replica in its log, it is never applied.
+const termEntry = "term"
+
+// replicaRecords is what a replica keeps in its log besides the applied state.
+type replicaRecords struct {
+	currentTerm int64
+	// the address of the replica voted for in the current term, empty if there is none
+	votedFor string
+	// the entries that were not applied yet
+	entries []LogEntry
+}
+
+// records returns what is written to the log for the records, nothing for a replica that has never been in a term.
+func (records replicaRecords) records() []LogEntry {
+	if records.currentTerm == 0 && len(records.entries) == 0 {
+		return nil
+	}
+	term := LogEntry{Type: termEntry, Term: records.currentTerm, VotedFor: records.votedFor}
+	return append([]LogEntry{term}, records.entries...)
+}
+
+// pendingChange is a change made on the leader that waits for the outcome of its entry.
+type pendingChange struct {
+	term   int64
+	result chan error
+}
+
+// errNotLeader turns a call away from a replica that is not, or is no longer, the leader. The caller retries it on the
+// leader.
+var errNotLeader = status.Error(codes.Unavailable, "not the leader")
+
+// errReplaced is the outcome of a change whose entry a newer leader replaced, it was not made and may be made again.
+var errReplaced = status.Error(codes.Unavailable, "the change was replaced by the log of a newer leader")
+
+// replica replicates the log entries of a CellManager to the other replicas, following the Raft algorithm. Only the
+// leader commits new entries, the followers apply what the leader has committed. A replica with persistence writes
+// its term, its vote and its log to the write-ahead log before it answers, so that it keeps its word after a restart.
+// A replica without persistence starts out empty and is brought up to date by the leader. The applied entries are
+// compacted into a snapshot every snapshotInterval entries, the leader sends its state instead of the compacted
+// entries to replicas that lag behind.
+type replica struct {
+	cellManager *CellManager
+	id          int
+	addresses   []string
+	peers       []generated.ReplicationClient
+	connections []*grpc.ClientConn
+	stopped     chan bool
+	// kicked wakes the leader up to replicate a new entry before the next heartbeat
+	kicked chan bool
+	// the persistence of the CellManager, or nil
+	store            *persistence
+	snapshotInterval int
+
+	// mutex guards everything below, it is never held while calling other replicas. When both are needed, the tree
+	// is locked before mutex.
+	mutex       *sync.Mutex
+	role        int
+	currentTerm int64
+	votedFor    int
+	leaderId    int
+	// the entries after snapshotIndex, the ones up to it have been compacted into the applied state
+	log              []LogEntry
+	snapshotIndex    int64
+	snapshotTerm     int64
+	commitIndex      int64
+	lastApplied      int64
+	nextIndex        []int64
+	matchIndex       []int64
+	electionDeadline time.Time
+	// the changes waiting for the outcome of their entries, by index
+	pending map[int64]pendingChange
+}
+
+// EnableReplication makes the CellManager the replica at index id of the given addresses. It must be called before
+// the CellManager starts serving. A CellManager with persistence continues from the state, term, vote and log it has
+// restored, a CellManager without persistence must not have any state.
+func (cellManager *CellManager) EnableReplication(id int, addresses []string) error {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+
+	if cellManager.replica != nil {
+		return errors.New("replication is already enabled")
+	}
+
+	if cellManager.CellTree != nil && cellManager.persistence == nil {
+		return errors.New("replicas without persistence start without state")
+	}
+
+	if id < 0 || id >= len(addresses) {
+		return errors.New("the replica id does not match an address")
+	}
+
+	r := &replica{
+		cellManager:      cellManager,
+		id:               id,
+		addresses:        addresses,
+		peers:            make([]generated.ReplicationClient, len(addresses)),
+		connections:      make([]*grpc.ClientConn, len(addresses)),
+		stopped:          make(chan bool),
+		kicked:           make(chan bool, 1),
+		store:            cellManager.persistence,
+		snapshotInterval: constants.SnapshotInterval,
+		mutex:            &sync.Mutex{},
+		role:             follower,
+		votedFor:         -1,
+		leaderId:         -1,
+		log:              make([]LogEntry, 0),
+		nextIndex:        make([]int64, len(addresses)),
+		matchIndex:       make([]int64, len(addresses)),
+		pending:          make(map[int64]pendingChange, 0),
+	}
+	if r.store != nil {
+		r.restore()
+	}
+
+	for peer, address := range addresses {
+		if peer == id {
+			continue
+		}
+
+		conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+		if err != nil {
+			r.closeConnections()
+			return err
+		}
+		r.connections[peer] = conn
+		r.peers[peer] = generated.NewReplicationClient(conn)
+	}
+
+	r.resetElectionDeadline()
+	cellManager.replica = r
+	go r.run()
+	return nil
+}
+
+// restore continues from what the persistence of the CellManager restored, the tree must be write locked.
+func (r *replica) restore() {
+	r.snapshotInterval = r.store.snapshotInterval
+	r.snapshotIndex, r.snapshotTerm = r.store.lastIndex, r.store.lastTerm
+	r.commitIndex, r.lastApplied = r.snapshotIndex, r.snapshotIndex
+	r.currentTerm = r.store.restored.currentTerm
+	r.log = append(r.log, r.store.restored.entries...)
+	for peer, address := range r.addresses {
+		if address == r.store.restored.votedFor {
+			r.votedFor = peer
+		}
+	}
+	r.store.restored = replicaRecords{}
+}
+
+// StopReplication stops taking part in elections and replication, the CellManager keeps its state. The changes that
+// wait for their entries are told that the outcome is unknown.
+func (cellManager *CellManager) StopReplication() {
+	cellManager.treeMutex.Lock()
+	r := cellManager.replica
+	cellManager.replica = nil
+	if r != nil {
+		r.mutex.Lock()
+		r.role = follower
+		r.resolvePending(0, status.Error(codes.Unknown, "replication was stopped before the outcome of the change was known"))
+		// what the replica did not apply stays in the log when persistence takes its next snapshot
+		if r.store != nil {
+			r.store.restored = r.unappliedRecords()
+		}
+		r.mutex.Unlock()
+	}
+	cellManager.treeMutex.Unlock()
+
+	if r == nil {
+		return
+	}
+
+	close(r.stopped)
+	r.closeConnections()
+}
+
+// IsLeader reports whether the CellManager may change its state, which a CellManager without replicas always may.
+func (cellManager *CellManager) IsLeader() bool {
+	r := cellManager.currentReplica()
+	if r == nil {
+		return true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.role == leader
+}
+
+// LeaderAddress returns the address of the current leader, or an empty string if it is not known.
+func (cellManager *CellManager) LeaderAddress() string {
+	r := cellManager.currentReplica()
+	if r == nil {
+		return ""
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.leaderId < 0 {
+		return ""
+	}
+	return r.addresses[r.leaderId]
+}
+
+func (cellManager *CellManager) currentReplica() *replica {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+	return cellManager.replica
+}
+
+func (cellManager *CellManager) RequestVote(ctx context.Context, in *generated.VoteRequest) (*generated.VoteReply, error) {
+	r := cellManager.currentReplica()
+	if r == nil {
+		return &generated.VoteReply{}, errors.New("replication is not enabled")
+	}
+	return r.requestVote(in)
+}
+
+func (cellManager *CellManager) AppendEntries(ctx context.Context, in *generated.AppendEntriesRequest) (*generated.AppendEntriesReply, error) {
+	r := cellManager.currentReplica()
+	if r == nil {
+		return &generated.AppendEntriesReply{}, errors.New("replication is not enabled")
+	}
+
+	reply, err := r.appendEntries(in)
+	if err != nil {
+		return &generated.AppendEntriesReply{}, err
+	}
+
+	cellManager.treeMutex.Lock()
+	r.applyCommitted()
+	cellManager.treeMutex.Unlock()
+	return reply, nil
+}
+
+func (cellManager *CellManager) InstallSnapshot(ctx context.Context, in *generated.InstallSnapshotRequest) (*generated.AppendEntriesReply, error) {
+	r := cellManager.currentReplica()
+	if r == nil {
+		return &generated.AppendEntriesReply{}, errors.New("replication is not enabled")
+	}
+	return r.installSnapshot(in)
+}
+
+func (r *replica) run() {
+	for {
+		select {
+		case <-r.stopped:
+			return
+		case <-r.kicked:
+		case <-time.After(time.Millisecond * constants.HeartbeatIntervalMilli):
+		}
+
+		r.mutex.Lock()
+		role := r.role
+		electionDue := time.Now().After(r.electionDeadline)
+		r.mutex.Unlock()
+
+		if role == leader {
+			r.replicateToAll()
+			r.cellManager.treeMutex.Lock()
+			r.applyCommitted()
+			r.cellManager.treeMutex.Unlock()
+		} else if electionDue {
+			r.startElection()
+		}
+	}
+}
+
+// kick makes the leader replicate without waiting for the next heartbeat.
+func (r *replica) kick() {
+	select {
+	case r.kicked <- true:
+	default:
+	}
+}
+
+// replicate adds the entry to the log and waits for its outcome. The entry is either applied once a majority of the
+// replicas has it, which may fail if the state has changed since it was checked, or it is replaced by the log of a
+// newer leader. A leader that can not reach a majority in time steps down, its entries stay in the log and the next
+// leader decides their outcome. If that is not known soon after, or ctx is done first, the caller is told to retry on
+// the leader without knowing whether the change was made. It must be called with the tree write locked, the tree is
+// unlocked while waiting so that the other calls are served in the meantime.
+func (r *replica) replicate(ctx context.Context, entry LogEntry) error {
+	r.mutex.Lock()
+	if r.role != leader {
+		r.mutex.Unlock()
+		return errNotLeader
+	}
+	entry.Index = r.lastIndex() + 1
+	entry.Term = r.currentTerm
+	if err := r.persist(entry); err != nil {
+		r.mutex.Unlock()
+		return err
+	}
+	r.log = append(r.log, entry)
+	result := make(chan error, 1)
+	r.pending[entry.Index] = pendingChange{term: entry.Term, result: result}
+	r.mutex.Unlock()
+	r.kick()
+
+	r.cellManager.treeMutex.Unlock()
+	defer r.cellManager.treeMutex.Lock()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Millisecond * constants.ReplicationTimeoutMilli):
+	}
+
+	r.mutex.Lock()
+	if r.role == leader && r.currentTerm == entry.Term {
+		println("replica ", r.id, " could not reach a majority and steps down")
+		r.role = follower
+		r.resetElectionDeadline()
+	}
+	r.mutex.Unlock()
+
+	select {
+	case err := <-result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Millisecond * constants.ReplicationTimeoutMilli):
+		return errNotLeader
+	}
+}
+
+// applyCommitted applies the committed entries that have not been applied yet, one at a time. An entry that can not
+// be applied is skipped, which every replica does alike as they apply the same entries to the same state. The applied
+// entries are compacted every snapshotInterval entries. It must be called with the tree write locked.
+func (r *replica) applyCommitted() {
+	for {
+		r.mutex.Lock()
+		if r.lastApplied >= r.commitIndex {
+			r.mutex.Unlock()
+			break
+		}
+		entry := r.entryAt(r.lastApplied + 1)
+		r.mutex.Unlock()
+
+		err := r.cellManager.apply(entry)
+		if err != nil {
+			println("skipped log entry ", entry.Index, ": ", err.Error())
+		}
+
+		r.mutex.Lock()
+		r.lastApplied = entry.Index
+		if change, ok := r.pending[entry.Index]; ok {
+			delete(r.pending, entry.Index)
+			if change.term != entry.Term {
+				err = errReplaced
+			}
+			change.result <- err
+		}
+		r.mutex.Unlock()
+
+		if r.store != nil {
+			r.store.lastIndex, r.store.lastTerm = entry.Index, entry.Term
+		}
+	}
+
+	r.mutex.Lock()
+	compactionDue := r.lastApplied-r.snapshotIndex >= int64(r.snapshotInterval)
+	r.mutex.Unlock()
+	if compactionDue {
+		if err := r.compact(); err != nil {
+			println("could not compact the log: ", err.Error())
+		}
+	}
+}
+
+// compact drops the applied entries from the log, after taking a snapshot that holds them if the replica has
+// persistence. It must be called with the tree write locked.
+func (r *replica) compact() error {
+	if r.store != nil {
+		if err := r.cellManager.takeSnapshot(); err != nil {
+			return err
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.snapshotTerm = r.termAt(r.lastApplied)
+	r.log = append([]LogEntry{}, r.log[r.lastApplied-r.snapshotIndex:]...)
+	r.snapshotIndex = r.lastApplied
+	return nil
+}
+
+func (r *replica) replicateToAll() {
+	wg := sync.WaitGroup{}
+	for peer := range r.peers {
+		if peer == r.id {
+			continue
+		}
+
+		wg.Add(1)
+		go func(peer int) {
+			defer wg.Done()
+			r.sendAppendEntries(peer)
+		}(peer)
+	}
+	wg.Wait()
+
+	r.mutex.Lock()
+	r.advanceCommitIndex()
+	r.mutex.Unlock()
+}
+
+// sendAppendEntries sends the peer the entries it is missing, a batch at a time, or the state if it is missing
+// compacted entries.
+func (r *replica) sendAppendEntries(peer int) {
+	r.mutex.Lock()
+	if r.role != leader {
+		r.mutex.Unlock()
+		return
+	}
+
+	if r.nextIndex[peer] <= r.snapshotIndex {
+		r.mutex.Unlock()
+		r.sendSnapshot(peer)
+		return
+	}
+
+	term := r.currentTerm
+	prevLogIndex := r.nextIndex[peer] - 1
+	request := &generated.AppendEntriesRequest{
+		Term:         term,
+		LeaderId:     int32(r.id),
+		PrevLogIndex: prevLogIndex,
+		PrevLogTerm:  r.termAt(prevLogIndex),
+		LeaderCommit: r.commitIndex,
+	}
+	lastIndex := minInt64(r.lastIndex(), prevLogIndex+constants.MaxReplicatedEntries)
+	for index := prevLogIndex + 1; index <= lastIndex; index++ {
+		logged := r.entryAt(index)
+		data, err := json.Marshal(logged)
+		if err != nil {
+			r.mutex.Unlock()
+			println("could not encode log entry: ", err.Error())
+			return
+		}
+		request.Entries = append(request.Entries, &generated.ReplicatedEntry{Term: logged.Term, Entry: data})
+	}
+	r.mutex.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*constants.HeartbeatIntervalMilli*2)
+	reply, err := r.peers[peer].AppendEntries(ctx, request)
+	cancel()
+	if err != nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.acceptReply(term, reply) {
+		return
+	}
+
+	if reply.Success {
+		r.matchIndex[peer] = maxInt64(r.matchIndex[peer], prevLogIndex+int64(len(request.Entries)))
+		r.nextIndex[peer] = r.matchIndex[peer] + 1
+	} else {
+		// the follower tells how much of its log may match, which saves going back one entry at a time
+		r.nextIndex[peer] = maxInt64(1, minInt64(reply.MatchIndex+1, prevLogIndex))
+	}
+}
+
+// sendSnapshot sends the applied state to the peer in place of the compacted entries it is missing.
+func (r *replica) sendSnapshot(peer int) {
+	r.cellManager.treeMutex.RLock()
+	r.mutex.Lock()
+	if r.role != leader {
+		r.mutex.Unlock()
+		r.cellManager.treeMutex.RUnlock()
+		return
+	}
+	term := r.currentTerm
+	request := &generated.InstallSnapshotRequest{
+		Term:              term,
+		LeaderId:          int32(r.id),
+		LastIncludedIndex: r.lastApplied,
+		LastIncludedTerm:  r.termAt(r.lastApplied),
+	}
+	r.mutex.Unlock()
+	data, err := json.Marshal(r.cellManager.snapshotOf(request.LastIncludedIndex, request.LastIncludedTerm))
+	r.cellManager.treeMutex.RUnlock()
+	if err != nil {
+		println("could not encode snapshot: ", err.Error())
+		return
+	}
+	request.Data = data
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*constants.ReplicationTimeoutMilli)
+	reply, err := r.peers[peer].InstallSnapshot(ctx, request)
+	cancel()
+	if err != nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.acceptReply(term, reply) && reply.Success {
+		r.matchIndex[peer] = maxInt64(r.matchIndex[peer], request.LastIncludedIndex)
+		r.nextIndex[peer] = r.matchIndex[peer] + 1
+	}
+}
+
+// acceptReply reports whether a reply to a request sent in the term is still of use to the leader, a reply from a
+// newer term makes it a follower. It must be called with mutex held.
+func (r *replica) acceptReply(term int64, reply *generated.AppendEntriesReply) bool {
+	if reply.Term > r.currentTerm {
+		if err := r.becomeFollower(reply.Term); err != nil {
+			println("could not store the term: ", err.Error())
+		}
+		return false
+	}
+	return r.role == leader && r.currentTerm == term
+}
+
+// advanceCommitIndex commits the newest entry of the current term that a majority has, it must be called with mutex
+// held.
+func (r *replica) advanceCommitIndex() {
+	if r.role != leader {
+		return
+	}
+
+	for index := r.lastIndex(); index > r.commitIndex; index-- {
+		if r.termAt(index) != r.currentTerm {
+			return
+		}
+
+		replicas := 1
+		for peer, matchIndex := range r.matchIndex {
+			if peer != r.id && matchIndex >= index {
+				replicas++
+			}
+		}
+
+		if replicas > len(r.addresses)/2 {
+			r.commitIndex = index
+			return
+		}
+	}
+}
+
+func (r *replica) startElection() {
+	r.mutex.Lock()
+	r.role = candidate
+	r.currentTerm++
+	r.votedFor = r.id
+	r.leaderId = -1
+	r.resetElectionDeadline()
+	// the replica does not ask for votes before its own is stored
+	if err := r.persist(r.termRecord()); err != nil {
+		r.role = follower
+		r.mutex.Unlock()
+		println("could not store the term: ", err.Error())
+		return
+	}
+	term := r.currentTerm
+	request := &generated.VoteRequest{
+		Term:         term,
+		CandidateId:  int32(r.id),
+		LastLogIndex: r.lastIndex(),
+		LastLogTerm:  r.termAt(r.lastIndex()),
+	}
+	votes := 1
+	if votes > len(r.addresses)/2 {
+		r.becomeLeader()
+	}
+	r.mutex.Unlock()
+
+	for peer := range r.peers {
+		if peer == r.id {
+			continue
+		}
+
+		go func(peer int) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*constants.HeartbeatIntervalMilli*2)
+			defer cancel()
+			reply, err := r.peers[peer].RequestVote(ctx, request)
+			if err != nil {
+				return
+			}
+
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			if reply.Term > r.currentTerm {
+				if err := r.becomeFollower(reply.Term); err != nil {
+					println("could not store the term: ", err.Error())
+				}
+				return
+			}
+
+			if r.role != candidate || r.currentTerm != term || !reply.VoteGranted {
+				return
+			}
+
+			votes++
+			if votes > len(r.addresses)/2 {
+				r.becomeLeader()
+			}
+		}(peer)
+	}
+}
+
+// requestVote grants the vote once it is stored, so that a restarted replica never votes twice in a term.
+func (r *replica) requestVote(in *generated.VoteRequest) (*generated.VoteReply, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if in.Term > r.currentTerm {
+		if err := r.becomeFollower(in.Term); err != nil {
+			return &generated.VoteReply{}, err
+		}
+	}
+
+	lastLogIndex := r.lastIndex()
+	lastLogTerm := r.termAt(lastLogIndex)
+	upToDate := in.LastLogTerm > lastLogTerm || (in.LastLogTerm == lastLogTerm && in.LastLogIndex >= lastLogIndex)
+	candidateId := int(in.CandidateId)
+	granted := in.Term == r.currentTerm && (r.votedFor == -1 || r.votedFor == candidateId) && upToDate
+
+	if granted && r.votedFor != candidateId {
+		r.votedFor = candidateId
+		if err := r.persist(r.termRecord()); err != nil {
+			return &generated.VoteReply{}, err
+		}
+	}
+	if granted {
+		r.resetElectionDeadline()
+	}
+	return &generated.VoteReply{Term: r.currentTerm, VoteGranted: granted}, nil
+}
+
+// appendEntries adds the entries of the leader to the log, and acknowledges them once they are stored.
+func (r *replica) appendEntries(in *generated.AppendEntriesRequest) (*generated.AppendEntriesReply, error) {
+	entries := make([]LogEntry, len(in.Entries))
+	for offset, replicated := range in.Entries {
+		if err := json.Unmarshal(replicated.Entry, &entries[offset]); err != nil {
+			return &generated.AppendEntriesReply{}, errors.New("corrupt replicated entry: " + err.Error())
+		}
+		entries[offset].Index = in.PrevLogIndex + int64(offset) + 1
+		entries[offset].Term = replicated.Term
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if in.Term < r.currentTerm {
+		return &generated.AppendEntriesReply{Term: r.currentTerm, Success: false, MatchIndex: r.lastIndex()}, nil
+	}
+
+	if err := r.becomeFollower(in.Term); err != nil {
+		return &generated.AppendEntriesReply{}, err
+	}
+	r.leaderId = int(in.LeaderId)
+	r.resetElectionDeadline()
+
+	if in.PrevLogIndex > r.lastIndex() {
+		return &generated.AppendEntriesReply{Term: r.currentTerm, Success: false, MatchIndex: r.lastIndex()}, nil
+	}
+
+	// the compacted entries are committed, so they match those of every leader
+	if in.PrevLogIndex > r.snapshotIndex && r.termAt(in.PrevLogIndex) != in.PrevLogTerm {
+		return &generated.AppendEntriesReply{Term: r.currentTerm, Success: false, MatchIndex: in.PrevLogIndex - 1}, nil
+	}
+
+	appended := make([]LogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Index <= r.snapshotIndex {
+			continue
+		}
+		if entry.Index <= r.lastIndex() {
+			if r.termAt(entry.Index) == entry.Term {
+				continue
+			}
+			r.truncateFrom(entry.Index)
+		}
+		r.log = append(r.log, entry)
+		appended = append(appended, entry)
+	}
+
+	if err := r.persist(appended...); err != nil {
+		// entries that are not stored are not kept either, or they would be acknowledged when they are sent again
+		r.log = r.log[:len(r.log)-len(appended)]
+		return &generated.AppendEntriesReply{}, err
+	}
+
+	lastNewIndex := maxInt64(in.PrevLogIndex+int64(len(entries)), r.snapshotIndex)
+	if in.LeaderCommit > r.commitIndex {
+		r.commitIndex = maxInt64(r.commitIndex, minInt64(in.LeaderCommit, lastNewIndex))
+	}
+	return &generated.AppendEntriesReply{Term: r.currentTerm, Success: true, MatchIndex: lastNewIndex}, nil
+}
+
+// installSnapshot replaces the state by the state of the leader, unless the replica has already applied the entries
+// it holds. The entries after the snapshot are kept if the replica has them.
+func (r *replica) installSnapshot(in *generated.InstallSnapshotRequest) (*generated.AppendEntriesReply, error) {
+	stored := snapshot{}
+	if err := json.Unmarshal(in.Data, &stored); err != nil {
+		return &generated.AppendEntriesReply{}, errors.New("corrupt snapshot: " + err.Error())
+	}
+
+	r.cellManager.treeMutex.Lock()
+	defer r.cellManager.treeMutex.Unlock()
+
+	r.mutex.Lock()
+	if in.Term < r.currentTerm {
+		reply := &generated.AppendEntriesReply{Term: r.currentTerm, Success: false, MatchIndex: r.lastIndex()}
+		r.mutex.Unlock()
+		return reply, nil
+	}
+
+	if err := r.becomeFollower(in.Term); err != nil {
+		r.mutex.Unlock()
+		return &generated.AppendEntriesReply{}, err
+	}
+	r.leaderId = int(in.LeaderId)
+	r.resetElectionDeadline()
+	term := r.currentTerm
+
+	if in.LastIncludedIndex <= r.lastApplied {
+		r.mutex.Unlock()
+		return &generated.AppendEntriesReply{Term: term, Success: true, MatchIndex: in.LastIncludedIndex}, nil
+	}
+
+	if in.LastIncludedIndex <= r.lastIndex() && r.termAt(in.LastIncludedIndex) == in.LastIncludedTerm {
+		r.log = append([]LogEntry{}, r.log[in.LastIncludedIndex-r.snapshotIndex:]...)
+	} else {
+		r.log = make([]LogEntry, 0)
+		r.resolvePending(in.LastIncludedIndex+1, errReplaced)
+	}
+	// the entries in the snapshot were committed, but it does not tell whether they were the ones the changes made
+	for index, change := range r.pending {
+		if index <= in.LastIncludedIndex {
+			delete(r.pending, index)
+			change.result <- status.Error(codes.Unknown, "the outcome of the change was replaced by a snapshot")
+		}
+	}
+	r.snapshotIndex, r.snapshotTerm = in.LastIncludedIndex, in.LastIncludedTerm
+	r.lastApplied = in.LastIncludedIndex
+	r.commitIndex = maxInt64(r.commitIndex, in.LastIncludedIndex)
+	r.mutex.Unlock()
+
+	r.cellManager.restoreSnapshot(stored)
+	if r.store != nil {
+		r.store.lastIndex, r.store.lastTerm = in.LastIncludedIndex, in.LastIncludedTerm
+		if err := r.cellManager.takeSnapshot(); err != nil {
+			return &generated.AppendEntriesReply{}, err
+		}
+	}
+	return &generated.AppendEntriesReply{Term: term, Success: true, MatchIndex: in.LastIncludedIndex}, nil
+}
+
+// truncateFrom drops the entries from index on, which a newer leader has replaced. It must be called with mutex held.
+func (r *replica) truncateFrom(index int64) {
+	r.log = r.log[:index-r.snapshotIndex-1]
+	r.resolvePending(index, errReplaced)
+}
+
+// resolvePending tells the changes waiting for the entries from index on about their outcome, it must be called with
+// mutex held.
+func (r *replica) resolvePending(index int64, outcome error) {
+	for pendingIndex, change := range r.pending {
+		if pendingIndex >= index {
+			delete(r.pending, pendingIndex)
+			change.result <- outcome
+		}
+	}
+}
+
+// becomeFollower stores a newer term before the replica acts in it, it must be called with mutex held.
+func (r *replica) becomeFollower(term int64) error {
+	r.role = follower
+	if term <= r.currentTerm {
+		return nil
+	}
+	r.currentTerm = term
+	r.votedFor = -1
+	r.leaderId = -1
+	return r.persist(r.termRecord())
+}
+
+// becomeLeader must be called with mutex held.
+func (r *replica) becomeLeader() {
+	noOp := LogEntry{Index: r.lastIndex() + 1, Term: r.currentTerm, Type: NoOpEntry}
+	if err := r.persist(noOp); err != nil {
+		r.role = follower
+		println("could not store the entry of the new leader: ", err.Error())
+		return
+	}
+
+	println("replica ", r.id, " is the leader of term ", r.currentTerm)
+	r.role = leader
+	r.leaderId = r.id
+	for peer := range r.addresses {
+		r.nextIndex[peer] = r.lastIndex() + 1
+		r.matchIndex[peer] = 0
+	}
+	r.log = append(r.log, noOp)
+	r.advanceCommitIndex()
+}
+
+// persist writes the records to the log of a replica with persistence, it must be called with mutex held.
+func (r *replica) persist(records ...LogEntry) error {
+	if r.store == nil || len(records) == 0 {
+		return nil
+	}
+	return r.store.appendRecords(records...)
+}
+
+// termRecord must be called with mutex held.
+func (r *replica) termRecord() LogEntry {
+	record := LogEntry{Type: termEntry, Term: r.currentTerm}
+	if r.votedFor >= 0 {
+		record.VotedFor = r.addresses[r.votedFor]
+	}
+	return record
+}
+
+// unappliedRecords returns the term, the vote and the entries that have not been applied, it must be called with
+// mutex held.
+func (r *replica) unappliedRecords() replicaRecords {
+	records := replicaRecords{
+		currentTerm: r.currentTerm,
+		entries:     append([]LogEntry{}, r.log[r.lastApplied-r.snapshotIndex:]...),
+	}
+	if r.votedFor >= 0 {
+		records.votedFor = r.addresses[r.votedFor]
+	}
+	return records
+}
+
+// durableRecords returns what the log must hold besides the snapshot at lastApplied, it must be called with mutex
+// held.
+func (r *replica) durableRecords() []LogEntry {
+	return r.unappliedRecords().records()
+}
+
+// lastIndex must be called with mutex held.
+func (r *replica) lastIndex() int64 {
+	return r.snapshotIndex + int64(len(r.log))
+}
+
+// entryAt returns the entry at an index after the snapshot, it must be called with mutex held.
+func (r *replica) entryAt(index int64) LogEntry {
+	return r.log[index-r.snapshotIndex-1]
+}
+
+// termAt returns the term of the entry at index, 0 for the empty start of the log and for compacted entries other
+// than the last one. It must be called with mutex held.
+func (r *replica) termAt(index int64) int64 {
+	if index == r.snapshotIndex {
+		return r.snapshotTerm
+	}
+	if index < r.snapshotIndex || index > r.lastIndex() {
+		return 0
+	}
+	return r.entryAt(index).Term
+}
+
+// resetElectionDeadline picks a new random election timeout, it must be called with mutex held.
+func (r *replica) resetElectionDeadline() {
+	timeout := constants.ElectionTimeoutMilli + rand.Intn(constants.ElectionTimeoutMilli)
+	r.electionDeadline = time.Now().Add(time.Millisecond * time.Duration(timeout))
+}
+
+func (r *replica) closeConnections() {
+	for _, conn := range r.connections {
+		if conn != nil {
+			conn.Close()
+		}
+	}
+}
+
+func minInt64(a int64, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
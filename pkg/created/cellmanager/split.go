@@ -0,0 +1,122 @@
+package cellmanager
+
+import (
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"sort"
+)
+
+// The axes a cell can be cut in halves along, cutting along x gives a left and a right half.
+const (
+	SplitAxisX = "x"
+	SplitAxisY = "y"
+)
+
+// SetSplitMode sets how PerformSplit splits cells.
+func (cellManager *CellManager) SetSplitMode(mode generated.SplitMode) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.splitMode = mode
+}
+
+// SetMinCellSize sets the smallest width and height a split or resize may leave a cell with, it must be at least 1.
+func (cellManager *CellManager) SetMinCellSize(size int64) error {
+	if size < 1 {
+		return errors.New("the minimum cell size must be at least 1")
+	}
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	cellManager.minCellSize = size
+	return nil
+}
+
+// canDivide reports whether the leaf can be split in the mode without making cells smaller than the minimum cell
+// size, the tree must be locked.
+func (cellManager *CellManager) canDivide(node *CellTreeNode, mode generated.SplitMode) bool {
+	if mode == generated.SplitMode_BALANCED_HALVES {
+		_, _, ok := node.balancedCut(cellManager.minCellSize)
+		return ok
+	}
+	return node.Width/2 >= cellManager.minCellSize && node.Height/2 >= cellManager.minCellSize
+}
+
+// balancedCut returns the axis and coordinate that split the players of the leaf most evenly, players before the
+// coordinate end up in the first half. Of equally balanced cuts the one closest to the middle of the longer side is
+// chosen. It returns false if the leaf is too small to be cut into halves of at least minSize.
+func (node *CellTreeNode) balancedCut(minSize int64) (string, int64, bool) {
+	axes := []string{SplitAxisX, SplitAxisY}
+	if node.Height > node.Width {
+		axes = []string{SplitAxisY, SplitAxisX}
+	}
+
+	bestAxis, bestAt := "", int64(0)
+	bestImbalance, bestOffCentre := 0, int64(0)
+	for _, axis := range axes {
+		start, size := node.PosX, node.Width
+		if axis == SplitAxisY {
+			start, size = node.PosY, node.Height
+		}
+		if size < 2*minSize {
+			continue
+		}
+
+		coordinates := make([]int64, len(node.Players))
+		for index, player := range node.Players {
+			coordinates[index] = player.PosX
+			if axis == SplitAxisY {
+				coordinates[index] = player.PosY
+			}
+		}
+		sort.Slice(coordinates, func(i, j int) bool { return coordinates[i] < coordinates[j] })
+
+		// every way to divide the players is a cut at one of their coordinates, if it leaves both halves large enough
+		middle := start + size/2
+		for _, at := range append([]int64{middle}, coordinates...) {
+			if at < start+minSize || at > start+size-minSize {
+				continue
+			}
+
+			before := sort.Search(len(coordinates), func(i int) bool { return coordinates[i] >= at })
+			imbalance := abs(len(coordinates) - 2*before)
+			offCentre := int64(abs(int(at - middle)))
+			if bestAxis == "" || imbalance < bestImbalance || imbalance == bestImbalance && offCentre < bestOffCentre {
+				bestAxis, bestAt, bestImbalance, bestOffCentre = axis, at, imbalance, offCentre
+			}
+		}
+	}
+	return bestAxis, bestAt, bestAxis != ""
+}
+
+// addHalves cuts the leaf in two at the coordinate along the axis, it must be called with the tree write locked.
+func (cellManager *CellManager) addHalves(node *CellTreeNode, axis string, at int64) {
+	node.addChildren(halfCells(node, axis, at)...)
+	node.distributePlayers()
+	cellManager.indexChildren(node)
+}
+
+// halfCells returns the two halves the leaf is cut into at the coordinate along the axis.
+func halfCells(node *CellTreeNode, axis string, at int64) []*objects.Cell {
+	first := objects.Cell{CellId: childId(node, northKey), PosX: node.PosX, PosY: node.PosY, Width: node.Width, Height: node.Height, Players: make([]objects.Client, 0)}
+	second := objects.Cell{CellId: childId(node, southKey), PosX: node.PosX, PosY: node.PosY, Width: node.Width, Height: node.Height, Players: make([]objects.Client, 0)}
+
+	if axis == SplitAxisX {
+		first.CellId, second.CellId = childId(node, westKey), childId(node, eastKey)
+		first.Width = at - node.PosX
+		second.PosX = at
+		second.Width = node.PosX + node.Width - at
+	} else {
+		first.Height = at - node.PosY
+		second.PosY = at
+		second.Height = node.PosY + node.Height - at
+	}
+
+	return []*objects.Cell{&first, &second}
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
@@ -0,0 +1,68 @@
+package cellmanager
+
+import (
+	"context"
+	"fmt"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+)
+
+// VerifyTiling checks that the leaves cover the world exactly, without gaps or overlap.
+func (cellManager *CellManager) VerifyTiling(
+	ctx context.Context, in *generated.VerifyTilingRequest,
+) (*generated.VerifyTilingReply, error) {
+	violations := cellManager.TilingViolations()
+	return &generated.VerifyTilingReply{Valid: len(violations) == 0, Violations: violations}, nil
+}
+
+// TilingViolations describes every way the tree fails to tile the world exactly, it is empty for a valid tree or a
+// world without cells.
+func (cellManager *CellManager) TilingViolations() []string {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	violations := make([]string, 0)
+	root := cellManager.CellTree
+	if root == nil {
+		return violations
+	}
+
+	if root.PosX != 0 || root.PosY != 0 || root.Width != cellManager.WorldWidth || root.Height != cellManager.WorldHeight {
+		violations = append(violations, fmt.Sprintf("cell %s at (%d, %d) of size (%d, %d) does not cover the world of size (%d, %d)",
+			root.CellId, root.PosX, root.PosY, root.Width, root.Height, cellManager.WorldWidth, cellManager.WorldHeight))
+	}
+	return append(violations, root.tilingViolations()...)
+}
+
+// tilingViolations describes every way the children of the node, and their children, fail to tile it exactly. Children
+// that stay inside the node without overlapping each other tile it exactly if their areas add up to its area.
+func (node *CellTreeNode) tilingViolations() []string {
+	violations := make([]string, 0)
+	if node.Width <= 0 || node.Height <= 0 {
+		violations = append(violations, fmt.Sprintf("cell %s has no area", node.CellId))
+	}
+	if node.isLeaf() {
+		return violations
+	}
+
+	area := int64(0)
+	for index, child := range node.Children {
+		if child.Parent != node {
+			violations = append(violations, fmt.Sprintf("cell %s does not point to its parent %s", child.CellId, node.CellId))
+		}
+		if !node.contains(child.Cell) {
+			violations = append(violations, fmt.Sprintf("cell %s reaches outside its parent %s", child.CellId, node.CellId))
+		}
+		for _, sibling := range node.Children[index+1:] {
+			if child.overlaps(sibling.Cell) {
+				violations = append(violations, fmt.Sprintf("cells %s and %s overlap", child.CellId, sibling.CellId))
+			}
+		}
+		area += child.Width * child.Height
+		violations = append(violations, child.tilingViolations()...)
+	}
+
+	if area != node.Width*node.Height {
+		violations = append(violations, fmt.Sprintf("the children of cell %s cover %d of its %d", node.CellId, area, node.Width*node.Height))
+	}
+	return violations
+}
@@ -0,0 +1,137 @@
+package cellmanager
+
+import (
+	"context"
+	"errors"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+)
+
+// watcherBufferSize is how many events a watcher may fall behind before it is dropped.
+const watcherBufferSize = 256
+
+type topologyWatchers struct {
+	version  int64
+	nextId   int
+	channels map[int]chan *generated.TopologyEvent
+}
+
+// WatchTopology sends a snapshot of the whole tree and then every change to it, each event has a version one higher
+// than the event before it. The stream ends with an error if the watcher cannot keep up.
+func (cellManager *CellManager) WatchTopology(
+	in *generated.WatchTopologyRequest, stream generated.CellManager_WatchTopologyServer,
+) error {
+	cellManager.treeMutex.Lock()
+	id, events := cellManager.addWatcher()
+	snapshot := &generated.TopologyEvent{
+		Version: cellManager.watchers.version,
+		Type:    generated.TopologyEventType_SNAPSHOT,
+		Cells:   make([]*generated.CellInfo, 0),
+	}
+	if cellManager.CellTree != nil {
+		snapshot.Cells = cellInfos(cellManager.CellTree.collectNodes(true))
+	}
+	cellManager.treeMutex.Unlock()
+	defer cellManager.removeWatcher(id)
+
+	if err := stream.Send(snapshot); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("watcher fell behind the topology changes")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// LocateCell returns the leaf at the position with its cell master and topology version. It does not select a cell
+// master, so it can be used to find the current owner of a position after a message was rejected as stale.
+func (cellManager *CellManager) LocateCell(ctx context.Context, in *generated.Position) (*generated.CellInfo, error) {
+	cellManager.treeMutex.RLock()
+	defer cellManager.treeMutex.RUnlock()
+
+	if cellManager.CellTree == nil {
+		return &generated.CellInfo{}, errors.New("world size has not been set")
+	}
+
+	node := cellManager.CellTree.findCollidingCell(in)
+	if node == nil {
+		return &generated.CellInfo{}, errors.New("no cell at the position")
+	}
+	return node.toCellInfo(), nil
+}
+
+// nextTopologyVersion raises the version of the tree and returns it, the tree must be write locked.
+func (cellManager *CellManager) nextTopologyVersion() int64 {
+	cellManager.topologyVersion++
+	return cellManager.topologyVersion
+}
+
+// addWatcher registers a new watcher, the tree must be write locked.
+func (cellManager *CellManager) addWatcher() (int, chan *generated.TopologyEvent) {
+	if cellManager.watchers.channels == nil {
+		cellManager.watchers.channels = make(map[int]chan *generated.TopologyEvent, 0)
+	}
+	id := cellManager.watchers.nextId
+	cellManager.watchers.nextId++
+	events := make(chan *generated.TopologyEvent, watcherBufferSize)
+	cellManager.watchers.channels[id] = events
+	return id, events
+}
+
+func (cellManager *CellManager) removeWatcher(id int) {
+	cellManager.treeMutex.Lock()
+	defer cellManager.treeMutex.Unlock()
+	if events, ok := cellManager.watchers.channels[id]; ok {
+		delete(cellManager.watchers.channels, id)
+		close(events)
+	}
+}
+
+// publishTopologyChange versions the change and sends it to every watcher, the tree must be write locked. Watchers
+// whose buffer is full are dropped instead of blocking the change.
+func (cellManager *CellManager) publishTopologyChange(
+	eventType generated.TopologyEventType, cellId string, nodes []*CellTreeNode,
+) {
+	cellManager.watchers.version++
+	event := &generated.TopologyEvent{
+		Version: cellManager.watchers.version,
+		Type:    eventType,
+		CellId:  cellId,
+		Cells:   cellInfos(nodes),
+	}
+
+	for id, events := range cellManager.watchers.channels {
+		select {
+		case events <- event:
+		default:
+			delete(cellManager.watchers.channels, id)
+			close(events)
+		}
+	}
+}
+
+// publishSnapshot tells the watchers to replace their copy of the tree, used when the tree is replaced as a whole.
+func (cellManager *CellManager) publishSnapshot() {
+	nodes := make([]*CellTreeNode, 0)
+	if cellManager.CellTree != nil {
+		nodes = cellManager.CellTree.collectNodes(true)
+	}
+	cellManager.publishTopologyChange(generated.TopologyEventType_SNAPSHOT, "", nodes)
+}
+
+func cellInfos(nodes []*CellTreeNode) []*generated.CellInfo {
+	infos := make([]*generated.CellInfo, len(nodes))
+	for index, node := range nodes {
+		infos[index] = node.toCellInfo()
+	}
+	return infos
+}
@@ -0,0 +1,104 @@
+package cellmanager
+
+import (
+	"context"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"time"
+)
+
+// The objects the old cell masters of a split or merged cell hand back are passed on to the cell masters of the
+// leaves they are in, by position. Objects in a leaf without a cell master, or whose cell master can not take them,
+// are held by the cell manager and passed on once the leaf is granted, or on the next tick of the planner. Every object
+// carries the tag of the release it came from, so a delivery that timed out is simply delivered again and the cell
+// master drops the objects it already has.
+//
+// Held objects are only kept in the memory of the leader, they are neither persisted nor replicated. They are lost when
+// the leader stops or steps down before they were delivered. The objects an old cell master set aside are lost the same
+// way if it stops before the cell manager collected them.
+
+// objectDelivery is the objects to hand to the cell master of a leaf.
+type objectDelivery struct {
+	cellMaster objects.Client
+	cell       objects.Cell
+	objects    []*objects2.SingleObject
+}
+
+// placeObjects hands the objects, and those held since earlier splits and merges, to the cell masters of the leaves
+// they are in.
+func (cellManager *CellManager) placeObjects(placed []*objects2.SingleObject) {
+	cellManager.treeMutex.Lock()
+	pending := append(append([]*objects2.SingleObject{}, cellManager.heldObjects...), placed...)
+	deliveries := cellManager.assignObjects(pending)
+	cellManager.treeMutex.Unlock()
+
+	for _, delivery := range deliveries {
+		if undelivered := deliverObjects(delivery); len(undelivered) > 0 {
+			cellManager.treeMutex.Lock()
+			cellManager.heldObjects = append(cellManager.heldObjects, undelivered...)
+			cellManager.treeMutex.Unlock()
+		}
+	}
+}
+
+// assignObjects groups the objects by the leaf they are in and holds those in leaves without a cell master, the tree
+// must be write locked.
+func (cellManager *CellManager) assignObjects(pending []*objects2.SingleObject) []*objectDelivery {
+	if cellManager.CellTree == nil {
+		cellManager.heldObjects = pending
+		return nil
+	}
+
+	cellManager.heldObjects = make([]*objects2.SingleObject, 0)
+	deliveries := make([]*objectDelivery, 0)
+	deliveryOf := make(map[string]*objectDelivery, 0)
+	for _, object := range pending {
+		leaf := cellManager.CellTree.findCollidingCell(&generated.Position{PosX: object.PosX, PosY: object.PosY})
+		if leaf == nil {
+			println("dropping object outside the world: ", object.ObjectId)
+			continue
+		}
+		if leaf.CellMaster == nil {
+			cellManager.heldObjects = append(cellManager.heldObjects, object)
+			continue
+		}
+
+		delivery, ok := deliveryOf[leaf.CellId]
+		if !ok {
+			delivery = &objectDelivery{cellMaster: *leaf.CellMaster, cell: *leaf.Cell}
+			deliveryOf[leaf.CellId] = delivery
+			deliveries = append(deliveries, delivery)
+		}
+		delivery.objects = append(delivery.objects, object)
+	}
+	return deliveries
+}
+
+// deliverObjects requests the mutations of the objects at the cell master of the leaf, addressed to the leaf. It
+// returns the objects that were not delivered.
+func deliverObjects(delivery *objectDelivery) []*objects2.SingleObject {
+	address := objects.ToAddress(delivery.cellMaster.Ip, delivery.cellMaster.Port)
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithTimeout(time.Millisecond*constants.DialTimeoutMilli))
+	if err != nil {
+		println("could not deliver objects: ", err.Error())
+		return delivery.objects
+	}
+	defer conn.Close()
+	cellMaster := objects2.NewPlayerClient(conn)
+
+	for index, object := range delivery.objects {
+		object.CellId = delivery.cell.CellId
+		object.TopologyVersion = delivery.cell.TopologyVersion
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_, err := cellMaster.RequestObjectMutation(ctx, object)
+		cancel()
+		if err != nil {
+			println("could not deliver objects: ", err.Error())
+			return delivery.objects[index:]
+		}
+	}
+	return nil
+}
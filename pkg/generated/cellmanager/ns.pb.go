@@ -29,20 +29,133 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
-type Cell struct {
+// the ways DivideCell can split a cell
+type SplitMode int32
+
+const (
+	// four equal quadrants
+	SplitMode_QUADRANTS SplitMode = 0
+	// two halves, cut along the axis and coordinate that best balance the players registered in the cell
+	SplitMode_BALANCED_HALVES SplitMode = 1
+)
+
+// Enum value maps for SplitMode.
+var (
+	SplitMode_name = map[int32]string{
+		0: "QUADRANTS",
+		1: "BALANCED_HALVES",
+	}
+	SplitMode_value = map[string]int32{
+		"QUADRANTS":       0,
+		"BALANCED_HALVES": 1,
+	}
+)
+
+func (x SplitMode) Enum() *SplitMode {
+	p := new(SplitMode)
+	*p = x
+	return p
+}
+
+func (x SplitMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SplitMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_ns_proto_enumTypes[0].Descriptor()
+}
+
+func (SplitMode) Type() protoreflect.EnumType {
+	return &file_ns_proto_enumTypes[0]
+}
+
+func (x SplitMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SplitMode.Descriptor instead.
+func (SplitMode) EnumDescriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{0}
+}
+
+type TopologyEventType int32
+
+const (
+	// the whole tree, always the first event of a watch
+	TopologyEventType_SNAPSHOT            TopologyEventType = 0
+	TopologyEventType_WORLD_CREATED       TopologyEventType = 1
+	TopologyEventType_SPLIT               TopologyEventType = 2
+	TopologyEventType_MERGE               TopologyEventType = 3
+	TopologyEventType_RESIZE              TopologyEventType = 4
+	TopologyEventType_CELL_MASTER_CHANGED TopologyEventType = 5
+	TopologyEventType_LOCKED              TopologyEventType = 6
+	TopologyEventType_UNLOCKED            TopologyEventType = 7
+)
+
+// Enum value maps for TopologyEventType.
+var (
+	TopologyEventType_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "WORLD_CREATED",
+		2: "SPLIT",
+		3: "MERGE",
+		4: "RESIZE",
+		5: "CELL_MASTER_CHANGED",
+		6: "LOCKED",
+		7: "UNLOCKED",
+	}
+	TopologyEventType_value = map[string]int32{
+		"SNAPSHOT":            0,
+		"WORLD_CREATED":       1,
+		"SPLIT":               2,
+		"MERGE":               3,
+		"RESIZE":              4,
+		"CELL_MASTER_CHANGED": 5,
+		"LOCKED":              6,
+		"UNLOCKED":            7,
+	}
+)
+
+func (x TopologyEventType) Enum() *TopologyEventType {
+	p := new(TopologyEventType)
+	*p = x
+	return p
+}
+
+func (x TopologyEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TopologyEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_ns_proto_enumTypes[1].Descriptor()
+}
+
+func (TopologyEventType) Type() protoreflect.EnumType {
+	return &file_ns_proto_enumTypes[1]
+}
+
+func (x TopologyEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TopologyEventType.Descriptor instead.
+func (TopologyEventType) EnumDescriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{1}
+}
+
+type VoteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
-	PosX   int64  `protobuf:"varint,2,opt,name=posX,proto3" json:"posX,omitempty"`
-	PosY   int64  `protobuf:"varint,3,opt,name=posY,proto3" json:"posY,omitempty"`
-	Width  int64  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
-	Height int64  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Term         int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	CandidateId  int32 `protobuf:"varint,2,opt,name=candidateId,proto3" json:"candidateId,omitempty"`
+	LastLogIndex int64 `protobuf:"varint,3,opt,name=lastLogIndex,proto3" json:"lastLogIndex,omitempty"`
+	LastLogTerm  int64 `protobuf:"varint,4,opt,name=lastLogTerm,proto3" json:"lastLogTerm,omitempty"`
 }
 
-func (x *Cell) Reset() {
-	*x = Cell{}
+func (x *VoteRequest) Reset() {
+	*x = VoteRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -50,13 +163,13 @@ func (x *Cell) Reset() {
 	}
 }
 
-func (x *Cell) String() string {
+func (x *VoteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Cell) ProtoMessage() {}
+func (*VoteRequest) ProtoMessage() {}
 
-func (x *Cell) ProtoReflect() protoreflect.Message {
+func (x *VoteRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -68,56 +181,50 @@ func (x *Cell) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
-func (*Cell) Descriptor() ([]byte, []int) {
+// Deprecated: Use VoteRequest.ProtoReflect.Descriptor instead.
+func (*VoteRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Cell) GetCellId() string {
-	if x != nil {
-		return x.CellId
-	}
-	return ""
-}
-
-func (x *Cell) GetPosX() int64 {
+func (x *VoteRequest) GetTerm() int64 {
 	if x != nil {
-		return x.PosX
+		return x.Term
 	}
 	return 0
 }
 
-func (x *Cell) GetPosY() int64 {
+func (x *VoteRequest) GetCandidateId() int32 {
 	if x != nil {
-		return x.PosY
+		return x.CandidateId
 	}
 	return 0
 }
 
-func (x *Cell) GetWidth() int64 {
+func (x *VoteRequest) GetLastLogIndex() int64 {
 	if x != nil {
-		return x.Width
+		return x.LastLogIndex
 	}
 	return 0
 }
 
-func (x *Cell) GetHeight() int64 {
+func (x *VoteRequest) GetLastLogTerm() int64 {
 	if x != nil {
-		return x.Height
+		return x.LastLogTerm
 	}
 	return 0
 }
 
-type CellListReply struct {
+type VoteReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cells []*Cell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	Term        int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	VoteGranted bool  `protobuf:"varint,2,opt,name=voteGranted,proto3" json:"voteGranted,omitempty"`
 }
 
-func (x *CellListReply) Reset() {
-	*x = CellListReply{}
+func (x *VoteReply) Reset() {
+	*x = VoteReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -125,13 +232,13 @@ func (x *CellListReply) Reset() {
 	}
 }
 
-func (x *CellListReply) String() string {
+func (x *VoteReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellListReply) ProtoMessage() {}
+func (*VoteReply) ProtoMessage() {}
 
-func (x *CellListReply) ProtoReflect() protoreflect.Message {
+func (x *VoteReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -143,28 +250,37 @@ func (x *CellListReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellListReply.ProtoReflect.Descriptor instead.
-func (*CellListReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use VoteReply.ProtoReflect.Descriptor instead.
+func (*VoteReply) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CellListReply) GetCells() []*Cell {
+func (x *VoteReply) GetTerm() int64 {
 	if x != nil {
-		return x.Cells
+		return x.Term
 	}
-	return nil
+	return 0
 }
 
-type TransactionSucceeded struct {
+func (x *VoteReply) GetVoteGranted() bool {
+	if x != nil {
+		return x.VoteGranted
+	}
+	return false
+}
+
+type ReplicatedEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Term int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	// a JSON encoded LogEntry
+	Entry []byte `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
 }
 
-func (x *TransactionSucceeded) Reset() {
-	*x = TransactionSucceeded{}
+func (x *ReplicatedEntry) Reset() {
+	*x = ReplicatedEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -172,13 +288,13 @@ func (x *TransactionSucceeded) Reset() {
 	}
 }
 
-func (x *TransactionSucceeded) String() string {
+func (x *ReplicatedEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TransactionSucceeded) ProtoMessage() {}
+func (*ReplicatedEntry) ProtoMessage() {}
 
-func (x *TransactionSucceeded) ProtoReflect() protoreflect.Message {
+func (x *ReplicatedEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -190,28 +306,40 @@ func (x *TransactionSucceeded) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TransactionSucceeded.ProtoReflect.Descriptor instead.
-func (*TransactionSucceeded) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReplicatedEntry.ProtoReflect.Descriptor instead.
+func (*ReplicatedEntry) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *TransactionSucceeded) GetSucceeded() bool {
+func (x *ReplicatedEntry) GetTerm() int64 {
 	if x != nil {
-		return x.Succeeded
+		return x.Term
 	}
-	return false
+	return 0
 }
 
-type CellNeighbourRequest struct {
+func (x *ReplicatedEntry) GetEntry() []byte {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type AppendEntriesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Term         int64              `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId     int32              `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	PrevLogIndex int64              `protobuf:"varint,3,opt,name=prevLogIndex,proto3" json:"prevLogIndex,omitempty"`
+	PrevLogTerm  int64              `protobuf:"varint,4,opt,name=prevLogTerm,proto3" json:"prevLogTerm,omitempty"`
+	Entries      []*ReplicatedEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	LeaderCommit int64              `protobuf:"varint,6,opt,name=leaderCommit,proto3" json:"leaderCommit,omitempty"`
 }
 
-func (x *CellNeighbourRequest) Reset() {
-	*x = CellNeighbourRequest{}
+func (x *AppendEntriesRequest) Reset() {
+	*x = AppendEntriesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -219,13 +347,13 @@ func (x *CellNeighbourRequest) Reset() {
 	}
 }
 
-func (x *CellNeighbourRequest) String() string {
+func (x *AppendEntriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellNeighbourRequest) ProtoMessage() {}
+func (*AppendEntriesRequest) ProtoMessage() {}
 
-func (x *CellNeighbourRequest) ProtoReflect() protoreflect.Message {
+func (x *AppendEntriesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,30 +365,66 @@ func (x *CellNeighbourRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellNeighbourRequest.ProtoReflect.Descriptor instead.
-func (*CellNeighbourRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendEntriesRequest.ProtoReflect.Descriptor instead.
+func (*AppendEntriesRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CellNeighbourRequest) GetCellId() string {
+func (x *AppendEntriesRequest) GetTerm() int64 {
 	if x != nil {
-		return x.CellId
+		return x.Term
 	}
-	return ""
+	return 0
 }
 
-type CellChangeSizeRequest struct {
+func (x *AppendEntriesRequest) GetLeaderId() int32 {
+	if x != nil {
+		return x.LeaderId
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetPrevLogIndex() int64 {
+	if x != nil {
+		return x.PrevLogIndex
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetPrevLogTerm() int64 {
+	if x != nil {
+		return x.PrevLogTerm
+	}
+	return 0
+}
+
+func (x *AppendEntriesRequest) GetEntries() []*ReplicatedEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AppendEntriesRequest) GetLeaderCommit() int64 {
+	if x != nil {
+		return x.LeaderCommit
+	}
+	return 0
+}
+
+type AppendEntriesReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId    string  `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
-	NewHeight float32 `protobuf:"fixed32,2,opt,name=newHeight,proto3" json:"newHeight,omitempty"`
-	NewWidth  float32 `protobuf:"fixed32,3,opt,name=newWidth,proto3" json:"newWidth,omitempty"`
+	Term    int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Success bool  `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// the index of the last entry known to match the leader's log
+	MatchIndex int64 `protobuf:"varint,3,opt,name=matchIndex,proto3" json:"matchIndex,omitempty"`
 }
 
-func (x *CellChangeSizeRequest) Reset() {
-	*x = CellChangeSizeRequest{}
+func (x *AppendEntriesReply) Reset() {
+	*x = AppendEntriesReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -268,13 +432,13 @@ func (x *CellChangeSizeRequest) Reset() {
 	}
 }
 
-func (x *CellChangeSizeRequest) String() string {
+func (x *AppendEntriesReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellChangeSizeRequest) ProtoMessage() {}
+func (*AppendEntriesReply) ProtoMessage() {}
 
-func (x *CellChangeSizeRequest) ProtoReflect() protoreflect.Message {
+func (x *AppendEntriesReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -286,43 +450,48 @@ func (x *CellChangeSizeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellChangeSizeRequest.ProtoReflect.Descriptor instead.
-func (*CellChangeSizeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendEntriesReply.ProtoReflect.Descriptor instead.
+func (*AppendEntriesReply) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CellChangeSizeRequest) GetCellId() string {
+func (x *AppendEntriesReply) GetTerm() int64 {
 	if x != nil {
-		return x.CellId
+		return x.Term
 	}
-	return ""
+	return 0
 }
 
-func (x *CellChangeSizeRequest) GetNewHeight() float32 {
+func (x *AppendEntriesReply) GetSuccess() bool {
 	if x != nil {
-		return x.NewHeight
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *CellChangeSizeRequest) GetNewWidth() float32 {
+func (x *AppendEntriesReply) GetMatchIndex() int64 {
 	if x != nil {
-		return x.NewWidth
+		return x.MatchIndex
 	}
 	return 0
 }
 
-type WorldSize struct {
+// InstallSnapshotRequest replaces the log of a replica that lags behind the compacted log of the leader
+type InstallSnapshotRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
-	Width  int64 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Term              int64 `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	LeaderId          int32 `protobuf:"varint,2,opt,name=leaderId,proto3" json:"leaderId,omitempty"`
+	LastIncludedIndex int64 `protobuf:"varint,3,opt,name=lastIncludedIndex,proto3" json:"lastIncludedIndex,omitempty"`
+	LastIncludedTerm  int64 `protobuf:"varint,4,opt,name=lastIncludedTerm,proto3" json:"lastIncludedTerm,omitempty"`
+	// the JSON encoded state after applying every entry up to lastIncludedIndex
+	Data []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *WorldSize) Reset() {
-	*x = WorldSize{}
+func (x *InstallSnapshotRequest) Reset() {
+	*x = InstallSnapshotRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -330,13 +499,13 @@ func (x *WorldSize) Reset() {
 	}
 }
 
-func (x *WorldSize) String() string {
+func (x *InstallSnapshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WorldSize) ProtoMessage() {}
+func (*InstallSnapshotRequest) ProtoMessage() {}
 
-func (x *WorldSize) ProtoReflect() protoreflect.Message {
+func (x *InstallSnapshotRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -348,36 +517,60 @@ func (x *WorldSize) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WorldSize.ProtoReflect.Descriptor instead.
-func (*WorldSize) Descriptor() ([]byte, []int) {
+// Deprecated: Use InstallSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*InstallSnapshotRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *WorldSize) GetHeight() int64 {
+func (x *InstallSnapshotRequest) GetTerm() int64 {
 	if x != nil {
-		return x.Height
+		return x.Term
 	}
 	return 0
 }
 
-func (x *WorldSize) GetWidth() int64 {
+func (x *InstallSnapshotRequest) GetLeaderId() int32 {
 	if x != nil {
-		return x.Width
+		return x.LeaderId
 	}
 	return 0
 }
 
-type LockCellsRequest struct {
+func (x *InstallSnapshotRequest) GetLastIncludedIndex() int64 {
+	if x != nil {
+		return x.LastIncludedIndex
+	}
+	return 0
+}
+
+func (x *InstallSnapshotRequest) GetLastIncludedTerm() int64 {
+	if x != nil {
+		return x.LastIncludedTerm
+	}
+	return 0
+}
+
+func (x *InstallSnapshotRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type Cell struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId       []string `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
-	SenderCellId string   `protobuf:"bytes,2,opt,name=senderCellId,proto3" json:"senderCellId,omitempty"`
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	PosX   int64  `protobuf:"varint,2,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY   int64  `protobuf:"varint,3,opt,name=posY,proto3" json:"posY,omitempty"`
+	Width  int64  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height int64  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
 }
 
-func (x *LockCellsRequest) Reset() {
-	*x = LockCellsRequest{}
+func (x *Cell) Reset() {
+	*x = Cell{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -385,13 +578,13 @@ func (x *LockCellsRequest) Reset() {
 	}
 }
 
-func (x *LockCellsRequest) String() string {
+func (x *Cell) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LockCellsRequest) ProtoMessage() {}
+func (*Cell) ProtoMessage() {}
 
-func (x *LockCellsRequest) ProtoReflect() protoreflect.Message {
+func (x *Cell) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -403,37 +596,56 @@ func (x *LockCellsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LockCellsRequest.ProtoReflect.Descriptor instead.
-func (*LockCellsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Cell.ProtoReflect.Descriptor instead.
+func (*Cell) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *LockCellsRequest) GetCellId() []string {
+func (x *Cell) GetCellId() string {
 	if x != nil {
 		return x.CellId
 	}
-	return nil
+	return ""
 }
 
-func (x *LockCellsRequest) GetSenderCellId() string {
+func (x *Cell) GetPosX() int64 {
 	if x != nil {
-		return x.SenderCellId
+		return x.PosX
 	}
-	return ""
+	return 0
 }
 
-type PlayerInCellRequest struct {
+func (x *Cell) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+func (x *Cell) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Cell) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type CellListReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip     string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port   int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	CellId string `protobuf:"bytes,3,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Cells []*Cell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
 }
 
-func (x *PlayerInCellRequest) Reset() {
-	*x = PlayerInCellRequest{}
+func (x *CellListReply) Reset() {
+	*x = CellListReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,13 +653,13 @@ func (x *PlayerInCellRequest) Reset() {
 	}
 }
 
-func (x *PlayerInCellRequest) String() string {
+func (x *CellListReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInCellRequest) ProtoMessage() {}
+func (*CellListReply) ProtoMessage() {}
 
-func (x *PlayerInCellRequest) ProtoReflect() protoreflect.Message {
+func (x *CellListReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -459,43 +671,28 @@ func (x *PlayerInCellRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInCellRequest.ProtoReflect.Descriptor instead.
-func (*PlayerInCellRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellListReply.ProtoReflect.Descriptor instead.
+func (*CellListReply) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *PlayerInCellRequest) GetIp() string {
-	if x != nil {
-		return x.Ip
-	}
-	return ""
-}
-
-func (x *PlayerInCellRequest) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-func (x *PlayerInCellRequest) GetCellId() string {
+func (x *CellListReply) GetCells() []*Cell {
 	if x != nil {
-		return x.CellId
+		return x.Cells
 	}
-	return ""
+	return nil
 }
 
-type Position struct {
+type TransactionSucceeded struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PosX int64 `protobuf:"varint,1,opt,name=posX,proto3" json:"posX,omitempty"`
-	PosY int64 `protobuf:"varint,2,opt,name=posY,proto3" json:"posY,omitempty"`
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
 }
 
-func (x *Position) Reset() {
-	*x = Position{}
+func (x *TransactionSucceeded) Reset() {
+	*x = TransactionSucceeded{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -503,13 +700,13 @@ func (x *Position) Reset() {
 	}
 }
 
-func (x *Position) String() string {
+func (x *TransactionSucceeded) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Position) ProtoMessage() {}
+func (*TransactionSucceeded) ProtoMessage() {}
 
-func (x *Position) ProtoReflect() protoreflect.Message {
+func (x *TransactionSucceeded) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -521,38 +718,28 @@ func (x *Position) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Position.ProtoReflect.Descriptor instead.
-func (*Position) Descriptor() ([]byte, []int) {
+// Deprecated: Use TransactionSucceeded.ProtoReflect.Descriptor instead.
+func (*TransactionSucceeded) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Position) GetPosX() int64 {
-	if x != nil {
-		return x.PosX
-	}
-	return 0
-}
-
-func (x *Position) GetPosY() int64 {
+func (x *TransactionSucceeded) GetSucceeded() bool {
 	if x != nil {
-		return x.PosY
+		return x.Succeeded
 	}
-	return 0
+	return false
 }
 
-type PlayerInCellRequestWithPositions struct {
+type CellNeighbourRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip   string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
-	PosX int64  `protobuf:"varint,3,opt,name=posX,proto3" json:"posX,omitempty"`
-	PosY int64  `protobuf:"varint,4,opt,name=posY,proto3" json:"posY,omitempty"`
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
 }
 
-func (x *PlayerInCellRequestWithPositions) Reset() {
-	*x = PlayerInCellRequestWithPositions{}
+func (x *CellNeighbourRequest) Reset() {
+	*x = CellNeighbourRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -560,13 +747,13 @@ func (x *PlayerInCellRequestWithPositions) Reset() {
 	}
 }
 
-func (x *PlayerInCellRequestWithPositions) String() string {
+func (x *CellNeighbourRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerInCellRequestWithPositions) ProtoMessage() {}
+func (*CellNeighbourRequest) ProtoMessage() {}
 
-func (x *PlayerInCellRequestWithPositions) ProtoReflect() protoreflect.Message {
+func (x *CellNeighbourRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -578,47 +765,31 @@ func (x *PlayerInCellRequestWithPositions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerInCellRequestWithPositions.ProtoReflect.Descriptor instead.
-func (*PlayerInCellRequestWithPositions) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellNeighbourRequest.ProtoReflect.Descriptor instead.
+func (*CellNeighbourRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *PlayerInCellRequestWithPositions) GetIp() string {
+func (x *CellNeighbourRequest) GetCellId() string {
 	if x != nil {
-		return x.Ip
+		return x.CellId
 	}
 	return ""
 }
 
-func (x *PlayerInCellRequestWithPositions) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-func (x *PlayerInCellRequestWithPositions) GetPosX() int64 {
-	if x != nil {
-		return x.PosX
-	}
-	return 0
-}
-
-func (x *PlayerInCellRequestWithPositions) GetPosY() int64 {
-	if x != nil {
-		return x.PosY
-	}
-	return 0
-}
-
-type ListCellsRequest struct {
+// the corner the cell shares with its parent stays in place, the borders to the siblings are moved
+type CellChangeSizeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	CellId    string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	NewHeight int64  `protobuf:"varint,2,opt,name=newHeight,proto3" json:"newHeight,omitempty"`
+	NewWidth  int64  `protobuf:"varint,3,opt,name=newWidth,proto3" json:"newWidth,omitempty"`
 }
 
-func (x *ListCellsRequest) Reset() {
-	*x = ListCellsRequest{}
+func (x *CellChangeSizeRequest) Reset() {
+	*x = CellChangeSizeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -626,13 +797,13 @@ func (x *ListCellsRequest) Reset() {
 	}
 }
 
-func (x *ListCellsRequest) String() string {
+func (x *CellChangeSizeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCellsRequest) ProtoMessage() {}
+func (*CellChangeSizeRequest) ProtoMessage() {}
 
-func (x *ListCellsRequest) ProtoReflect() protoreflect.Message {
+func (x *CellChangeSizeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,21 +815,43 @@ func (x *ListCellsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCellsRequest.ProtoReflect.Descriptor instead.
-func (*ListCellsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellChangeSizeRequest.ProtoReflect.Descriptor instead.
+func (*CellChangeSizeRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{10}
 }
 
-type ListPlayersRequest struct {
+func (x *CellChangeSizeRequest) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *CellChangeSizeRequest) GetNewHeight() int64 {
+	if x != nil {
+		return x.NewHeight
+	}
+	return 0
+}
+
+func (x *CellChangeSizeRequest) GetNewWidth() int64 {
+	if x != nil {
+		return x.NewWidth
+	}
+	return 0
+}
+
+type WorldSize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Width  int64 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
 }
 
-func (x *ListPlayersRequest) Reset() {
-	*x = ListPlayersRequest{}
+func (x *WorldSize) Reset() {
+	*x = WorldSize{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -666,13 +859,13 @@ func (x *ListPlayersRequest) Reset() {
 	}
 }
 
-func (x *ListPlayersRequest) String() string {
+func (x *WorldSize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListPlayersRequest) ProtoMessage() {}
+func (*WorldSize) ProtoMessage() {}
 
-func (x *ListPlayersRequest) ProtoReflect() protoreflect.Message {
+func (x *WorldSize) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -684,28 +877,36 @@ func (x *ListPlayersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListPlayersRequest.ProtoReflect.Descriptor instead.
-func (*ListPlayersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WorldSize.ProtoReflect.Descriptor instead.
+func (*WorldSize) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ListPlayersRequest) GetCellId() string {
+func (x *WorldSize) GetHeight() int64 {
 	if x != nil {
-		return x.CellId
+		return x.Height
 	}
-	return ""
+	return 0
 }
 
-type CellMasterRequest struct {
+func (x *WorldSize) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+type LockCellsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	CellId       []string `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
+	SenderCellId string   `protobuf:"bytes,2,opt,name=senderCellId,proto3" json:"senderCellId,omitempty"`
 }
 
-func (x *CellMasterRequest) Reset() {
-	*x = CellMasterRequest{}
+func (x *LockCellsRequest) Reset() {
+	*x = LockCellsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -713,13 +914,13 @@ func (x *CellMasterRequest) Reset() {
 	}
 }
 
-func (x *CellMasterRequest) String() string {
+func (x *LockCellsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellMasterRequest) ProtoMessage() {}
+func (*LockCellsRequest) ProtoMessage() {}
 
-func (x *CellMasterRequest) ProtoReflect() protoreflect.Message {
+func (x *LockCellsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -731,28 +932,37 @@ func (x *CellMasterRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellMasterRequest.ProtoReflect.Descriptor instead.
-func (*CellMasterRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LockCellsRequest.ProtoReflect.Descriptor instead.
+func (*LockCellsRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CellMasterRequest) GetCellId() string {
+func (x *LockCellsRequest) GetCellId() []string {
 	if x != nil {
 		return x.CellId
 	}
+	return nil
+}
+
+func (x *LockCellsRequest) GetSenderCellId() string {
+	if x != nil {
+		return x.SenderCellId
+	}
 	return ""
 }
 
-type CellMasterStatusReply struct {
+type PlayerInCellRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	WasUnregistered bool `protobuf:"varint,1,opt,name=wasUnregistered,proto3" json:"wasUnregistered,omitempty"`
+	Ip     string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port   int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	CellId string `protobuf:"bytes,3,opt,name=cellId,proto3" json:"cellId,omitempty"`
 }
 
-func (x *CellMasterStatusReply) Reset() {
-	*x = CellMasterStatusReply{}
+func (x *PlayerInCellRequest) Reset() {
+	*x = PlayerInCellRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -760,13 +970,13 @@ func (x *CellMasterStatusReply) Reset() {
 	}
 }
 
-func (x *CellMasterStatusReply) String() string {
+func (x *PlayerInCellRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellMasterStatusReply) ProtoMessage() {}
+func (*PlayerInCellRequest) ProtoMessage() {}
 
-func (x *CellMasterStatusReply) ProtoReflect() protoreflect.Message {
+func (x *PlayerInCellRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -778,28 +988,43 @@ func (x *CellMasterStatusReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellMasterStatusReply.ProtoReflect.Descriptor instead.
-func (*CellMasterStatusReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerInCellRequest.ProtoReflect.Descriptor instead.
+func (*PlayerInCellRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *CellMasterStatusReply) GetWasUnregistered() bool {
+func (x *PlayerInCellRequest) GetIp() string {
 	if x != nil {
-		return x.WasUnregistered
+		return x.Ip
 	}
-	return false
+	return ""
 }
 
-type PlayerStatusReply struct {
+func (x *PlayerInCellRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PlayerInCellRequest) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+type Position struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PlayerLeft bool `protobuf:"varint,1,opt,name=playerLeft,proto3" json:"playerLeft,omitempty"`
+	PosX int64 `protobuf:"varint,1,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY int64 `protobuf:"varint,2,opt,name=posY,proto3" json:"posY,omitempty"`
 }
 
-func (x *PlayerStatusReply) Reset() {
-	*x = PlayerStatusReply{}
+func (x *Position) Reset() {
+	*x = Position{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -807,13 +1032,13 @@ func (x *PlayerStatusReply) Reset() {
 	}
 }
 
-func (x *PlayerStatusReply) String() string {
+func (x *Position) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayerStatusReply) ProtoMessage() {}
+func (*Position) ProtoMessage() {}
 
-func (x *PlayerStatusReply) ProtoReflect() protoreflect.Message {
+func (x *Position) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -825,28 +1050,39 @@ func (x *PlayerStatusReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayerStatusReply.ProtoReflect.Descriptor instead.
-func (*PlayerStatusReply) Descriptor() ([]byte, []int) {
-	return file_ns_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *PlayerStatusReply) GetPlayerLeft() bool {
+func (x *Position) GetPosX() int64 {
 	if x != nil {
-		return x.PlayerLeft
+		return x.PosX
 	}
-	return false
+	return 0
 }
 
-type CellRequest struct {
+func (x *Position) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+type PlayerInCellRequestWithPositions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Ip       string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	PosX     int64  `protobuf:"varint,3,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY     int64  `protobuf:"varint,4,opt,name=posY,proto3" json:"posY,omitempty"`
+	ObjectId string `protobuf:"bytes,5,opt,name=objectId,proto3" json:"objectId,omitempty"`
 }
 
-func (x *CellRequest) Reset() {
-	*x = CellRequest{}
+func (x *PlayerInCellRequestWithPositions) Reset() {
+	*x = PlayerInCellRequestWithPositions{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -854,13 +1090,13 @@ func (x *CellRequest) Reset() {
 	}
 }
 
-func (x *CellRequest) String() string {
+func (x *PlayerInCellRequestWithPositions) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellRequest) ProtoMessage() {}
+func (*PlayerInCellRequestWithPositions) ProtoMessage() {}
 
-func (x *CellRequest) ProtoReflect() protoreflect.Message {
+func (x *PlayerInCellRequestWithPositions) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -872,28 +1108,57 @@ func (x *CellRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellRequest.ProtoReflect.Descriptor instead.
-func (*CellRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PlayerInCellRequestWithPositions.ProtoReflect.Descriptor instead.
+func (*PlayerInCellRequestWithPositions) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *CellRequest) GetCellId() string {
+func (x *PlayerInCellRequestWithPositions) GetIp() string {
 	if x != nil {
-		return x.CellId
+		return x.Ip
 	}
 	return ""
 }
 
-type CellNeighboursReply struct {
+func (x *PlayerInCellRequestWithPositions) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PlayerInCellRequestWithPositions) GetPosX() int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return 0
+}
+
+func (x *PlayerInCellRequestWithPositions) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+func (x *PlayerInCellRequestWithPositions) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+type ListCellsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId []string `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
+	// when false only the leaves, i.e. the cells that are actually played in, are listed
+	IncludeInterior bool `protobuf:"varint,1,opt,name=includeInterior,proto3" json:"includeInterior,omitempty"`
 }
 
-func (x *CellNeighboursReply) Reset() {
-	*x = CellNeighboursReply{}
+func (x *ListCellsRequest) Reset() {
+	*x = ListCellsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -901,13 +1166,13 @@ func (x *CellNeighboursReply) Reset() {
 	}
 }
 
-func (x *CellNeighboursReply) String() string {
+func (x *ListCellsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellNeighboursReply) ProtoMessage() {}
+func (*ListCellsRequest) ProtoMessage() {}
 
-func (x *CellNeighboursReply) ProtoReflect() protoreflect.Message {
+func (x *ListCellsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -919,28 +1184,28 @@ func (x *CellNeighboursReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellNeighboursReply.ProtoReflect.Descriptor instead.
-func (*CellNeighboursReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListCellsRequest.ProtoReflect.Descriptor instead.
+func (*ListCellsRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *CellNeighboursReply) GetCellId() []string {
+func (x *ListCellsRequest) GetIncludeInterior() bool {
 	if x != nil {
-		return x.CellId
+		return x.IncludeInterior
 	}
-	return nil
+	return false
 }
 
-type CellChangeStatusReply struct {
+type ListPlayersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
 }
 
-func (x *CellChangeStatusReply) Reset() {
-	*x = CellChangeStatusReply{}
+func (x *ListPlayersRequest) Reset() {
+	*x = ListPlayersRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -948,13 +1213,13 @@ func (x *CellChangeStatusReply) Reset() {
 	}
 }
 
-func (x *CellChangeStatusReply) String() string {
+func (x *ListPlayersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellChangeStatusReply) ProtoMessage() {}
+func (*ListPlayersRequest) ProtoMessage() {}
 
-func (x *CellChangeStatusReply) ProtoReflect() protoreflect.Message {
+func (x *ListPlayersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -966,29 +1231,30 @@ func (x *CellChangeStatusReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellChangeStatusReply.ProtoReflect.Descriptor instead.
-func (*CellChangeStatusReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListPlayersRequest.ProtoReflect.Descriptor instead.
+func (*ListPlayersRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CellChangeStatusReply) GetSucceeded() bool {
+func (x *ListPlayersRequest) GetCellId() string {
 	if x != nil {
-		return x.Succeeded
+		return x.CellId
 	}
-	return false
+	return ""
 }
 
-type CellLockStatusReply struct {
+type CellMasterRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Locked bool   `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
-	Lockee string `protobuf:"bytes,2,opt,name=lockee,proto3" json:"lockee,omitempty"`
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	// the epoch of the cell master unregistering, 0 if the request is not from the cell master
+	Epoch int64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
 }
 
-func (x *CellLockStatusReply) Reset() {
-	*x = CellLockStatusReply{}
+func (x *CellMasterRequest) Reset() {
+	*x = CellMasterRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -996,13 +1262,13 @@ func (x *CellLockStatusReply) Reset() {
 	}
 }
 
-func (x *CellLockStatusReply) String() string {
+func (x *CellMasterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellLockStatusReply) ProtoMessage() {}
+func (*CellMasterRequest) ProtoMessage() {}
 
-func (x *CellLockStatusReply) ProtoReflect() protoreflect.Message {
+func (x *CellMasterRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1014,35 +1280,39 @@ func (x *CellLockStatusReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellLockStatusReply.ProtoReflect.Descriptor instead.
-func (*CellLockStatusReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellMasterRequest.ProtoReflect.Descriptor instead.
+func (*CellMasterRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CellLockStatusReply) GetLocked() bool {
+func (x *CellMasterRequest) GetCellId() string {
 	if x != nil {
-		return x.Locked
+		return x.CellId
 	}
-	return false
+	return ""
 }
 
-func (x *CellLockStatusReply) GetLockee() string {
+func (x *CellMasterRequest) GetEpoch() int64 {
 	if x != nil {
-		return x.Lockee
+		return x.Epoch
 	}
-	return ""
+	return 0
 }
 
-type CellStatusReply struct {
+// a cell master renewing the lease on its cell, the epoch is the one it was granted the cell with
+type LeaseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	WasPerformed bool `protobuf:"varint,1,opt,name=wasPerformed,proto3" json:"wasPerformed,omitempty"`
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Ip     string `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port   int32  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	Epoch  int64  `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
 }
 
-func (x *CellStatusReply) Reset() {
-	*x = CellStatusReply{}
+func (x *LeaseRequest) Reset() {
+	*x = LeaseRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1050,13 +1320,13 @@ func (x *CellStatusReply) Reset() {
 	}
 }
 
-func (x *CellStatusReply) String() string {
+func (x *LeaseRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellStatusReply) ProtoMessage() {}
+func (*LeaseRequest) ProtoMessage() {}
 
-func (x *CellStatusReply) ProtoReflect() protoreflect.Message {
+func (x *LeaseRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,28 +1338,52 @@ func (x *CellStatusReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CellStatusReply.ProtoReflect.Descriptor instead.
-func (*CellStatusReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaseRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRequest) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CellStatusReply) GetWasPerformed() bool {
+func (x *LeaseRequest) GetCellId() string {
 	if x != nil {
-		return x.WasPerformed
+		return x.CellId
 	}
-	return false
+	return ""
 }
 
-type ListCellsReply struct {
+func (x *LeaseRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LeaseRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *LeaseRequest) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// a lease that is not granted is lost, the cell has been given to another cell master or no longer exists
+type LeaseReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId []string `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
+	Granted     bool  `protobuf:"varint,1,opt,name=granted,proto3" json:"granted,omitempty"`
+	Epoch       int64 `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	LeaseMillis int64 `protobuf:"varint,3,opt,name=leaseMillis,proto3" json:"leaseMillis,omitempty"`
 }
 
-func (x *ListCellsReply) Reset() {
-	*x = ListCellsReply{}
+func (x *LeaseReply) Reset() {
+	*x = LeaseReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1097,13 +1391,13 @@ func (x *ListCellsReply) Reset() {
 	}
 }
 
-func (x *ListCellsReply) String() string {
+func (x *LeaseReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCellsReply) ProtoMessage() {}
+func (*LeaseReply) ProtoMessage() {}
 
-func (x *ListCellsReply) ProtoReflect() protoreflect.Message {
+func (x *LeaseReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1115,29 +1409,42 @@ func (x *ListCellsReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCellsReply.ProtoReflect.Descriptor instead.
-func (*ListCellsReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use LeaseReply.ProtoReflect.Descriptor instead.
+func (*LeaseReply) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListCellsReply) GetCellId() []string {
+func (x *LeaseReply) GetGranted() bool {
 	if x != nil {
-		return x.CellId
+		return x.Granted
 	}
-	return nil
+	return false
 }
 
-type PlayersReply struct {
+func (x *LeaseReply) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *LeaseReply) GetLeaseMillis() int64 {
+	if x != nil {
+		return x.LeaseMillis
+	}
+	return 0
+}
+
+type CellMasterStatusReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip   []string `protobuf:"bytes,1,rep,name=ip,proto3" json:"ip,omitempty"`
-	Port []int32  `protobuf:"varint,2,rep,packed,name=port,proto3" json:"port,omitempty"`
+	WasUnregistered bool `protobuf:"varint,1,opt,name=wasUnregistered,proto3" json:"wasUnregistered,omitempty"`
 }
 
-func (x *PlayersReply) Reset() {
-	*x = PlayersReply{}
+func (x *CellMasterStatusReply) Reset() {
+	*x = CellMasterStatusReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1145,13 +1452,13 @@ func (x *PlayersReply) Reset() {
 	}
 }
 
-func (x *PlayersReply) String() string {
+func (x *CellMasterStatusReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlayersReply) ProtoMessage() {}
+func (*CellMasterStatusReply) ProtoMessage() {}
 
-func (x *PlayersReply) ProtoReflect() protoreflect.Message {
+func (x *CellMasterStatusReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1163,36 +1470,28 @@ func (x *PlayersReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlayersReply.ProtoReflect.Descriptor instead.
-func (*PlayersReply) Descriptor() ([]byte, []int) {
+// Deprecated: Use CellMasterStatusReply.ProtoReflect.Descriptor instead.
+func (*CellMasterStatusReply) Descriptor() ([]byte, []int) {
 	return file_ns_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *PlayersReply) GetIp() []string {
-	if x != nil {
-		return x.Ip
-	}
-	return nil
-}
-
-func (x *PlayersReply) GetPort() []int32 {
+func (x *CellMasterStatusReply) GetWasUnregistered() bool {
 	if x != nil {
-		return x.Port
+		return x.WasUnregistered
 	}
-	return nil
+	return false
 }
 
-type CellMasterReply struct {
+type PlayerStatusReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ip   string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
-	Port int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	PlayerLeft bool `protobuf:"varint,1,opt,name=playerLeft,proto3" json:"playerLeft,omitempty"`
 }
 
-func (x *CellMasterReply) Reset() {
-	*x = CellMasterReply{}
+func (x *PlayerStatusReply) Reset() {
+	*x = PlayerStatusReply{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_ns_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1200,313 +1499,2696 @@ func (x *CellMasterReply) Reset() {
 	}
 }
 
-func (x *CellMasterReply) String() string {
+func (x *PlayerStatusReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CellMasterReply) ProtoMessage() {}
+func (*PlayerStatusReply) ProtoMessage() {}
 
-func (x *CellMasterReply) ProtoReflect() protoreflect.Message {
+func (x *PlayerStatusReply) ProtoReflect() protoreflect.Message {
 	mi := &file_ns_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use CellMasterReply.ProtoReflect.Descriptor instead.
-func (*CellMasterReply) Descriptor() ([]byte, []int) {
-	return file_ns_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *CellMasterReply) GetIp() string {
-	if x != nil {
-		return x.Ip
-	}
-	return ""
-}
-
-func (x *CellMasterReply) GetPort() int32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
-}
-
-var File_ns_proto protoreflect.FileDescriptor
-
-var file_ns_proto_rawDesc = []byte{
-	0x0a, 0x08, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x65, 0x6c, 0x6c,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x22, 0x74, 0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12,
-	0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x73, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12,
-	0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x38, 0x0a,
-	0x0d, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x27,
-	0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c,
-	0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x34, 0x0a, 0x14, 0x54, 0x72, 0x61, 0x6e, 0x73,
-	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x2e, 0x0a,
-	0x14, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x69, 0x0a,
-	0x15, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x1c,
-	0x0a, 0x09, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x6e, 0x65, 0x77, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08,
-	0x6e, 0x65, 0x77, 0x57, 0x69, 0x64, 0x74, 0x68, 0x22, 0x39, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x6c,
-	0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x69,
-	0x64, 0x74, 0x68, 0x22, 0x4e, 0x0a, 0x10, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c, 0x6c, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12,
-	0x22, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x43, 0x65, 0x6c,
-	0x6c, 0x49, 0x64, 0x22, 0x51, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43,
-	0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x08, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x6e, 0x0a, 0x20, 0x50, 0x6c,
-	0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12,
-	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x12, 0x0a, 0x10, 0x4c, 0x69,
-	0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2c,
-	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x2b, 0x0a, 0x11,
-	0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x41, 0x0a, 0x15, 0x43, 0x65, 0x6c,
-	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x28, 0x0a, 0x0f, 0x77, 0x61, 0x73, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73,
-	0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x77, 0x61, 0x73,
-	0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22, 0x33, 0x0a, 0x11,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65, 0x66, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65, 0x66,
-	0x74, 0x22, 0x25, 0x0a, 0x0b, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x65, 0x6c, 0x6c,
-	0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x15, 0x43, 0x65, 0x6c, 0x6c, 0x43,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x45,
-	0x0a, 0x13, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c,
-	0x6f, 0x63, 0x6b, 0x65, 0x65, 0x22, 0x35, 0x0a, 0x0f, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x61, 0x73, 0x50,
-	0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
-	0x77, 0x61, 0x73, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x22, 0x28, 0x0a, 0x0e,
-	0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
-	0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x35, 0x0a, 0x0f, 0x43, 0x65,
-	0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72,
-	0x74, 0x32, 0xeb, 0x0a, 0x0a, 0x0b, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x12, 0x46, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12,
-	0x18, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65,
-	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x53, 0x65, 0x74,
-	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x69, 0x7a,
-	0x65, 0x1a, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
-	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65,
-	0x65, 0x64, 0x65, 0x64, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x43, 0x65, 0x6c, 0x6c, 0x12, 0x18, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
-	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
-	0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49,
-	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x65,
-	0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65,
-	0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x65, 0x6c,
-	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c,
-	0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f, 0x41, 0x64, 0x64,
-	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x63,
-	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
-	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x72, 0x61,
-	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
-	0x64, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x1a, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
-	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63,
-	0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x1e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x57, 0x69, 0x74, 0x68,
-	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x1a, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43,
-	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
-	0x12, 0x4c, 0x0a, 0x0a, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x18,
-	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
-	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67,
-	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51,
-	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x43,
-	0x65, 0x6c, 0x6c, 0x12, 0x1f, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
-	0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
-	0x00, 0x12, 0x53, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c,
-	0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x14, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1e,
-	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
-	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22,
-	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
-	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65,
-	0x66, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c,
-	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x15, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
-	0x75, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e,
-	0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
-	0x75, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x15, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x43, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x12, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
-	0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
-	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e, 0x0a,
-	0x09, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x65, 0x6c,
-	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c,
-	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
-	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x63, 0x6b,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a,
-	0x0b, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63,
-	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x43,
-	0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65,
-	0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f,
-	0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_ns_proto_rawDescOnce sync.Once
-	file_ns_proto_rawDescData = file_ns_proto_rawDesc
-)
-
-func file_ns_proto_rawDescGZIP() []byte {
-	file_ns_proto_rawDescOnce.Do(func() {
-		file_ns_proto_rawDescData = protoimpl.X.CompressGZIP(file_ns_proto_rawDescData)
-	})
-	return file_ns_proto_rawDescData
-}
-
-var file_ns_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
-var file_ns_proto_goTypes = []interface{}{
-	(*Cell)(nil),                             // 0: cellmanager.Cell
-	(*CellListReply)(nil),                    // 1: cellmanager.CellListReply
-	(*TransactionSucceeded)(nil),             // 2: cellmanager.TransactionSucceeded
-	(*CellNeighbourRequest)(nil),             // 3: cellmanager.CellNeighbourRequest
-	(*CellChangeSizeRequest)(nil),            // 4: cellmanager.CellChangeSizeRequest
-	(*WorldSize)(nil),                        // 5: cellmanager.WorldSize
-	(*LockCellsRequest)(nil),                 // 6: cellmanager.LockCellsRequest
-	(*PlayerInCellRequest)(nil),              // 7: cellmanager.PlayerInCellRequest
-	(*Position)(nil),                         // 8: cellmanager.Position
-	(*PlayerInCellRequestWithPositions)(nil), // 9: cellmanager.PlayerInCellRequestWithPositions
-	(*ListCellsRequest)(nil),                 // 10: cellmanager.ListCellsRequest
-	(*ListPlayersRequest)(nil),               // 11: cellmanager.ListPlayersRequest
-	(*CellMasterRequest)(nil),                // 12: cellmanager.CellMasterRequest
-	(*CellMasterStatusReply)(nil),            // 13: cellmanager.CellMasterStatusReply
-	(*PlayerStatusReply)(nil),                // 14: cellmanager.PlayerStatusReply
-	(*CellRequest)(nil),                      // 15: cellmanager.CellRequest
-	(*CellNeighboursReply)(nil),              // 16: cellmanager.CellNeighboursReply
-	(*CellChangeStatusReply)(nil),            // 17: cellmanager.CellChangeStatusReply
-	(*CellLockStatusReply)(nil),              // 18: cellmanager.CellLockStatusReply
-	(*CellStatusReply)(nil),                  // 19: cellmanager.CellStatusReply
-	(*ListCellsReply)(nil),                   // 20: cellmanager.ListCellsReply
-	(*PlayersReply)(nil),                     // 21: cellmanager.PlayersReply
-	(*CellMasterReply)(nil),                  // 22: cellmanager.CellMasterReply
-}
-var file_ns_proto_depIdxs = []int32{
-	0,  // 0: cellmanager.CellListReply.cells:type_name -> cellmanager.Cell
-	15, // 1: cellmanager.CellManager.CreateCell:input_type -> cellmanager.CellRequest
-	5,  // 2: cellmanager.CellManager.SetWorldSize:input_type -> cellmanager.WorldSize
-	15, // 3: cellmanager.CellManager.DeleteCell:input_type -> cellmanager.CellRequest
-	10, // 4: cellmanager.CellManager.ListCells:input_type -> cellmanager.ListCellsRequest
-	7,  // 5: cellmanager.CellManager.AddPlayerToCell:input_type -> cellmanager.PlayerInCellRequest
-	9,  // 6: cellmanager.CellManager.AddPlayerToCellWithPositions:input_type -> cellmanager.PlayerInCellRequestWithPositions
-	8,  // 7: cellmanager.CellManager.RequestCellMasterWithPositions:input_type -> cellmanager.Position
-	15, // 8: cellmanager.CellManager.DivideCell:input_type -> cellmanager.CellRequest
-	11, // 9: cellmanager.CellManager.ListPlayersInCell:input_type -> cellmanager.ListPlayersRequest
-	12, // 10: cellmanager.CellManager.RequestCellMaster:input_type -> cellmanager.CellMasterRequest
-	12, // 11: cellmanager.CellManager.UnregisterCellMaster:input_type -> cellmanager.CellMasterRequest
-	7,  // 12: cellmanager.CellManager.PlayerLeftCell:input_type -> cellmanager.PlayerInCellRequest
-	3,  // 13: cellmanager.CellManager.RequestCellNeighbours:input_type -> cellmanager.CellNeighbourRequest
-	4,  // 14: cellmanager.CellManager.RequestCellSizeChange:input_type -> cellmanager.CellChangeSizeRequest
-	6,  // 15: cellmanager.CellManager.LockCells:input_type -> cellmanager.LockCellsRequest
-	6,  // 16: cellmanager.CellManager.UnlockCells:input_type -> cellmanager.LockCellsRequest
-	19, // 17: cellmanager.CellManager.CreateCell:output_type -> cellmanager.CellStatusReply
-	2,  // 18: cellmanager.CellManager.SetWorldSize:output_type -> cellmanager.TransactionSucceeded
-	19, // 19: cellmanager.CellManager.DeleteCell:output_type -> cellmanager.CellStatusReply
-	20, // 20: cellmanager.CellManager.ListCells:output_type -> cellmanager.ListCellsReply
-	2,  // 21: cellmanager.CellManager.AddPlayerToCell:output_type -> cellmanager.TransactionSucceeded
-	2,  // 22: cellmanager.CellManager.AddPlayerToCellWithPositions:output_type -> cellmanager.TransactionSucceeded
-	22, // 23: cellmanager.CellManager.RequestCellMasterWithPositions:output_type -> cellmanager.CellMasterReply
-	17, // 24: cellmanager.CellManager.DivideCell:output_type -> cellmanager.CellChangeStatusReply
-	21, // 25: cellmanager.CellManager.ListPlayersInCell:output_type -> cellmanager.PlayersReply
-	22, // 26: cellmanager.CellManager.RequestCellMaster:output_type -> cellmanager.CellMasterReply
-	13, // 27: cellmanager.CellManager.UnregisterCellMaster:output_type -> cellmanager.CellMasterStatusReply
-	14, // 28: cellmanager.CellManager.PlayerLeftCell:output_type -> cellmanager.PlayerStatusReply
-	16, // 29: cellmanager.CellManager.RequestCellNeighbours:output_type -> cellmanager.CellNeighboursReply
-	17, // 30: cellmanager.CellManager.RequestCellSizeChange:output_type -> cellmanager.CellChangeStatusReply
-	18, // 31: cellmanager.CellManager.LockCells:output_type -> cellmanager.CellLockStatusReply
-	18, // 32: cellmanager.CellManager.UnlockCells:output_type -> cellmanager.CellLockStatusReply
-	17, // [17:33] is the sub-list for method output_type
-	1,  // [1:17] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
-}
-
-func init() { file_ns_proto_init() }
-func file_ns_proto_init() {
-	if File_ns_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_ns_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cell); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerStatusReply.ProtoReflect.Descriptor instead.
+func (*PlayerStatusReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PlayerStatusReply) GetPlayerLeft() bool {
+	if x != nil {
+		return x.PlayerLeft
+	}
+	return false
+}
+
+type CellRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	// only used by DivideCell
+	SplitMode SplitMode `protobuf:"varint,2,opt,name=splitMode,proto3,enum=cellmanager.SplitMode" json:"splitMode,omitempty"`
+}
+
+func (x *CellRequest) Reset() {
+	*x = CellRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellRequest) ProtoMessage() {}
+
+func (x *CellRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellRequest.ProtoReflect.Descriptor instead.
+func (*CellRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CellRequest) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *CellRequest) GetSplitMode() SplitMode {
+	if x != nil {
+		return x.SplitMode
+	}
+	return SplitMode_QUADRANTS
+}
+
+type CellNeighboursReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId     []string    `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
+	Neighbours []*CellInfo `protobuf:"bytes,2,rep,name=neighbours,proto3" json:"neighbours,omitempty"`
+	// false if the neighbour only touches the requested cell in a corner
+	SharesEdge []bool `protobuf:"varint,3,rep,packed,name=sharesEdge,proto3" json:"sharesEdge,omitempty"`
+}
+
+func (x *CellNeighboursReply) Reset() {
+	*x = CellNeighboursReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellNeighboursReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellNeighboursReply) ProtoMessage() {}
+
+func (x *CellNeighboursReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellNeighboursReply.ProtoReflect.Descriptor instead.
+func (*CellNeighboursReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CellNeighboursReply) GetCellId() []string {
+	if x != nil {
+		return x.CellId
+	}
+	return nil
+}
+
+func (x *CellNeighboursReply) GetNeighbours() []*CellInfo {
+	if x != nil {
+		return x.Neighbours
+	}
+	return nil
+}
+
+func (x *CellNeighboursReply) GetSharesEdge() []bool {
+	if x != nil {
+		return x.SharesEdge
+	}
+	return nil
+}
+
+type CellChangeStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Succeeded bool `protobuf:"varint,1,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+}
+
+func (x *CellChangeStatusReply) Reset() {
+	*x = CellChangeStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellChangeStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellChangeStatusReply) ProtoMessage() {}
+
+func (x *CellChangeStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellChangeStatusReply.ProtoReflect.Descriptor instead.
+func (*CellChangeStatusReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CellChangeStatusReply) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+type CellLockStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Locked bool   `protobuf:"varint,1,opt,name=locked,proto3" json:"locked,omitempty"`
+	Lockee string `protobuf:"bytes,2,opt,name=lockee,proto3" json:"lockee,omitempty"`
+}
+
+func (x *CellLockStatusReply) Reset() {
+	*x = CellLockStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellLockStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellLockStatusReply) ProtoMessage() {}
+
+func (x *CellLockStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellLockStatusReply.ProtoReflect.Descriptor instead.
+func (*CellLockStatusReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CellLockStatusReply) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *CellLockStatusReply) GetLockee() string {
+	if x != nil {
+		return x.Lockee
+	}
+	return ""
+}
+
+type CellStatusReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WasPerformed bool `protobuf:"varint,1,opt,name=wasPerformed,proto3" json:"wasPerformed,omitempty"`
+}
+
+func (x *CellStatusReply) Reset() {
+	*x = CellStatusReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellStatusReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellStatusReply) ProtoMessage() {}
+
+func (x *CellStatusReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellStatusReply.ProtoReflect.Descriptor instead.
+func (*CellStatusReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CellStatusReply) GetWasPerformed() bool {
+	if x != nil {
+		return x.WasPerformed
+	}
+	return false
+}
+
+type ListCellsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId []string    `protobuf:"bytes,1,rep,name=cellId,proto3" json:"cellId,omitempty"`
+	Cells  []*CellInfo `protobuf:"bytes,2,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *ListCellsReply) Reset() {
+	*x = ListCellsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCellsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCellsReply) ProtoMessage() {}
+
+func (x *ListCellsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCellsReply.ProtoReflect.Descriptor instead.
+func (*ListCellsReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListCellsReply) GetCellId() []string {
+	if x != nil {
+		return x.CellId
+	}
+	return nil
+}
+
+func (x *ListCellsReply) GetCells() []*CellInfo {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type CellInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId         string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	PosX           int64  `protobuf:"varint,2,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY           int64  `protobuf:"varint,3,opt,name=posY,proto3" json:"posY,omitempty"`
+	Width          int64  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height         int64  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Depth          int32  `protobuf:"varint,6,opt,name=depth,proto3" json:"depth,omitempty"`
+	ParentId       string `protobuf:"bytes,7,opt,name=parentId,proto3" json:"parentId,omitempty"`
+	IsLeaf         bool   `protobuf:"varint,8,opt,name=isLeaf,proto3" json:"isLeaf,omitempty"`
+	CellMasterIp   string `protobuf:"bytes,9,opt,name=cellMasterIp,proto3" json:"cellMasterIp,omitempty"`
+	CellMasterPort int32  `protobuf:"varint,10,opt,name=cellMasterPort,proto3" json:"cellMasterPort,omitempty"`
+	PlayerCount    int32  `protobuf:"varint,11,opt,name=playerCount,proto3" json:"playerCount,omitempty"`
+	Locked         bool   `protobuf:"varint,12,opt,name=locked,proto3" json:"locked,omitempty"`
+	Lockee         string `protobuf:"bytes,13,opt,name=lockee,proto3" json:"lockee,omitempty"`
+	// the epoch of the current or, if there is none, the latest cell master
+	Epoch int64 `protobuf:"varint,14,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the version of the tree the cell was created at by a split or merge
+	TopologyVersion int64 `protobuf:"varint,15,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
+}
+
+func (x *CellInfo) Reset() {
+	*x = CellInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellInfo) ProtoMessage() {}
+
+func (x *CellInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellInfo.ProtoReflect.Descriptor instead.
+func (*CellInfo) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CellInfo) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *CellInfo) GetPosX() int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return 0
+}
+
+func (x *CellInfo) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+func (x *CellInfo) GetWidth() int64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CellInfo) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CellInfo) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *CellInfo) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CellInfo) GetIsLeaf() bool {
+	if x != nil {
+		return x.IsLeaf
+	}
+	return false
+}
+
+func (x *CellInfo) GetCellMasterIp() string {
+	if x != nil {
+		return x.CellMasterIp
+	}
+	return ""
+}
+
+func (x *CellInfo) GetCellMasterPort() int32 {
+	if x != nil {
+		return x.CellMasterPort
+	}
+	return 0
+}
+
+func (x *CellInfo) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *CellInfo) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *CellInfo) GetLockee() string {
+	if x != nil {
+		return x.Lockee
+	}
+	return ""
+}
+
+func (x *CellInfo) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *CellInfo) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+// a cell master reports how busy its cell is, the reports are only kept in memory by the leader
+type CellLoadReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId             string  `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Ip                 string  `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port               int32   `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	MutationsPerSecond float64 `protobuf:"fixed64,4,opt,name=mutationsPerSecond,proto3" json:"mutationsPerSecond,omitempty"`
+	// the cell master's own measure of how busy it is, 1 meaning fully used
+	Load  float64 `protobuf:"fixed64,5,opt,name=load,proto3" json:"load,omitempty"`
+	Epoch int64   `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *CellLoadReport) Reset() {
+	*x = CellLoadReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellLoadReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellLoadReport) ProtoMessage() {}
+
+func (x *CellLoadReport) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellLoadReport.ProtoReflect.Descriptor instead.
+func (*CellLoadReport) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CellLoadReport) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *CellLoadReport) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *CellLoadReport) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CellLoadReport) GetMutationsPerSecond() float64 {
+	if x != nil {
+		return x.MutationsPerSecond
+	}
+	return 0
+}
+
+func (x *CellLoadReport) GetLoad() float64 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+func (x *CellLoadReport) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+type CellLoadReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Accepted bool `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+}
+
+func (x *CellLoadReply) Reset() {
+	*x = CellLoadReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellLoadReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellLoadReply) ProtoMessage() {}
+
+func (x *CellLoadReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellLoadReply.ProtoReflect.Descriptor instead.
+func (*CellLoadReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CellLoadReply) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+// a player is identified either by its address or, if ip is empty, by its object id
+type PlayerPosition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip       string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	ObjectId string `protobuf:"bytes,3,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	PosX     int64  `protobuf:"varint,4,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY     int64  `protobuf:"varint,5,opt,name=posY,proto3" json:"posY,omitempty"`
+}
+
+func (x *PlayerPosition) Reset() {
+	*x = PlayerPosition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerPosition) ProtoMessage() {}
+
+func (x *PlayerPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerPosition.ProtoReflect.Descriptor instead.
+func (*PlayerPosition) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PlayerPosition) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PlayerPosition) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PlayerPosition) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *PlayerPosition) GetPosX() int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return 0
+}
+
+func (x *PlayerPosition) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+// cell masters report the positions of their subscribers in batches
+// a report from a cell master names its cell and epoch, reports without a cell id are not fenced
+type PositionReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Positions []*PlayerPosition `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	CellId    string            `protobuf:"bytes,2,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Epoch     int64             `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *PositionReport) Reset() {
+	*x = PositionReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PositionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionReport) ProtoMessage() {}
+
+func (x *PositionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionReport.ProtoReflect.Descriptor instead.
+func (*PositionReport) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PositionReport) GetPositions() []*PlayerPosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+func (x *PositionReport) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *PositionReport) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// updated counts the players whose position changed, moved those of them that are now registered in another cell
+// and unknown the reported players that are not registered or are outside the world
+type PositionReportReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Updated int32 `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	Moved   int32 `protobuf:"varint,2,opt,name=moved,proto3" json:"moved,omitempty"`
+	Unknown int32 `protobuf:"varint,3,opt,name=unknown,proto3" json:"unknown,omitempty"`
+}
+
+func (x *PositionReportReply) Reset() {
+	*x = PositionReportReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PositionReportReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionReportReply) ProtoMessage() {}
+
+func (x *PositionReportReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionReportReply.ProtoReflect.Descriptor instead.
+func (*PositionReportReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PositionReportReply) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *PositionReportReply) GetMoved() int32 {
+	if x != nil {
+		return x.Moved
+	}
+	return 0
+}
+
+func (x *PositionReportReply) GetUnknown() int32 {
+	if x != nil {
+		return x.Unknown
+	}
+	return 0
+}
+
+type VerifyTilingRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VerifyTilingRequest) Reset() {
+	*x = VerifyTilingRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTilingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTilingRequest) ProtoMessage() {}
+
+func (x *VerifyTilingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTilingRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTilingRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{35}
+}
+
+// valid is true if the leaves cover the world exactly, otherwise every violation found is described
+type VerifyTilingReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valid      bool     `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Violations []string `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+}
+
+func (x *VerifyTilingReply) Reset() {
+	*x = VerifyTilingReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyTilingReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTilingReply) ProtoMessage() {}
+
+func (x *VerifyTilingReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTilingReply.ProtoReflect.Descriptor instead.
+func (*VerifyTilingReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *VerifyTilingReply) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *VerifyTilingReply) GetViolations() []string {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type PlanTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PlanTopologyRequest) Reset() {
+	*x = PlanTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanTopologyRequest) ProtoMessage() {}
+
+func (x *PlanTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanTopologyRequest.ProtoReflect.Descriptor instead.
+func (*PlanTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{37}
+}
+
+// a split or merge the cell manager would make, with what the split and merge policy was told about the cell
+type PlannedChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SPLIT or MERGE
+	Type               TopologyEventType `protobuf:"varint,1,opt,name=type,proto3,enum=cellmanager.TopologyEventType" json:"type,omitempty"`
+	CellId             string            `protobuf:"bytes,2,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Reason             string            `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	PlayerCount        int32             `protobuf:"varint,4,opt,name=playerCount,proto3" json:"playerCount,omitempty"`
+	AgeSeconds         int64             `protobuf:"varint,5,opt,name=ageSeconds,proto3" json:"ageSeconds,omitempty"`
+	MutationsPerSecond float64           `protobuf:"fixed64,6,opt,name=mutationsPerSecond,proto3" json:"mutationsPerSecond,omitempty"`
+	Load               float64           `protobuf:"fixed64,7,opt,name=load,proto3" json:"load,omitempty"`
+	// false if the cell masters of the cell have not all reported their load
+	Reported bool `protobuf:"varint,8,opt,name=reported,proto3" json:"reported,omitempty"`
+}
+
+func (x *PlannedChange) Reset() {
+	*x = PlannedChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlannedChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedChange) ProtoMessage() {}
+
+func (x *PlannedChange) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedChange.ProtoReflect.Descriptor instead.
+func (*PlannedChange) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PlannedChange) GetType() TopologyEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TopologyEventType_SNAPSHOT
+}
+
+func (x *PlannedChange) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *PlannedChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PlannedChange) GetPlayerCount() int32 {
+	if x != nil {
+		return x.PlayerCount
+	}
+	return 0
+}
+
+func (x *PlannedChange) GetAgeSeconds() int64 {
+	if x != nil {
+		return x.AgeSeconds
+	}
+	return 0
+}
+
+func (x *PlannedChange) GetMutationsPerSecond() float64 {
+	if x != nil {
+		return x.MutationsPerSecond
+	}
+	return 0
+}
+
+func (x *PlannedChange) GetLoad() float64 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+func (x *PlannedChange) GetReported() bool {
+	if x != nil {
+		return x.Reported
+	}
+	return false
+}
+
+type PlanTopologyReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*PlannedChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	Policy  string           `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+}
+
+func (x *PlanTopologyReply) Reset() {
+	*x = PlanTopologyReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanTopologyReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanTopologyReply) ProtoMessage() {}
+
+func (x *PlanTopologyReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanTopologyReply.ProtoReflect.Descriptor instead.
+func (*PlanTopologyReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PlanTopologyReply) GetChanges() []*PlannedChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *PlanTopologyReply) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+type TopologyMetricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *TopologyMetricsRequest) Reset() {
+	*x = TopologyMetricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyMetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyMetricsRequest) ProtoMessage() {}
+
+func (x *TopologyMetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyMetricsRequest.ProtoReflect.Descriptor instead.
+func (*TopologyMetricsRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{40}
+}
+
+// how often the cell manager split and merged a cell, a flap is a change that undid the previous change of the cell
+// within the flap window
+type RegionMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId             string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Splits             int32  `protobuf:"varint,2,opt,name=splits,proto3" json:"splits,omitempty"`
+	Merges             int32  `protobuf:"varint,3,opt,name=merges,proto3" json:"merges,omitempty"`
+	Flaps              int32  `protobuf:"varint,4,opt,name=flaps,proto3" json:"flaps,omitempty"`
+	SecondsSinceChange int64  `protobuf:"varint,5,opt,name=secondsSinceChange,proto3" json:"secondsSinceChange,omitempty"`
+}
+
+func (x *RegionMetrics) Reset() {
+	*x = RegionMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegionMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegionMetrics) ProtoMessage() {}
+
+func (x *RegionMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegionMetrics.ProtoReflect.Descriptor instead.
+func (*RegionMetrics) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RegionMetrics) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *RegionMetrics) GetSplits() int32 {
+	if x != nil {
+		return x.Splits
+	}
+	return 0
+}
+
+func (x *RegionMetrics) GetMerges() int32 {
+	if x != nil {
+		return x.Merges
+	}
+	return 0
+}
+
+func (x *RegionMetrics) GetFlaps() int32 {
+	if x != nil {
+		return x.Flaps
+	}
+	return 0
+}
+
+func (x *RegionMetrics) GetSecondsSinceChange() int64 {
+	if x != nil {
+		return x.SecondsSinceChange
+	}
+	return 0
+}
+
+type TopologyMetricsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Regions []*RegionMetrics `protobuf:"bytes,1,rep,name=regions,proto3" json:"regions,omitempty"`
+	Flaps   int32            `protobuf:"varint,2,opt,name=flaps,proto3" json:"flaps,omitempty"`
+}
+
+func (x *TopologyMetricsReply) Reset() {
+	*x = TopologyMetricsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyMetricsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyMetricsReply) ProtoMessage() {}
+
+func (x *TopologyMetricsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyMetricsReply.ProtoReflect.Descriptor instead.
+func (*TopologyMetricsReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TopologyMetricsReply) GetRegions() []*RegionMetrics {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+func (x *TopologyMetricsReply) GetFlaps() int32 {
+	if x != nil {
+		return x.Flaps
+	}
+	return 0
+}
+
+type WatchTopologyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchTopologyRequest) Reset() {
+	*x = WatchTopologyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchTopologyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTopologyRequest) ProtoMessage() {}
+
+func (x *WatchTopologyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTopologyRequest.ProtoReflect.Descriptor instead.
+func (*WatchTopologyRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{43}
+}
+
+// TopologyEvent holds the cells that changed, as they are after the change. A split holds the split cell followed by
+// its children, a resize holds every sibling that was moved.
+type TopologyEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version int64             `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Type    TopologyEventType `protobuf:"varint,2,opt,name=type,proto3,enum=cellmanager.TopologyEventType" json:"type,omitempty"`
+	CellId  string            `protobuf:"bytes,3,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Cells   []*CellInfo       `protobuf:"bytes,4,rep,name=cells,proto3" json:"cells,omitempty"`
+}
+
+func (x *TopologyEvent) Reset() {
+	*x = TopologyEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopologyEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyEvent) ProtoMessage() {}
+
+func (x *TopologyEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyEvent.ProtoReflect.Descriptor instead.
+func (*TopologyEvent) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *TopologyEvent) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TopologyEvent) GetType() TopologyEventType {
+	if x != nil {
+		return x.Type
+	}
+	return TopologyEventType_SNAPSHOT
+}
+
+func (x *TopologyEvent) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *TopologyEvent) GetCells() []*CellInfo {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+type PlayersReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip       []string `protobuf:"bytes,1,rep,name=ip,proto3" json:"ip,omitempty"`
+	Port     []int32  `protobuf:"varint,2,rep,packed,name=port,proto3" json:"port,omitempty"`
+	ObjectId []string `protobuf:"bytes,3,rep,name=objectId,proto3" json:"objectId,omitempty"`
+	PosX     []int64  `protobuf:"varint,4,rep,packed,name=posX,proto3" json:"posX,omitempty"`
+	PosY     []int64  `protobuf:"varint,5,rep,packed,name=posY,proto3" json:"posY,omitempty"`
+}
+
+func (x *PlayersReply) Reset() {
+	*x = PlayersReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayersReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayersReply) ProtoMessage() {}
+
+func (x *PlayersReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayersReply.ProtoReflect.Descriptor instead.
+func (*PlayersReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PlayersReply) GetIp() []string {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+func (x *PlayersReply) GetPort() []int32 {
+	if x != nil {
+		return x.Port
+	}
+	return nil
+}
+
+func (x *PlayersReply) GetObjectId() []string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return nil
+}
+
+func (x *PlayersReply) GetPosX() []int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return nil
+}
+
+func (x *PlayersReply) GetPosY() []int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return nil
+}
+
+// a player is located either by its address or, if ip is empty, by its object id
+type LocatePlayerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip       string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port     int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	ObjectId string `protobuf:"bytes,3,opt,name=objectId,proto3" json:"objectId,omitempty"`
+}
+
+func (x *LocatePlayerRequest) Reset() {
+	*x = LocatePlayerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LocatePlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocatePlayerRequest) ProtoMessage() {}
+
+func (x *LocatePlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocatePlayerRequest.ProtoReflect.Descriptor instead.
+func (*LocatePlayerRequest) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *LocatePlayerRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *LocatePlayerRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *LocatePlayerRequest) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+type PlayerLocationReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Found          bool   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	CellId         string `protobuf:"bytes,2,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	CellMasterIp   string `protobuf:"bytes,3,opt,name=cellMasterIp,proto3" json:"cellMasterIp,omitempty"`
+	CellMasterPort int32  `protobuf:"varint,4,opt,name=cellMasterPort,proto3" json:"cellMasterPort,omitempty"`
+	Ip             string `protobuf:"bytes,5,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port           int32  `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`
+	ObjectId       string `protobuf:"bytes,7,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	PosX           int64  `protobuf:"varint,8,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY           int64  `protobuf:"varint,9,opt,name=posY,proto3" json:"posY,omitempty"`
+}
+
+func (x *PlayerLocationReply) Reset() {
+	*x = PlayerLocationReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlayerLocationReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerLocationReply) ProtoMessage() {}
+
+func (x *PlayerLocationReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerLocationReply.ProtoReflect.Descriptor instead.
+func (*PlayerLocationReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PlayerLocationReply) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *PlayerLocationReply) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *PlayerLocationReply) GetCellMasterIp() string {
+	if x != nil {
+		return x.CellMasterIp
+	}
+	return ""
+}
+
+func (x *PlayerLocationReply) GetCellMasterPort() int32 {
+	if x != nil {
+		return x.CellMasterPort
+	}
+	return 0
+}
+
+func (x *PlayerLocationReply) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *PlayerLocationReply) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *PlayerLocationReply) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *PlayerLocationReply) GetPosX() int64 {
+	if x != nil {
+		return x.PosX
+	}
+	return 0
+}
+
+func (x *PlayerLocationReply) GetPosY() int64 {
+	if x != nil {
+		return x.PosY
+	}
+	return 0
+}
+
+// the cell master of the cell, messages to it are addressed with the cell id and topology version
+type CellMasterReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip              string `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port            int32  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	CellId          string `protobuf:"bytes,3,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	TopologyVersion int64  `protobuf:"varint,4,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
+}
+
+func (x *CellMasterReply) Reset() {
+	*x = CellMasterReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ns_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellMasterReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellMasterReply) ProtoMessage() {}
+
+func (x *CellMasterReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ns_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellMasterReply.ProtoReflect.Descriptor instead.
+func (*CellMasterReply) Descriptor() ([]byte, []int) {
+	return file_ns_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CellMasterReply) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *CellMasterReply) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CellMasterReply) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *CellMasterReply) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+var File_ns_proto protoreflect.FileDescriptor
+
+var file_ns_proto_rawDesc = []byte{
+	0x0a, 0x08, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x56, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x22, 0x0a,
+	0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x4c, 0x6f, 0x67, 0x54,
+	0x65, 0x72, 0x6d, 0x22, 0x41, 0x0a, 0x09, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x74, 0x65, 0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x65, 0x47, 0x72, 0x61, 0x6e,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x65, 0x47,
+	0x72, 0x61, 0x6e, 0x74, 0x65, 0x64, 0x22, 0x3b, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x65, 0x6e,
+	0x74, 0x72, 0x79, 0x22, 0xe8, 0x01, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c,
+	0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x20, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x54, 0x65, 0x72, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x4c, 0x6f, 0x67, 0x54, 0x65,
+	0x72, 0x6d, 0x12, 0x36, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x62,
+	0x0a, 0x12, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0xb6, 0x01, 0x0a, 0x16, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x65, 0x72,
+	0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a,
+	0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x10, 0x6c,
+	0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x64, 0x54, 0x65, 0x72, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x74, 0x0a, 0x04, 0x43,
+	0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x6f, 0x73, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70,
+	0x6f, 0x73, 0x59, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x22, 0x38, 0x0a, 0x0d, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x27, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0x34, 0x0a, 0x14, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65,
+	0x64, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x22, 0x2e, 0x0a, 0x14, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f,
+	0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49,
+	0x64, 0x22, 0x69, 0x0a, 0x15, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65,
+	0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c,
+	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x57, 0x69, 0x64, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x57, 0x69, 0x64, 0x74, 0x68, 0x22, 0x39, 0x0a, 0x09,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x22, 0x4e, 0x0a, 0x10, 0x4c, 0x6f, 0x63, 0x6b, 0x43,
+	0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x43, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x51, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x08, 0x50, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
+	0x73, 0x59, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x8a,
+	0x01, 0x0a, 0x20, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x6f, 0x73, 0x59, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22, 0x3c, 0x0a, 0x10, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x69,
+	0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x22, 0x2c, 0x0a, 0x12, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x22, 0x41, 0x0a, 0x11, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65,
+	0x6c, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x60, 0x0a, 0x0c, 0x4c, 0x65,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65,
+	0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c,
+	0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x5e, 0x0a, 0x0a,
+	0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72,
+	0x61, 0x6e, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x67, 0x72, 0x61,
+	0x6e, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x41, 0x0a, 0x15,
+	0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x28, 0x0a, 0x0f, 0x77, 0x61, 0x73, 0x55, 0x6e, 0x72, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f,
+	0x77, 0x61, 0x73, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x22,
+	0x33, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x65,
+	0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x4c, 0x65, 0x66, 0x74, 0x22, 0x5b, 0x0a, 0x0b, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16,
+	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x22, 0x84, 0x01, 0x0a, 0x13, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x75, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49,
+	0x64, 0x12, 0x35, 0x0a, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x6e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x73, 0x45, 0x64, 0x67, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x73, 0x45, 0x64, 0x67, 0x65, 0x22, 0x35, 0x0a, 0x15, 0x43, 0x65, 0x6c, 0x6c,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22,
+	0x45, 0x0a, 0x13, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x65, 0x22, 0x35, 0x0a, 0x0f, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x77, 0x61, 0x73,
+	0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x77, 0x61, 0x73, 0x50, 0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x65, 0x64, 0x22, 0x55, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x63,
+	0x65, 0x6c, 0x6c, 0x73, 0x22, 0xa0, 0x03, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73,
+	0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73,
+	0x59, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x64, 0x65, 0x70, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x4c, 0x65, 0x61, 0x66, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x69, 0x73, 0x4c, 0x65, 0x61, 0x66, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x65, 0x6c,
+	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x63, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x70, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x79,
+	0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x28, 0x0a,
+	0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa6, 0x01, 0x0a, 0x0e, 0x43, 0x65, 0x6c, 0x6c,
+	0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65,
+	0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c,
+	0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x12, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68,
+	0x22, 0x2b, 0x0a, 0x0d, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x22, 0x78, 0x0a,
+	0x0e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70,
+	0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x79, 0x0a, 0x0e, 0x50, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x39, 0x0a, 0x09, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x22, 0x5f, 0x0a, 0x13, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x6e, 0x6b,
+	0x6e, 0x6f, 0x77, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x75, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x22, 0x15, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6c,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x49, 0x0a, 0x11, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x15, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x95, 0x02, 0x0a,
+	0x0d, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x32,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x67, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x67, 0x65, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x12, 0x6d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x65, 0x64, 0x22, 0x61, 0x0a, 0x11, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x6e, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x22, 0x18, 0x0a, 0x16, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x70, 0x6c, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x70, 0x6c,
+	0x69, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6c, 0x61, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x70,
+	0x73, 0x12, 0x2e, 0x0a, 0x12, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x53, 0x69, 0x6e, 0x63,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x22, 0x62, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x67,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x07, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x66, 0x6c, 0x61, 0x70, 0x73, 0x22, 0x16, 0x0a, 0x14, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa2, 0x01,
+	0x0a, 0x0d, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63,
+	0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x63, 0x65, 0x6c,
+	0x6c, 0x73, 0x22, 0x76, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x04, 0x20, 0x03, 0x28, 0x03,
+	0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x55, 0x0a, 0x13, 0x4c, 0x6f,
+	0x63, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49,
+	0x64, 0x22, 0xf7, 0x01, 0x0a, 0x13, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x49, 0x70, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50,
+	0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x22, 0x77, 0x0a, 0x0f, 0x43,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x2a, 0x2f, 0x0a, 0x09, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x0d, 0x0a, 0x09, 0x51, 0x55, 0x41, 0x44, 0x52, 0x41, 0x4e, 0x54, 0x53, 0x10, 0x00,
+	0x12, 0x13, 0x0a, 0x0f, 0x42, 0x41, 0x4c, 0x41, 0x4e, 0x43, 0x45, 0x44, 0x5f, 0x48, 0x41, 0x4c,
+	0x56, 0x45, 0x53, 0x10, 0x01, 0x2a, 0x89, 0x01, 0x0a, 0x11, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x53,
+	0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x57, 0x4f, 0x52,
+	0x4c, 0x44, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05,
+	0x53, 0x50, 0x4c, 0x49, 0x54, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x45, 0x52, 0x47, 0x45,
+	0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x53, 0x49, 0x5a, 0x45, 0x10, 0x04, 0x12, 0x17,
+	0x0a, 0x13, 0x43, 0x45, 0x4c, 0x4c, 0x5f, 0x4d, 0x41, 0x53, 0x54, 0x45, 0x52, 0x5f, 0x43, 0x48,
+	0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x43, 0x4b, 0x45,
+	0x44, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x55, 0x4e, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10,
+	0x07, 0x32, 0xc6, 0x10, 0x0a, 0x0b, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x12, 0x46, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12,
+	0x18, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x53, 0x65, 0x74,
+	0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x6f, 0x72, 0x6c, 0x64, 0x53, 0x69, 0x7a,
+	0x65, 0x1a, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65,
+	0x65, 0x64, 0x65, 0x64, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x65, 0x6c, 0x6c, 0x12, 0x18, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
+	0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x49,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x65,
+	0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65,
+	0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x6c,
+	0x6c, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f, 0x41, 0x64, 0x64,
+	0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65,
+	0x64, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x1c, 0x41, 0x64, 0x64, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x54, 0x6f, 0x43, 0x65, 0x6c, 0x6c, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x57, 0x69, 0x74, 0x68, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x1a, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x75, 0x63, 0x63,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x1e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x57, 0x69, 0x74, 0x68,
+	0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x1c, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4c, 0x0a, 0x0a, 0x44, 0x69, 0x76, 0x69, 0x64, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x18,
+	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x51,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x49, 0x6e, 0x43,
+	0x65, 0x6c, 0x6c, 0x12, 0x1f, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x54, 0x0a, 0x0c, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x12, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x1e, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x0a,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x15, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x1a, 0x15, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x5c, 0x0a, 0x14, 0x55, 0x6e,
+	0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74,
+	0x65, 0x72, 0x12, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x13, 0x52, 0x65, 0x6e, 0x65,
+	0x77, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12,
+	0x19, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x65,
+	0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x0e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4c,
+	0x65, 0x66, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x43, 0x65,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5e, 0x0a, 0x15, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x75, 0x72, 0x73, 0x12, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x75, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62,
+	0x6f, 0x75, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x61, 0x0a, 0x15, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67,
+	0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d,
+	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x4e,
+	0x0a, 0x09, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e, 0x63, 0x65,
+	0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x6b, 0x43, 0x65,
+	0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x50,
+	0x0a, 0x0b, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x65, 0x6c, 0x6c, 0x73, 0x12, 0x1d, 0x2e,
+	0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x4c, 0x6f, 0x63, 0x6b,
+	0x43, 0x65, 0x6c, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c,
+	0x6f, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x4b, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f,
+	0x61, 0x64, 0x12, 0x1b, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a,
+	0x1a, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x43, 0x65,
+	0x6c, 0x6c, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x52, 0x0a,
+	0x0f, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1b, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x1a, 0x20, 0x2e,
+	0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x52, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6c, 0x69, 0x6e,
+	0x67, 0x12, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x54, 0x69, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x20, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61,
+	0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f,
+	0x67, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x5b, 0x0a, 0x0f, 0x54, 0x6f, 0x70,
+	0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x23, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x21, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x65, 0x6c,
+	0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x32, 0x82, 0x02, 0x0a, 0x0b, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a, 0x0b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x65, 0x6c, 0x6c,
+	0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x55, 0x0a,
+	0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x21,
+	0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70,
+	0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e,
+	0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x23, 0x2e, 0x63, 0x65, 0x6c, 0x6c, 0x6d, 0x61,
+	0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6c, 0x6c, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x63,
+	0x65, 0x6c, 0x6c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e,
+	0x64, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ns_proto_rawDescOnce sync.Once
+	file_ns_proto_rawDescData = file_ns_proto_rawDesc
+)
+
+func file_ns_proto_rawDescGZIP() []byte {
+	file_ns_proto_rawDescOnce.Do(func() {
+		file_ns_proto_rawDescData = protoimpl.X.CompressGZIP(file_ns_proto_rawDescData)
+	})
+	return file_ns_proto_rawDescData
+}
+
+var file_ns_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_ns_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_ns_proto_goTypes = []interface{}{
+	(SplitMode)(0),                           // 0: cellmanager.SplitMode
+	(TopologyEventType)(0),                   // 1: cellmanager.TopologyEventType
+	(*VoteRequest)(nil),                      // 2: cellmanager.VoteRequest
+	(*VoteReply)(nil),                        // 3: cellmanager.VoteReply
+	(*ReplicatedEntry)(nil),                  // 4: cellmanager.ReplicatedEntry
+	(*AppendEntriesRequest)(nil),             // 5: cellmanager.AppendEntriesRequest
+	(*AppendEntriesReply)(nil),               // 6: cellmanager.AppendEntriesReply
+	(*InstallSnapshotRequest)(nil),           // 7: cellmanager.InstallSnapshotRequest
+	(*Cell)(nil),                             // 8: cellmanager.Cell
+	(*CellListReply)(nil),                    // 9: cellmanager.CellListReply
+	(*TransactionSucceeded)(nil),             // 10: cellmanager.TransactionSucceeded
+	(*CellNeighbourRequest)(nil),             // 11: cellmanager.CellNeighbourRequest
+	(*CellChangeSizeRequest)(nil),            // 12: cellmanager.CellChangeSizeRequest
+	(*WorldSize)(nil),                        // 13: cellmanager.WorldSize
+	(*LockCellsRequest)(nil),                 // 14: cellmanager.LockCellsRequest
+	(*PlayerInCellRequest)(nil),              // 15: cellmanager.PlayerInCellRequest
+	(*Position)(nil),                         // 16: cellmanager.Position
+	(*PlayerInCellRequestWithPositions)(nil), // 17: cellmanager.PlayerInCellRequestWithPositions
+	(*ListCellsRequest)(nil),                 // 18: cellmanager.ListCellsRequest
+	(*ListPlayersRequest)(nil),               // 19: cellmanager.ListPlayersRequest
+	(*CellMasterRequest)(nil),                // 20: cellmanager.CellMasterRequest
+	(*LeaseRequest)(nil),                     // 21: cellmanager.LeaseRequest
+	(*LeaseReply)(nil),                       // 22: cellmanager.LeaseReply
+	(*CellMasterStatusReply)(nil),            // 23: cellmanager.CellMasterStatusReply
+	(*PlayerStatusReply)(nil),                // 24: cellmanager.PlayerStatusReply
+	(*CellRequest)(nil),                      // 25: cellmanager.CellRequest
+	(*CellNeighboursReply)(nil),              // 26: cellmanager.CellNeighboursReply
+	(*CellChangeStatusReply)(nil),            // 27: cellmanager.CellChangeStatusReply
+	(*CellLockStatusReply)(nil),              // 28: cellmanager.CellLockStatusReply
+	(*CellStatusReply)(nil),                  // 29: cellmanager.CellStatusReply
+	(*ListCellsReply)(nil),                   // 30: cellmanager.ListCellsReply
+	(*CellInfo)(nil),                         // 31: cellmanager.CellInfo
+	(*CellLoadReport)(nil),                   // 32: cellmanager.CellLoadReport
+	(*CellLoadReply)(nil),                    // 33: cellmanager.CellLoadReply
+	(*PlayerPosition)(nil),                   // 34: cellmanager.PlayerPosition
+	(*PositionReport)(nil),                   // 35: cellmanager.PositionReport
+	(*PositionReportReply)(nil),              // 36: cellmanager.PositionReportReply
+	(*VerifyTilingRequest)(nil),              // 37: cellmanager.VerifyTilingRequest
+	(*VerifyTilingReply)(nil),                // 38: cellmanager.VerifyTilingReply
+	(*PlanTopologyRequest)(nil),              // 39: cellmanager.PlanTopologyRequest
+	(*PlannedChange)(nil),                    // 40: cellmanager.PlannedChange
+	(*PlanTopologyReply)(nil),                // 41: cellmanager.PlanTopologyReply
+	(*TopologyMetricsRequest)(nil),           // 42: cellmanager.TopologyMetricsRequest
+	(*RegionMetrics)(nil),                    // 43: cellmanager.RegionMetrics
+	(*TopologyMetricsReply)(nil),             // 44: cellmanager.TopologyMetricsReply
+	(*WatchTopologyRequest)(nil),             // 45: cellmanager.WatchTopologyRequest
+	(*TopologyEvent)(nil),                    // 46: cellmanager.TopologyEvent
+	(*PlayersReply)(nil),                     // 47: cellmanager.PlayersReply
+	(*LocatePlayerRequest)(nil),              // 48: cellmanager.LocatePlayerRequest
+	(*PlayerLocationReply)(nil),              // 49: cellmanager.PlayerLocationReply
+	(*CellMasterReply)(nil),                  // 50: cellmanager.CellMasterReply
+}
+var file_ns_proto_depIdxs = []int32{
+	4,  // 0: cellmanager.AppendEntriesRequest.entries:type_name -> cellmanager.ReplicatedEntry
+	8,  // 1: cellmanager.CellListReply.cells:type_name -> cellmanager.Cell
+	0,  // 2: cellmanager.CellRequest.splitMode:type_name -> cellmanager.SplitMode
+	31, // 3: cellmanager.CellNeighboursReply.neighbours:type_name -> cellmanager.CellInfo
+	31, // 4: cellmanager.ListCellsReply.cells:type_name -> cellmanager.CellInfo
+	34, // 5: cellmanager.PositionReport.positions:type_name -> cellmanager.PlayerPosition
+	1,  // 6: cellmanager.PlannedChange.type:type_name -> cellmanager.TopologyEventType
+	40, // 7: cellmanager.PlanTopologyReply.changes:type_name -> cellmanager.PlannedChange
+	43, // 8: cellmanager.TopologyMetricsReply.regions:type_name -> cellmanager.RegionMetrics
+	1,  // 9: cellmanager.TopologyEvent.type:type_name -> cellmanager.TopologyEventType
+	31, // 10: cellmanager.TopologyEvent.cells:type_name -> cellmanager.CellInfo
+	25, // 11: cellmanager.CellManager.CreateCell:input_type -> cellmanager.CellRequest
+	13, // 12: cellmanager.CellManager.SetWorldSize:input_type -> cellmanager.WorldSize
+	25, // 13: cellmanager.CellManager.DeleteCell:input_type -> cellmanager.CellRequest
+	18, // 14: cellmanager.CellManager.ListCells:input_type -> cellmanager.ListCellsRequest
+	15, // 15: cellmanager.CellManager.AddPlayerToCell:input_type -> cellmanager.PlayerInCellRequest
+	17, // 16: cellmanager.CellManager.AddPlayerToCellWithPositions:input_type -> cellmanager.PlayerInCellRequestWithPositions
+	16, // 17: cellmanager.CellManager.RequestCellMasterWithPositions:input_type -> cellmanager.Position
+	25, // 18: cellmanager.CellManager.DivideCell:input_type -> cellmanager.CellRequest
+	19, // 19: cellmanager.CellManager.ListPlayersInCell:input_type -> cellmanager.ListPlayersRequest
+	48, // 20: cellmanager.CellManager.LocatePlayer:input_type -> cellmanager.LocatePlayerRequest
+	20, // 21: cellmanager.CellManager.RequestCellMaster:input_type -> cellmanager.CellMasterRequest
+	16, // 22: cellmanager.CellManager.LocateCell:input_type -> cellmanager.Position
+	20, // 23: cellmanager.CellManager.UnregisterCellMaster:input_type -> cellmanager.CellMasterRequest
+	21, // 24: cellmanager.CellManager.RenewCellMastership:input_type -> cellmanager.LeaseRequest
+	15, // 25: cellmanager.CellManager.PlayerLeftCell:input_type -> cellmanager.PlayerInCellRequest
+	11, // 26: cellmanager.CellManager.RequestCellNeighbours:input_type -> cellmanager.CellNeighbourRequest
+	12, // 27: cellmanager.CellManager.RequestCellSizeChange:input_type -> cellmanager.CellChangeSizeRequest
+	14, // 28: cellmanager.CellManager.LockCells:input_type -> cellmanager.LockCellsRequest
+	14, // 29: cellmanager.CellManager.UnlockCells:input_type -> cellmanager.LockCellsRequest
+	32, // 30: cellmanager.CellManager.ReportCellLoad:input_type -> cellmanager.CellLoadReport
+	35, // 31: cellmanager.CellManager.ReportPositions:input_type -> cellmanager.PositionReport
+	37, // 32: cellmanager.CellManager.VerifyTiling:input_type -> cellmanager.VerifyTilingRequest
+	39, // 33: cellmanager.CellManager.PlanTopology:input_type -> cellmanager.PlanTopologyRequest
+	42, // 34: cellmanager.CellManager.TopologyMetrics:input_type -> cellmanager.TopologyMetricsRequest
+	45, // 35: cellmanager.CellManager.WatchTopology:input_type -> cellmanager.WatchTopologyRequest
+	2,  // 36: cellmanager.Replication.RequestVote:input_type -> cellmanager.VoteRequest
+	5,  // 37: cellmanager.Replication.AppendEntries:input_type -> cellmanager.AppendEntriesRequest
+	7,  // 38: cellmanager.Replication.InstallSnapshot:input_type -> cellmanager.InstallSnapshotRequest
+	29, // 39: cellmanager.CellManager.CreateCell:output_type -> cellmanager.CellStatusReply
+	10, // 40: cellmanager.CellManager.SetWorldSize:output_type -> cellmanager.TransactionSucceeded
+	29, // 41: cellmanager.CellManager.DeleteCell:output_type -> cellmanager.CellStatusReply
+	30, // 42: cellmanager.CellManager.ListCells:output_type -> cellmanager.ListCellsReply
+	10, // 43: cellmanager.CellManager.AddPlayerToCell:output_type -> cellmanager.TransactionSucceeded
+	10, // 44: cellmanager.CellManager.AddPlayerToCellWithPositions:output_type -> cellmanager.TransactionSucceeded
+	50, // 45: cellmanager.CellManager.RequestCellMasterWithPositions:output_type -> cellmanager.CellMasterReply
+	27, // 46: cellmanager.CellManager.DivideCell:output_type -> cellmanager.CellChangeStatusReply
+	47, // 47: cellmanager.CellManager.ListPlayersInCell:output_type -> cellmanager.PlayersReply
+	49, // 48: cellmanager.CellManager.LocatePlayer:output_type -> cellmanager.PlayerLocationReply
+	50, // 49: cellmanager.CellManager.RequestCellMaster:output_type -> cellmanager.CellMasterReply
+	31, // 50: cellmanager.CellManager.LocateCell:output_type -> cellmanager.CellInfo
+	23, // 51: cellmanager.CellManager.UnregisterCellMaster:output_type -> cellmanager.CellMasterStatusReply
+	22, // 52: cellmanager.CellManager.RenewCellMastership:output_type -> cellmanager.LeaseReply
+	24, // 53: cellmanager.CellManager.PlayerLeftCell:output_type -> cellmanager.PlayerStatusReply
+	26, // 54: cellmanager.CellManager.RequestCellNeighbours:output_type -> cellmanager.CellNeighboursReply
+	27, // 55: cellmanager.CellManager.RequestCellSizeChange:output_type -> cellmanager.CellChangeStatusReply
+	28, // 56: cellmanager.CellManager.LockCells:output_type -> cellmanager.CellLockStatusReply
+	28, // 57: cellmanager.CellManager.UnlockCells:output_type -> cellmanager.CellLockStatusReply
+	33, // 58: cellmanager.CellManager.ReportCellLoad:output_type -> cellmanager.CellLoadReply
+	36, // 59: cellmanager.CellManager.ReportPositions:output_type -> cellmanager.PositionReportReply
+	38, // 60: cellmanager.CellManager.VerifyTiling:output_type -> cellmanager.VerifyTilingReply
+	41, // 61: cellmanager.CellManager.PlanTopology:output_type -> cellmanager.PlanTopologyReply
+	44, // 62: cellmanager.CellManager.TopologyMetrics:output_type -> cellmanager.TopologyMetricsReply
+	46, // 63: cellmanager.CellManager.WatchTopology:output_type -> cellmanager.TopologyEvent
+	3,  // 64: cellmanager.Replication.RequestVote:output_type -> cellmanager.VoteReply
+	6,  // 65: cellmanager.Replication.AppendEntries:output_type -> cellmanager.AppendEntriesReply
+	6,  // 66: cellmanager.Replication.InstallSnapshot:output_type -> cellmanager.AppendEntriesReply
+	39, // [39:67] is the sub-list for method output_type
+	11, // [11:39] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_ns_proto_init() }
+func file_ns_proto_init() {
+	if File_ns_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ns_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoteReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicatedEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendEntriesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendEntriesReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InstallSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellListReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionSucceeded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellNeighbourRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellChangeSizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorldSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LockCellsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerInCellRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerInCellRequestWithPositions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCellsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPlayersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellMasterRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaseReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellMasterStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerStatusReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellNeighboursReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1517,8 +4199,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellListReply); i {
+		file_ns_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellChangeStatusReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1529,8 +4211,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TransactionSucceeded); i {
+		file_ns_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellLockStatusReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1541,8 +4223,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellNeighbourRequest); i {
+		file_ns_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellStatusReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1553,8 +4235,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellChangeSizeRequest); i {
+		file_ns_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCellsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1565,8 +4247,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WorldSize); i {
+		file_ns_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1577,8 +4259,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LockCellsRequest); i {
+		file_ns_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellLoadReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1589,8 +4271,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayerInCellRequest); i {
+		file_ns_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellLoadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1601,8 +4283,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Position); i {
+		file_ns_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerPosition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1613,8 +4295,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayerInCellRequestWithPositions); i {
+		file_ns_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PositionReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1625,8 +4307,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListCellsRequest); i {
+		file_ns_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PositionReportReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1637,8 +4319,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListPlayersRequest); i {
+		file_ns_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTilingRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1649,8 +4331,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellMasterRequest); i {
+		file_ns_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyTilingReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1661,8 +4343,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellMasterStatusReply); i {
+		file_ns_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanTopologyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1673,8 +4355,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayerStatusReply); i {
+		file_ns_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlannedChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1685,8 +4367,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellRequest); i {
+		file_ns_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanTopologyReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1697,8 +4379,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellNeighboursReply); i {
+		file_ns_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyMetricsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1709,8 +4391,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellChangeStatusReply); i {
+		file_ns_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegionMetrics); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1721,8 +4403,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellLockStatusReply); i {
+		file_ns_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyMetricsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1733,8 +4415,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellStatusReply); i {
+		file_ns_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchTopologyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1745,8 +4427,8 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListCellsReply); i {
+		file_ns_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopologyEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1757,7 +4439,7 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_ns_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PlayersReply); i {
 			case 0:
 				return &v.state
@@ -1769,7 +4451,31 @@ func file_ns_proto_init() {
 				return nil
 			}
 		}
-		file_ns_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_ns_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocatePlayerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlayerLocationReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ns_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CellMasterReply); i {
 			case 0:
 				return &v.state
@@ -1787,13 +4493,14 @@ func file_ns_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ns_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   23,
+			NumEnums:      2,
+			NumMessages:   49,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_ns_proto_goTypes,
 		DependencyIndexes: file_ns_proto_depIdxs,
+		EnumInfos:         file_ns_proto_enumTypes,
 		MessageInfos:      file_ns_proto_msgTypes,
 	}.Build()
 	File_ns_proto = out.File
@@ -1823,13 +4530,22 @@ type CellManagerClient interface {
 	RequestCellMasterWithPositions(ctx context.Context, in *Position, opts ...grpc.CallOption) (*CellMasterReply, error)
 	DivideCell(ctx context.Context, in *CellRequest, opts ...grpc.CallOption) (*CellChangeStatusReply, error)
 	ListPlayersInCell(ctx context.Context, in *ListPlayersRequest, opts ...grpc.CallOption) (*PlayersReply, error)
+	LocatePlayer(ctx context.Context, in *LocatePlayerRequest, opts ...grpc.CallOption) (*PlayerLocationReply, error)
 	RequestCellMaster(ctx context.Context, in *CellMasterRequest, opts ...grpc.CallOption) (*CellMasterReply, error)
+	LocateCell(ctx context.Context, in *Position, opts ...grpc.CallOption) (*CellInfo, error)
 	UnregisterCellMaster(ctx context.Context, in *CellMasterRequest, opts ...grpc.CallOption) (*CellMasterStatusReply, error)
+	RenewCellMastership(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseReply, error)
 	PlayerLeftCell(ctx context.Context, in *PlayerInCellRequest, opts ...grpc.CallOption) (*PlayerStatusReply, error)
 	RequestCellNeighbours(ctx context.Context, in *CellNeighbourRequest, opts ...grpc.CallOption) (*CellNeighboursReply, error)
 	RequestCellSizeChange(ctx context.Context, in *CellChangeSizeRequest, opts ...grpc.CallOption) (*CellChangeStatusReply, error)
 	LockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error)
 	UnlockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error)
+	ReportCellLoad(ctx context.Context, in *CellLoadReport, opts ...grpc.CallOption) (*CellLoadReply, error)
+	ReportPositions(ctx context.Context, in *PositionReport, opts ...grpc.CallOption) (*PositionReportReply, error)
+	VerifyTiling(ctx context.Context, in *VerifyTilingRequest, opts ...grpc.CallOption) (*VerifyTilingReply, error)
+	PlanTopology(ctx context.Context, in *PlanTopologyRequest, opts ...grpc.CallOption) (*PlanTopologyReply, error)
+	TopologyMetrics(ctx context.Context, in *TopologyMetricsRequest, opts ...grpc.CallOption) (*TopologyMetricsReply, error)
+	WatchTopology(ctx context.Context, in *WatchTopologyRequest, opts ...grpc.CallOption) (CellManager_WatchTopologyClient, error)
 }
 
 type cellManagerClient struct {
@@ -1921,6 +4637,15 @@ func (c *cellManagerClient) ListPlayersInCell(ctx context.Context, in *ListPlaye
 	return out, nil
 }
 
+func (c *cellManagerClient) LocatePlayer(ctx context.Context, in *LocatePlayerRequest, opts ...grpc.CallOption) (*PlayerLocationReply, error) {
+	out := new(PlayerLocationReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/LocatePlayer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cellManagerClient) RequestCellMaster(ctx context.Context, in *CellMasterRequest, opts ...grpc.CallOption) (*CellMasterReply, error) {
 	out := new(CellMasterReply)
 	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RequestCellMaster", in, out, opts...)
@@ -1930,6 +4655,15 @@ func (c *cellManagerClient) RequestCellMaster(ctx context.Context, in *CellMaste
 	return out, nil
 }
 
+func (c *cellManagerClient) LocateCell(ctx context.Context, in *Position, opts ...grpc.CallOption) (*CellInfo, error) {
+	out := new(CellInfo)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/LocateCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *cellManagerClient) UnregisterCellMaster(ctx context.Context, in *CellMasterRequest, opts ...grpc.CallOption) (*CellMasterStatusReply, error) {
 	out := new(CellMasterStatusReply)
 	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/UnregisterCellMaster", in, out, opts...)
@@ -1939,49 +4673,135 @@ func (c *cellManagerClient) UnregisterCellMaster(ctx context.Context, in *CellMa
 	return out, nil
 }
 
-func (c *cellManagerClient) PlayerLeftCell(ctx context.Context, in *PlayerInCellRequest, opts ...grpc.CallOption) (*PlayerStatusReply, error) {
-	out := new(PlayerStatusReply)
-	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/PlayerLeftCell", in, out, opts...)
+func (c *cellManagerClient) RenewCellMastership(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseReply, error) {
+	out := new(LeaseReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RenewCellMastership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) PlayerLeftCell(ctx context.Context, in *PlayerInCellRequest, opts ...grpc.CallOption) (*PlayerStatusReply, error) {
+	out := new(PlayerStatusReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/PlayerLeftCell", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) RequestCellNeighbours(ctx context.Context, in *CellNeighbourRequest, opts ...grpc.CallOption) (*CellNeighboursReply, error) {
+	out := new(CellNeighboursReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RequestCellNeighbours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) RequestCellSizeChange(ctx context.Context, in *CellChangeSizeRequest, opts ...grpc.CallOption) (*CellChangeStatusReply, error) {
+	out := new(CellChangeStatusReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RequestCellSizeChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) LockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error) {
+	out := new(CellLockStatusReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/LockCells", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) UnlockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error) {
+	out := new(CellLockStatusReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/UnlockCells", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) ReportCellLoad(ctx context.Context, in *CellLoadReport, opts ...grpc.CallOption) (*CellLoadReply, error) {
+	out := new(CellLoadReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/ReportCellLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) ReportPositions(ctx context.Context, in *PositionReport, opts ...grpc.CallOption) (*PositionReportReply, error) {
+	out := new(PositionReportReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/ReportPositions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cellManagerClient) VerifyTiling(ctx context.Context, in *VerifyTilingRequest, opts ...grpc.CallOption) (*VerifyTilingReply, error) {
+	out := new(VerifyTilingReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/VerifyTiling", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cellManagerClient) RequestCellNeighbours(ctx context.Context, in *CellNeighbourRequest, opts ...grpc.CallOption) (*CellNeighboursReply, error) {
-	out := new(CellNeighboursReply)
-	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RequestCellNeighbours", in, out, opts...)
+func (c *cellManagerClient) PlanTopology(ctx context.Context, in *PlanTopologyRequest, opts ...grpc.CallOption) (*PlanTopologyReply, error) {
+	out := new(PlanTopologyReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/PlanTopology", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cellManagerClient) RequestCellSizeChange(ctx context.Context, in *CellChangeSizeRequest, opts ...grpc.CallOption) (*CellChangeStatusReply, error) {
-	out := new(CellChangeStatusReply)
-	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/RequestCellSizeChange", in, out, opts...)
+func (c *cellManagerClient) TopologyMetrics(ctx context.Context, in *TopologyMetricsRequest, opts ...grpc.CallOption) (*TopologyMetricsReply, error) {
+	out := new(TopologyMetricsReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/TopologyMetrics", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *cellManagerClient) LockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error) {
-	out := new(CellLockStatusReply)
-	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/LockCells", in, out, opts...)
+func (c *cellManagerClient) WatchTopology(ctx context.Context, in *WatchTopologyRequest, opts ...grpc.CallOption) (CellManager_WatchTopologyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_CellManager_serviceDesc.Streams[0], "/cellmanager.CellManager/WatchTopology", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &cellManagerWatchTopologyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *cellManagerClient) UnlockCells(ctx context.Context, in *LockCellsRequest, opts ...grpc.CallOption) (*CellLockStatusReply, error) {
-	out := new(CellLockStatusReply)
-	err := c.cc.Invoke(ctx, "/cellmanager.CellManager/UnlockCells", in, out, opts...)
-	if err != nil {
+type CellManager_WatchTopologyClient interface {
+	Recv() (*TopologyEvent, error)
+	grpc.ClientStream
+}
+
+type cellManagerWatchTopologyClient struct {
+	grpc.ClientStream
+}
+
+func (x *cellManagerWatchTopologyClient) Recv() (*TopologyEvent, error) {
+	m := new(TopologyEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 // CellManagerServer is the server API for CellManager service.
@@ -1995,13 +4815,22 @@ type CellManagerServer interface {
 	RequestCellMasterWithPositions(context.Context, *Position) (*CellMasterReply, error)
 	DivideCell(context.Context, *CellRequest) (*CellChangeStatusReply, error)
 	ListPlayersInCell(context.Context, *ListPlayersRequest) (*PlayersReply, error)
+	LocatePlayer(context.Context, *LocatePlayerRequest) (*PlayerLocationReply, error)
 	RequestCellMaster(context.Context, *CellMasterRequest) (*CellMasterReply, error)
+	LocateCell(context.Context, *Position) (*CellInfo, error)
 	UnregisterCellMaster(context.Context, *CellMasterRequest) (*CellMasterStatusReply, error)
+	RenewCellMastership(context.Context, *LeaseRequest) (*LeaseReply, error)
 	PlayerLeftCell(context.Context, *PlayerInCellRequest) (*PlayerStatusReply, error)
 	RequestCellNeighbours(context.Context, *CellNeighbourRequest) (*CellNeighboursReply, error)
 	RequestCellSizeChange(context.Context, *CellChangeSizeRequest) (*CellChangeStatusReply, error)
 	LockCells(context.Context, *LockCellsRequest) (*CellLockStatusReply, error)
 	UnlockCells(context.Context, *LockCellsRequest) (*CellLockStatusReply, error)
+	ReportCellLoad(context.Context, *CellLoadReport) (*CellLoadReply, error)
+	ReportPositions(context.Context, *PositionReport) (*PositionReportReply, error)
+	VerifyTiling(context.Context, *VerifyTilingRequest) (*VerifyTilingReply, error)
+	PlanTopology(context.Context, *PlanTopologyRequest) (*PlanTopologyReply, error)
+	TopologyMetrics(context.Context, *TopologyMetricsRequest) (*TopologyMetricsReply, error)
+	WatchTopology(*WatchTopologyRequest, CellManager_WatchTopologyServer) error
 }
 
 // UnimplementedCellManagerServer can be embedded to have forward compatible implementations.
@@ -2035,12 +4864,21 @@ func (*UnimplementedCellManagerServer) DivideCell(context.Context, *CellRequest)
 func (*UnimplementedCellManagerServer) ListPlayersInCell(context.Context, *ListPlayersRequest) (*PlayersReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPlayersInCell not implemented")
 }
+func (*UnimplementedCellManagerServer) LocatePlayer(context.Context, *LocatePlayerRequest) (*PlayerLocationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocatePlayer not implemented")
+}
 func (*UnimplementedCellManagerServer) RequestCellMaster(context.Context, *CellMasterRequest) (*CellMasterReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RequestCellMaster not implemented")
 }
+func (*UnimplementedCellManagerServer) LocateCell(context.Context, *Position) (*CellInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LocateCell not implemented")
+}
 func (*UnimplementedCellManagerServer) UnregisterCellMaster(context.Context, *CellMasterRequest) (*CellMasterStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnregisterCellMaster not implemented")
 }
+func (*UnimplementedCellManagerServer) RenewCellMastership(context.Context, *LeaseRequest) (*LeaseReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewCellMastership not implemented")
+}
 func (*UnimplementedCellManagerServer) PlayerLeftCell(context.Context, *PlayerInCellRequest) (*PlayerStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PlayerLeftCell not implemented")
 }
@@ -2056,6 +4894,24 @@ func (*UnimplementedCellManagerServer) LockCells(context.Context, *LockCellsRequ
 func (*UnimplementedCellManagerServer) UnlockCells(context.Context, *LockCellsRequest) (*CellLockStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnlockCells not implemented")
 }
+func (*UnimplementedCellManagerServer) ReportCellLoad(context.Context, *CellLoadReport) (*CellLoadReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCellLoad not implemented")
+}
+func (*UnimplementedCellManagerServer) ReportPositions(context.Context, *PositionReport) (*PositionReportReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPositions not implemented")
+}
+func (*UnimplementedCellManagerServer) VerifyTiling(context.Context, *VerifyTilingRequest) (*VerifyTilingReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTiling not implemented")
+}
+func (*UnimplementedCellManagerServer) PlanTopology(context.Context, *PlanTopologyRequest) (*PlanTopologyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanTopology not implemented")
+}
+func (*UnimplementedCellManagerServer) TopologyMetrics(context.Context, *TopologyMetricsRequest) (*TopologyMetricsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopologyMetrics not implemented")
+}
+func (*UnimplementedCellManagerServer) WatchTopology(*WatchTopologyRequest, CellManager_WatchTopologyServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTopology not implemented")
+}
 
 func RegisterCellManagerServer(s *grpc.Server, srv CellManagerServer) {
 	s.RegisterService(&_CellManager_serviceDesc, srv)
@@ -2223,6 +5079,24 @@ func _CellManager_ListPlayersInCell_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CellManager_LocatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocatePlayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).LocatePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/LocatePlayer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).LocatePlayer(ctx, req.(*LocatePlayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CellManager_RequestCellMaster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CellMasterRequest)
 	if err := dec(in); err != nil {
@@ -2241,6 +5115,24 @@ func _CellManager_RequestCellMaster_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CellManager_LocateCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Position)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).LocateCell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/LocateCell",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).LocateCell(ctx, req.(*Position))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CellManager_UnregisterCellMaster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CellMasterRequest)
 	if err := dec(in); err != nil {
@@ -2259,6 +5151,24 @@ func _CellManager_UnregisterCellMaster_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CellManager_RenewCellMastership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).RenewCellMastership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/RenewCellMastership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).RenewCellMastership(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CellManager_PlayerLeftCell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PlayerInCellRequest)
 	if err := dec(in); err != nil {
@@ -2349,6 +5259,117 @@ func _CellManager_UnlockCells_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CellManager_ReportCellLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CellLoadReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).ReportCellLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/ReportCellLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).ReportCellLoad(ctx, req.(*CellLoadReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CellManager_ReportPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PositionReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).ReportPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/ReportPositions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).ReportPositions(ctx, req.(*PositionReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CellManager_VerifyTiling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyTilingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).VerifyTiling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/VerifyTiling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).VerifyTiling(ctx, req.(*VerifyTilingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CellManager_PlanTopology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanTopologyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).PlanTopology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/PlanTopology",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).PlanTopology(ctx, req.(*PlanTopologyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CellManager_TopologyMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopologyMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CellManagerServer).TopologyMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.CellManager/TopologyMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CellManagerServer).TopologyMetrics(ctx, req.(*TopologyMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CellManager_WatchTopology_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchTopologyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CellManagerServer).WatchTopology(m, &cellManagerWatchTopologyServer{stream})
+}
+
+type CellManager_WatchTopologyServer interface {
+	Send(*TopologyEvent) error
+	grpc.ServerStream
+}
+
+type cellManagerWatchTopologyServer struct {
+	grpc.ServerStream
+}
+
+func (x *cellManagerWatchTopologyServer) Send(m *TopologyEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _CellManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cellmanager.CellManager",
 	HandlerType: (*CellManagerServer)(nil),
@@ -2389,14 +5410,26 @@ var _CellManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListPlayersInCell",
 			Handler:    _CellManager_ListPlayersInCell_Handler,
 		},
+		{
+			MethodName: "LocatePlayer",
+			Handler:    _CellManager_LocatePlayer_Handler,
+		},
 		{
 			MethodName: "RequestCellMaster",
 			Handler:    _CellManager_RequestCellMaster_Handler,
 		},
+		{
+			MethodName: "LocateCell",
+			Handler:    _CellManager_LocateCell_Handler,
+		},
 		{
 			MethodName: "UnregisterCellMaster",
 			Handler:    _CellManager_UnregisterCellMaster_Handler,
 		},
+		{
+			MethodName: "RenewCellMastership",
+			Handler:    _CellManager_RenewCellMastership_Handler,
+		},
 		{
 			MethodName: "PlayerLeftCell",
 			Handler:    _CellManager_PlayerLeftCell_Handler,
@@ -2417,6 +5450,176 @@ var _CellManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UnlockCells",
 			Handler:    _CellManager_UnlockCells_Handler,
 		},
+		{
+			MethodName: "ReportCellLoad",
+			Handler:    _CellManager_ReportCellLoad_Handler,
+		},
+		{
+			MethodName: "ReportPositions",
+			Handler:    _CellManager_ReportPositions_Handler,
+		},
+		{
+			MethodName: "VerifyTiling",
+			Handler:    _CellManager_VerifyTiling_Handler,
+		},
+		{
+			MethodName: "PlanTopology",
+			Handler:    _CellManager_PlanTopology_Handler,
+		},
+		{
+			MethodName: "TopologyMetrics",
+			Handler:    _CellManager_TopologyMetrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTopology",
+			Handler:       _CellManager_WatchTopology_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ns.proto",
+}
+
+// ReplicationClient is the client API for Replication service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ReplicationClient interface {
+	RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteReply, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error)
+	InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error)
+}
+
+type replicationClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplicationClient(cc grpc.ClientConnInterface) ReplicationClient {
+	return &replicationClient{cc}
+}
+
+func (c *replicationClient) RequestVote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteReply, error) {
+	out := new(VoteReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.Replication/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicationClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error) {
+	out := new(AppendEntriesReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.Replication/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *replicationClient) InstallSnapshot(ctx context.Context, in *InstallSnapshotRequest, opts ...grpc.CallOption) (*AppendEntriesReply, error) {
+	out := new(AppendEntriesReply)
+	err := c.cc.Invoke(ctx, "/cellmanager.Replication/InstallSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReplicationServer is the server API for Replication service.
+type ReplicationServer interface {
+	RequestVote(context.Context, *VoteRequest) (*VoteReply, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error)
+	InstallSnapshot(context.Context, *InstallSnapshotRequest) (*AppendEntriesReply, error)
+}
+
+// UnimplementedReplicationServer can be embedded to have forward compatible implementations.
+type UnimplementedReplicationServer struct {
+}
+
+func (*UnimplementedReplicationServer) RequestVote(context.Context, *VoteRequest) (*VoteReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (*UnimplementedReplicationServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
+func (*UnimplementedReplicationServer) InstallSnapshot(context.Context, *InstallSnapshotRequest) (*AppendEntriesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstallSnapshot not implemented")
+}
+
+func RegisterReplicationServer(s *grpc.Server, srv ReplicationServer) {
+	s.RegisterService(&_Replication_serviceDesc, srv)
+}
+
+func _Replication_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.Replication/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).RequestVote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replication_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.Replication/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Replication_InstallSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstallSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReplicationServer).InstallSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cellmanager.Replication/InstallSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReplicationServer).InstallSnapshot(ctx, req.(*InstallSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Replication_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cellmanager.Replication",
+	HandlerType: (*ReplicationServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequestVote",
+			Handler:    _Replication_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _Replication_AppendEntries_Handler,
+		},
+		{
+			MethodName: "InstallSnapshot",
+			Handler:    _Replication_InstallSnapshot_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ns.proto",
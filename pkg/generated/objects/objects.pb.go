@@ -29,10 +29,14 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+// the objects the cell master held for the cell it gave up, they are handed to the cell masters of the cells that
+// replace it
 type NotifyOfSplitCellReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Objects []*SingleObject `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
 }
 
 func (x *NotifyOfSplitCellReply) Reset() {
@@ -67,10 +71,27 @@ func (*NotifyOfSplitCellReply) Descriptor() ([]byte, []int) {
 	return file_objects_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *NotifyOfSplitCellReply) GetObjects() []*SingleObject {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+// sent to the players of a cell that lost its cell master. The cell is the one the player leaves, a player that has
+// since moved on to another cell ignores the notice. If the new cell master is known it is given with the cell the
+// player is redirected to, otherwise the player asks the cell manager for it.
 type ChangedCellMasterRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	CellId             string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	TopologyVersion    int64  `protobuf:"varint,2,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
+	Ip                 string `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port               int32  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	NewCellId          string `protobuf:"bytes,5,opt,name=newCellId,proto3" json:"newCellId,omitempty"`
+	NewTopologyVersion int64  `protobuf:"varint,6,opt,name=newTopologyVersion,proto3" json:"newTopologyVersion,omitempty"`
 }
 
 func (x *ChangedCellMasterRequest) Reset() {
@@ -105,6 +126,48 @@ func (*ChangedCellMasterRequest) Descriptor() ([]byte, []int) {
 	return file_objects_proto_rawDescGZIP(), []int{1}
 }
 
+func (x *ChangedCellMasterRequest) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *ChangedCellMasterRequest) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+func (x *ChangedCellMasterRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *ChangedCellMasterRequest) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ChangedCellMasterRequest) GetNewCellId() string {
+	if x != nil {
+		return x.NewCellId
+	}
+	return ""
+}
+
+func (x *ChangedCellMasterRequest) GetNewTopologyVersion() int64 {
+	if x != nil {
+		return x.NewTopologyVersion
+	}
+	return 0
+}
+
 type ChangedCellMasterReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -143,12 +206,14 @@ func (*ChangedCellMasterReply) Descriptor() ([]byte, []int) {
 	return file_objects_proto_rawDescGZIP(), []int{2}
 }
 
+// objects broadcast by a cell master carry its epoch, so that subscribers can drop those of a replaced cell master
 type MultipleObjects struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Objects []*SingleObject `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	Epoch   int64           `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
 }
 
 func (x *MultipleObjects) Reset() {
@@ -190,18 +255,83 @@ func (x *MultipleObjects) GetObjects() []*SingleObject {
 	return nil
 }
 
+func (x *MultipleObjects) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+// a cell a player is about to be granted by a split or merge, with the players that will be subscribed to it
+type CellHandover struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cell        *Cell         `protobuf:"bytes,1,opt,name=cell,proto3" json:"cell,omitempty"`
+	Subscribers []*PlayerInfo `protobuf:"bytes,2,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+}
+
+func (x *CellHandover) Reset() {
+	*x = CellHandover{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellHandover) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellHandover) ProtoMessage() {}
+
+func (x *CellHandover) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellHandover.ProtoReflect.Descriptor instead.
+func (*CellHandover) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CellHandover) GetCell() *Cell {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellHandover) GetSubscribers() []*PlayerInfo {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+// the cells are granted for leaseMillis, a lease of 0 never expires
 type CellList struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cells []*Cell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	Cells       []*Cell `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	LeaseMillis int64   `protobuf:"varint,2,opt,name=leaseMillis,proto3" json:"leaseMillis,omitempty"`
 }
 
 func (x *CellList) Reset() {
 	*x = CellList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[4]
+		mi := &file_objects_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -214,7 +344,7 @@ func (x *CellList) String() string {
 func (*CellList) ProtoMessage() {}
 
 func (x *CellList) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[4]
+	mi := &file_objects_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -227,7 +357,7 @@ func (x *CellList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CellList.ProtoReflect.Descriptor instead.
 func (*CellList) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{4}
+	return file_objects_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *CellList) GetCells() []*Cell {
@@ -237,24 +367,36 @@ func (x *CellList) GetCells() []*Cell {
 	return nil
 }
 
+func (x *CellList) GetLeaseMillis() int64 {
+	if x != nil {
+		return x.LeaseMillis
+	}
+	return 0
+}
+
+// an object addressed to a cell carries the topology version the sender knows the cell at, a version of 0 is not
+// checked
 type SingleObject struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CellId     string   `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
-	ObjectId   string   `protobuf:"bytes,2,opt,name=objectId,proto3" json:"objectId,omitempty"`
-	UpdateKey  []string `protobuf:"bytes,3,rep,name=updateKey,proto3" json:"updateKey,omitempty"`
-	NewValue   []string `protobuf:"bytes,4,rep,name=newValue,proto3" json:"newValue,omitempty"`
-	PosX       int64    `protobuf:"varint,5,opt,name=posX,proto3" json:"posX,omitempty"`
-	PosY       int64    `protobuf:"varint,6,opt,name=posY,proto3" json:"posY,omitempty"`
-	ObjectType string   `protobuf:"bytes,7,opt,name=objectType,proto3" json:"objectType,omitempty"`
+	CellId          string   `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	ObjectId        string   `protobuf:"bytes,2,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	UpdateKey       []string `protobuf:"bytes,3,rep,name=updateKey,proto3" json:"updateKey,omitempty"`
+	NewValue        []string `protobuf:"bytes,4,rep,name=newValue,proto3" json:"newValue,omitempty"`
+	PosX            int64    `protobuf:"varint,5,opt,name=posX,proto3" json:"posX,omitempty"`
+	PosY            int64    `protobuf:"varint,6,opt,name=posY,proto3" json:"posY,omitempty"`
+	ObjectType      string   `protobuf:"bytes,7,opt,name=objectType,proto3" json:"objectType,omitempty"`
+	TopologyVersion int64    `protobuf:"varint,8,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
+	// set on an object handed on by the cell manager after a cell master gave its cell up
+	Released *ReleaseTag `protobuf:"bytes,9,opt,name=released,proto3" json:"released,omitempty"`
 }
 
 func (x *SingleObject) Reset() {
 	*x = SingleObject{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[5]
+		mi := &file_objects_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -267,7 +409,7 @@ func (x *SingleObject) String() string {
 func (*SingleObject) ProtoMessage() {}
 
 func (x *SingleObject) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[5]
+	mi := &file_objects_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -280,7 +422,7 @@ func (x *SingleObject) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SingleObject.ProtoReflect.Descriptor instead.
 func (*SingleObject) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{5}
+	return file_objects_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SingleObject) GetCellId() string {
@@ -332,6 +474,85 @@ func (x *SingleObject) GetObjectType() string {
 	return ""
 }
 
+func (x *SingleObject) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+func (x *SingleObject) GetReleased() *ReleaseTag {
+	if x != nil {
+		return x.Released
+	}
+	return nil
+}
+
+// where a handed on object was released, a cell was released once per epoch and the sequence numbers the objects of
+// the release. A cell master drops an object it was already handed, so that a delivery that timed out can be retried.
+type ReleaseTag struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId   string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Epoch    int64  `protobuf:"varint,2,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Sequence int32  `protobuf:"varint,3,opt,name=sequence,proto3" json:"sequence,omitempty"`
+}
+
+func (x *ReleaseTag) Reset() {
+	*x = ReleaseTag{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseTag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseTag) ProtoMessage() {}
+
+func (x *ReleaseTag) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseTag.ProtoReflect.Descriptor instead.
+func (*ReleaseTag) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReleaseTag) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *ReleaseTag) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *ReleaseTag) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
 type NewCellMaster struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -344,7 +565,7 @@ type NewCellMaster struct {
 func (x *NewCellMaster) Reset() {
 	*x = NewCellMaster{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[6]
+		mi := &file_objects_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -357,7 +578,7 @@ func (x *NewCellMaster) String() string {
 func (*NewCellMaster) ProtoMessage() {}
 
 func (x *NewCellMaster) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[6]
+	mi := &file_objects_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,7 +591,7 @@ func (x *NewCellMaster) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NewCellMaster.ProtoReflect.Descriptor instead.
 func (*NewCellMaster) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{6}
+	return file_objects_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *NewCellMaster) GetIp() string {
@@ -397,12 +618,16 @@ type PlayerInfo struct {
 	PosX     int64  `protobuf:"varint,3,opt,name=posX,proto3" json:"posX,omitempty"`
 	PosY     int64  `protobuf:"varint,4,opt,name=posY,proto3" json:"posY,omitempty"`
 	ObjectId string `protobuf:"bytes,5,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	// the cell the player subscribes to and the topology version it knows it at, empty if it subscribes to whatever
+	// cell the cell master owns
+	CellId          string `protobuf:"bytes,6,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	TopologyVersion int64  `protobuf:"varint,7,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
 }
 
 func (x *PlayerInfo) Reset() {
 	*x = PlayerInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[7]
+		mi := &file_objects_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -415,7 +640,7 @@ func (x *PlayerInfo) String() string {
 func (*PlayerInfo) ProtoMessage() {}
 
 func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[7]
+	mi := &file_objects_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +653,7 @@ func (x *PlayerInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerInfo.ProtoReflect.Descriptor instead.
 func (*PlayerInfo) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{7}
+	return file_objects_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *PlayerInfo) GetIp() string {
@@ -466,6 +691,20 @@ func (x *PlayerInfo) GetObjectId() string {
 	return ""
 }
 
+func (x *PlayerInfo) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *PlayerInfo) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
 type Cell struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -476,12 +715,17 @@ type Cell struct {
 	PosY   int64  `protobuf:"varint,3,opt,name=posY,proto3" json:"posY,omitempty"`
 	Width  int64  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
 	Height int64  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	// the fencing token of the cell master, every grant of a cell has a higher epoch than the grants before it
+	Epoch int64 `protobuf:"varint,6,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	// the version of the tree the cell was created at by a split or merge, a cell of the same id created later has a
+	// higher version
+	TopologyVersion int64 `protobuf:"varint,7,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
 }
 
 func (x *Cell) Reset() {
 	*x = Cell{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[8]
+		mi := &file_objects_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -494,7 +738,7 @@ func (x *Cell) String() string {
 func (*Cell) ProtoMessage() {}
 
 func (x *Cell) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[8]
+	mi := &file_objects_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +751,7 @@ func (x *Cell) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Cell.ProtoReflect.Descriptor instead.
 func (*Cell) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{8}
+	return file_objects_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Cell) GetCellId() string {
@@ -545,6 +789,204 @@ func (x *Cell) GetHeight() int64 {
 	return 0
 }
 
+func (x *Cell) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *Cell) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+// the details of a request rejected because it was addressed to a cell that has since been split or merged, the owner
+// is the cell that now holds the position of the request and is empty if the receiver does not know it
+type StaleTopology struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CellId               string `protobuf:"bytes,1,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	TopologyVersion      int64  `protobuf:"varint,2,opt,name=topologyVersion,proto3" json:"topologyVersion,omitempty"`
+	OwnerCellId          string `protobuf:"bytes,3,opt,name=ownerCellId,proto3" json:"ownerCellId,omitempty"`
+	OwnerTopologyVersion int64  `protobuf:"varint,4,opt,name=ownerTopologyVersion,proto3" json:"ownerTopologyVersion,omitempty"`
+	OwnerIp              string `protobuf:"bytes,5,opt,name=ownerIp,proto3" json:"ownerIp,omitempty"`
+	OwnerPort            int32  `protobuf:"varint,6,opt,name=ownerPort,proto3" json:"ownerPort,omitempty"`
+}
+
+func (x *StaleTopology) Reset() {
+	*x = StaleTopology{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StaleTopology) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaleTopology) ProtoMessage() {}
+
+func (x *StaleTopology) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaleTopology.ProtoReflect.Descriptor instead.
+func (*StaleTopology) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StaleTopology) GetCellId() string {
+	if x != nil {
+		return x.CellId
+	}
+	return ""
+}
+
+func (x *StaleTopology) GetTopologyVersion() int64 {
+	if x != nil {
+		return x.TopologyVersion
+	}
+	return 0
+}
+
+func (x *StaleTopology) GetOwnerCellId() string {
+	if x != nil {
+		return x.OwnerCellId
+	}
+	return ""
+}
+
+func (x *StaleTopology) GetOwnerTopologyVersion() int64 {
+	if x != nil {
+		return x.OwnerTopologyVersion
+	}
+	return 0
+}
+
+func (x *StaleTopology) GetOwnerIp() string {
+	if x != nil {
+		return x.OwnerIp
+	}
+	return ""
+}
+
+func (x *StaleTopology) GetOwnerPort() int32 {
+	if x != nil {
+		return x.OwnerPort
+	}
+	return 0
+}
+
+type CellMastershipReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ip          string        `protobuf:"bytes,1,opt,name=ip,proto3" json:"ip,omitempty"`
+	Port        int32         `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	ObjectId    string        `protobuf:"bytes,3,opt,name=objectId,proto3" json:"objectId,omitempty"`
+	OwnsCell    bool          `protobuf:"varint,4,opt,name=ownsCell,proto3" json:"ownsCell,omitempty"`
+	Cell        *Cell         `protobuf:"bytes,5,opt,name=cell,proto3" json:"cell,omitempty"`
+	Subscribers []*PlayerInfo `protobuf:"bytes,6,rep,name=subscribers,proto3" json:"subscribers,omitempty"`
+	Epoch       int64         `protobuf:"varint,7,opt,name=epoch,proto3" json:"epoch,omitempty"`
+}
+
+func (x *CellMastershipReport) Reset() {
+	*x = CellMastershipReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_objects_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CellMastershipReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CellMastershipReport) ProtoMessage() {}
+
+func (x *CellMastershipReport) ProtoReflect() protoreflect.Message {
+	mi := &file_objects_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CellMastershipReport.ProtoReflect.Descriptor instead.
+func (*CellMastershipReport) Descriptor() ([]byte, []int) {
+	return file_objects_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CellMastershipReport) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *CellMastershipReport) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *CellMastershipReport) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *CellMastershipReport) GetOwnsCell() bool {
+	if x != nil {
+		return x.OwnsCell
+	}
+	return false
+}
+
+func (x *CellMastershipReport) GetCell() *Cell {
+	if x != nil {
+		return x.Cell
+	}
+	return nil
+}
+
+func (x *CellMastershipReport) GetSubscribers() []*PlayerInfo {
+	if x != nil {
+		return x.Subscribers
+	}
+	return nil
+}
+
+func (x *CellMastershipReport) GetEpoch() int64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
 type SubscriptionReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -556,7 +998,7 @@ type SubscriptionReply struct {
 func (x *SubscriptionReply) Reset() {
 	*x = SubscriptionReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[9]
+		mi := &file_objects_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -569,7 +1011,7 @@ func (x *SubscriptionReply) String() string {
 func (*SubscriptionReply) ProtoMessage() {}
 
 func (x *SubscriptionReply) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[9]
+	mi := &file_objects_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +1024,7 @@ func (x *SubscriptionReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscriptionReply.ProtoReflect.Descriptor instead.
 func (*SubscriptionReply) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{9}
+	return file_objects_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SubscriptionReply) GetSucceeded() bool {
@@ -601,7 +1043,7 @@ type EmptyReply struct {
 func (x *EmptyReply) Reset() {
 	*x = EmptyReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[10]
+		mi := &file_objects_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -614,7 +1056,7 @@ func (x *EmptyReply) String() string {
 func (*EmptyReply) ProtoMessage() {}
 
 func (x *EmptyReply) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[10]
+	mi := &file_objects_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -627,7 +1069,7 @@ func (x *EmptyReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyReply.ProtoReflect.Descriptor instead.
 func (*EmptyReply) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{10}
+	return file_objects_proto_rawDescGZIP(), []int{14}
 }
 
 type EmptyRequest struct {
@@ -639,7 +1081,7 @@ type EmptyRequest struct {
 func (x *EmptyRequest) Reset() {
 	*x = EmptyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_objects_proto_msgTypes[11]
+		mi := &file_objects_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -652,7 +1094,7 @@ func (x *EmptyRequest) String() string {
 func (*EmptyRequest) ProtoMessage() {}
 
 func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_objects_proto_msgTypes[11]
+	mi := &file_objects_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -665,111 +1107,193 @@ func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
 func (*EmptyRequest) Descriptor() ([]byte, []int) {
-	return file_objects_proto_rawDescGZIP(), []int{11}
+	return file_objects_proto_rawDescGZIP(), []int{15}
 }
 
 var File_objects_proto protoreflect.FileDescriptor
 
 var file_objects_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x18, 0x0a, 0x16, 0x4e, 0x6f, 0x74, 0x69,
+	0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x49, 0x0a, 0x16, 0x4e, 0x6f, 0x74, 0x69,
 	0x66, 0x79, 0x4f, 0x66, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x22, 0x1a, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x43, 0x65, 0x6c,
-	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x18,
-	0x0a, 0x16, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73,
-	0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x42, 0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74,
-	0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x6f,
-	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f,
-	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x2f, 0x0a, 0x08,
-	0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x22, 0xc4, 0x01,
-	0x0a, 0x0c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79,
-	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x6f, 0x73, 0x58, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
-	0x70, 0x6f, 0x73, 0x59, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79,
-	0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x54, 0x79, 0x70, 0x65, 0x22, 0x33, 0x0a, 0x0d, 0x4e, 0x65, 0x77, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
-	0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x74, 0x0a, 0x0a, 0x50, 0x6c, 0x61,
-	0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x73, 0x58, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12,
-	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70,
-	0x6f, 0x73, 0x59, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x22,
-	0x74, 0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12,
-	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70,
-	0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a,
-	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x31, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73,
-	0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x0c, 0x0a, 0x0a, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x32, 0x8c, 0x06, 0x0a, 0x06, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x12, 0x48, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4d, 0x75, 0x74, 0x61,
-	0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x6f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x73, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x10, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12,
-	0x16, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4e, 0x65, 0x77, 0x43, 0x65, 0x6c,
-	0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x45,
-	0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d,
-	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x2e, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x1a, 0x13,
-	0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x16, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12,
-	0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x18,
-	0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
-	0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x00, 0x12, 0x4a, 0x0a, 0x17, 0x42, 0x72,
-	0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
-	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x1a,
+	0x6c, 0x79, 0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x69,
+	0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x22, 0xce, 0x01, 0x0a, 0x18, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x43,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f, 0x70, 0x6f,
+	0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x43, 0x65,
+	0x6c, 0x6c, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x6e, 0x65, 0x77, 0x54, 0x6f, 0x70, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x12, 0x6e, 0x65, 0x77, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x43,
+	0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x58,
+	0x0a, 0x0f, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x73, 0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6e,
+	0x67, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x07, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x68, 0x0a, 0x0c, 0x43, 0x65, 0x6c, 0x6c,
+	0x48, 0x61, 0x6e, 0x64, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x04, 0x63, 0x65, 0x6c, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x12, 0x35, 0x0a, 0x0b, 0x73,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x72, 0x73, 0x22, 0x51, 0x0a, 0x08, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23,
+	0x0a, 0x05, 0x63, 0x65, 0x6c, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x05, 0x63, 0x65,
+	0x6c, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d,
+	0x69, 0x6c, 0x6c, 0x69, 0x73, 0x22, 0x9f, 0x02, 0x0a, 0x0c, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x0f,
+	0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x54, 0x61, 0x67, 0x52, 0x08, 0x72,
+	0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x22, 0x56, 0x0a, 0x0a, 0x52, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x54, 0x61, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70,
+	0x6f, 0x63, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x22,
+	0x33, 0x0a, 0x0d, 0x4e, 0x65, 0x77, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x22, 0xb6, 0x01, 0x0a, 0x0a, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x6f, 0x73, 0x59, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c,
+	0x6c, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb4, 0x01,
+	0x0a, 0x04, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x6f, 0x73, 0x58, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x6f,
+	0x73, 0x58, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x70, 0x6f, 0x73, 0x59, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x22, 0xdf, 0x01, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x54, 0x6f,
+	0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x28,
+	0x0a, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x43, 0x65, 0x6c, 0x6c, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x14, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x54, 0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x54,
+	0x6f, 0x70, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x14, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x73, 0x43, 0x65, 0x6c, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x6f, 0x77, 0x6e, 0x73, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x21, 0x0a, 0x04, 0x63,
+	0x65, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x52, 0x04, 0x63, 0x65, 0x6c, 0x6c, 0x12, 0x35,
+	0x0a, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x22, 0x31, 0x0a, 0x11, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x22, 0x0c,
+	0x0a, 0x0a, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x0e, 0x0a, 0x0c,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x32, 0xa3, 0x07, 0x0a,
+	0x06, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x12, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x4d,
+	0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x16, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x4e, 0x65, 0x77, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x1a, 0x13, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x15, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x16, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x17, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x4d, 0x75, 0x74,
+	0x61, 0x74, 0x65, 0x64, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x18, 0x2e, 0x6f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x15,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65,
+	0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x45, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61,
+	0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x48, 0x61, 0x6e, 0x64, 0x6f, 0x76, 0x65, 0x72, 0x1a,
 	0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x15, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
-	0x65, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12,
-	0x11, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x4c, 0x69,
-	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0c, 0x47, 0x65, 0x74,
-	0x43, 0x65, 0x6c, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65,
-	0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12,
-	0x15, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a,
-	0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72,
-	0x12, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65,
-	0x72, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x1a, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
-	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x11, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x4f, 0x66, 0x53,
-	0x70, 0x6c, 0x69, 0x74, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63,
-	0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x1f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x73, 0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x4f, 0x66, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x43,
-	0x65, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x11, 0x43, 0x68,
-	0x61, 0x6e, 0x67, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12,
-	0x21, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61,
-	0x6e, 0x67, 0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x43, 0x65, 0x6c,
+	0x6c, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x2e, 0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x18, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22,
+	0x00, 0x12, 0x37, 0x0a, 0x07, 0x49, 0x73, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x12, 0x15, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0f, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12, 0x13, 0x2e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x49, 0x6e,
+	0x66, 0x6f, 0x1a, 0x1a, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00,
+	0x12, 0x45, 0x0a, 0x11, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x4f, 0x66, 0x53, 0x70, 0x6c, 0x69,
+	0x74, 0x43, 0x65, 0x6c, 0x6c, 0x12, 0x0d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e,
+	0x43, 0x65, 0x6c, 0x6c, 0x1a, 0x1f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x4e,
+	0x6f, 0x74, 0x69, 0x66, 0x79, 0x4f, 0x66, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x43, 0x65, 0x6c, 0x6c,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x11, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x12, 0x21, 0x2e, 0x6f,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x43, 0x65,
+	0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x43, 0x65, 0x6c, 0x6c, 0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4e, 0x0a, 0x14, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x65, 0x6c, 0x6c,
+	0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x12, 0x15, 0x2e, 0x6f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x2e, 0x43, 0x65, 0x6c, 0x6c,
+	0x4d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x73, 0x68, 0x69, 0x70, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -784,51 +1308,65 @@ func file_objects_proto_rawDescGZIP() []byte {
 	return file_objects_proto_rawDescData
 }
 
-var file_objects_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_objects_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_objects_proto_goTypes = []interface{}{
 	(*NotifyOfSplitCellReply)(nil),   // 0: objects.NotifyOfSplitCellReply
 	(*ChangedCellMasterRequest)(nil), // 1: objects.ChangedCellMasterRequest
 	(*ChangedCellMasterReply)(nil),   // 2: objects.ChangedCellMasterReply
 	(*MultipleObjects)(nil),          // 3: objects.MultipleObjects
-	(*CellList)(nil),                 // 4: objects.CellList
-	(*SingleObject)(nil),             // 5: objects.SingleObject
-	(*NewCellMaster)(nil),            // 6: objects.NewCellMaster
-	(*PlayerInfo)(nil),               // 7: objects.PlayerInfo
-	(*Cell)(nil),                     // 8: objects.Cell
-	(*SubscriptionReply)(nil),        // 9: objects.SubscriptionReply
-	(*EmptyReply)(nil),               // 10: objects.EmptyReply
-	(*EmptyRequest)(nil),             // 11: objects.EmptyRequest
+	(*CellHandover)(nil),             // 4: objects.CellHandover
+	(*CellList)(nil),                 // 5: objects.CellList
+	(*SingleObject)(nil),             // 6: objects.SingleObject
+	(*ReleaseTag)(nil),               // 7: objects.ReleaseTag
+	(*NewCellMaster)(nil),            // 8: objects.NewCellMaster
+	(*PlayerInfo)(nil),               // 9: objects.PlayerInfo
+	(*Cell)(nil),                     // 10: objects.Cell
+	(*StaleTopology)(nil),            // 11: objects.StaleTopology
+	(*CellMastershipReport)(nil),     // 12: objects.CellMastershipReport
+	(*SubscriptionReply)(nil),        // 13: objects.SubscriptionReply
+	(*EmptyReply)(nil),               // 14: objects.EmptyReply
+	(*EmptyRequest)(nil),             // 15: objects.EmptyRequest
 }
 var file_objects_proto_depIdxs = []int32{
-	5,  // 0: objects.MultipleObjects.objects:type_name -> objects.SingleObject
-	8,  // 1: objects.CellList.cells:type_name -> objects.Cell
-	3,  // 2: objects.Player.ReceiveMutatedObjects:input_type -> objects.MultipleObjects
-	6,  // 3: objects.Player.UpdateCellMaster:input_type -> objects.NewCellMaster
-	5,  // 4: objects.Player.RequestObjectMutation:input_type -> objects.SingleObject
-	8,  // 5: objects.Player.RequestMutatingObjects:input_type -> objects.Cell
-	3,  // 6: objects.Player.BroadcastMutatedObjects:input_type -> objects.MultipleObjects
-	4,  // 7: objects.Player.ReceiveCellMastership:input_type -> objects.CellList
-	8,  // 8: objects.Player.GetCellState:input_type -> objects.Cell
-	11, // 9: objects.Player.IsAlive:input_type -> objects.EmptyRequest
-	7,  // 10: objects.Player.SubscribePlayer:input_type -> objects.PlayerInfo
-	8,  // 11: objects.Player.NotifyOfSplitCell:input_type -> objects.Cell
-	1,  // 12: objects.Player.ChangedCellMaster:input_type -> objects.ChangedCellMasterRequest
-	10, // 13: objects.Player.ReceiveMutatedObjects:output_type -> objects.EmptyReply
-	10, // 14: objects.Player.UpdateCellMaster:output_type -> objects.EmptyReply
-	10, // 15: objects.Player.RequestObjectMutation:output_type -> objects.EmptyReply
-	3,  // 16: objects.Player.RequestMutatingObjects:output_type -> objects.MultipleObjects
-	10, // 17: objects.Player.BroadcastMutatedObjects:output_type -> objects.EmptyReply
-	10, // 18: objects.Player.ReceiveCellMastership:output_type -> objects.EmptyReply
-	3,  // 19: objects.Player.GetCellState:output_type -> objects.MultipleObjects
-	10, // 20: objects.Player.IsAlive:output_type -> objects.EmptyReply
-	9,  // 21: objects.Player.SubscribePlayer:output_type -> objects.SubscriptionReply
-	0,  // 22: objects.Player.NotifyOfSplitCell:output_type -> objects.NotifyOfSplitCellReply
-	2,  // 23: objects.Player.ChangedCellMaster:output_type -> objects.ChangedCellMasterReply
-	13, // [13:24] is the sub-list for method output_type
-	2,  // [2:13] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	6,  // 0: objects.NotifyOfSplitCellReply.objects:type_name -> objects.SingleObject
+	6,  // 1: objects.MultipleObjects.objects:type_name -> objects.SingleObject
+	10, // 2: objects.CellHandover.cell:type_name -> objects.Cell
+	9,  // 3: objects.CellHandover.subscribers:type_name -> objects.PlayerInfo
+	10, // 4: objects.CellList.cells:type_name -> objects.Cell
+	7,  // 5: objects.SingleObject.released:type_name -> objects.ReleaseTag
+	10, // 6: objects.CellMastershipReport.cell:type_name -> objects.Cell
+	9,  // 7: objects.CellMastershipReport.subscribers:type_name -> objects.PlayerInfo
+	3,  // 8: objects.Player.ReceiveMutatedObjects:input_type -> objects.MultipleObjects
+	8,  // 9: objects.Player.UpdateCellMaster:input_type -> objects.NewCellMaster
+	6,  // 10: objects.Player.RequestObjectMutation:input_type -> objects.SingleObject
+	10, // 11: objects.Player.RequestMutatingObjects:input_type -> objects.Cell
+	3,  // 12: objects.Player.BroadcastMutatedObjects:input_type -> objects.MultipleObjects
+	5,  // 13: objects.Player.ReceiveCellMastership:input_type -> objects.CellList
+	4,  // 14: objects.Player.PrepareCellMastership:input_type -> objects.CellHandover
+	10, // 15: objects.Player.GetCellState:input_type -> objects.Cell
+	15, // 16: objects.Player.IsAlive:input_type -> objects.EmptyRequest
+	9,  // 17: objects.Player.SubscribePlayer:input_type -> objects.PlayerInfo
+	10, // 18: objects.Player.NotifyOfSplitCell:input_type -> objects.Cell
+	1,  // 19: objects.Player.ChangedCellMaster:input_type -> objects.ChangedCellMasterRequest
+	15, // 20: objects.Player.ReportCellMastership:input_type -> objects.EmptyRequest
+	14, // 21: objects.Player.ReceiveMutatedObjects:output_type -> objects.EmptyReply
+	14, // 22: objects.Player.UpdateCellMaster:output_type -> objects.EmptyReply
+	14, // 23: objects.Player.RequestObjectMutation:output_type -> objects.EmptyReply
+	3,  // 24: objects.Player.RequestMutatingObjects:output_type -> objects.MultipleObjects
+	14, // 25: objects.Player.BroadcastMutatedObjects:output_type -> objects.EmptyReply
+	14, // 26: objects.Player.ReceiveCellMastership:output_type -> objects.EmptyReply
+	14, // 27: objects.Player.PrepareCellMastership:output_type -> objects.EmptyReply
+	3,  // 28: objects.Player.GetCellState:output_type -> objects.MultipleObjects
+	14, // 29: objects.Player.IsAlive:output_type -> objects.EmptyReply
+	13, // 30: objects.Player.SubscribePlayer:output_type -> objects.SubscriptionReply
+	0,  // 31: objects.Player.NotifyOfSplitCell:output_type -> objects.NotifyOfSplitCellReply
+	2,  // 32: objects.Player.ChangedCellMaster:output_type -> objects.ChangedCellMasterReply
+	12, // 33: objects.Player.ReportCellMastership:output_type -> objects.CellMastershipReport
+	21, // [21:34] is the sub-list for method output_type
+	8,  // [8:21] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_objects_proto_init() }
@@ -886,7 +1424,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CellList); i {
+			switch v := v.(*CellHandover); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -898,7 +1436,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SingleObject); i {
+			switch v := v.(*CellList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -910,7 +1448,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NewCellMaster); i {
+			switch v := v.(*SingleObject); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -922,7 +1460,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PlayerInfo); i {
+			switch v := v.(*ReleaseTag); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -934,7 +1472,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Cell); i {
+			switch v := v.(*NewCellMaster); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -946,7 +1484,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscriptionReply); i {
+			switch v := v.(*PlayerInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -958,7 +1496,7 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyReply); i {
+			switch v := v.(*Cell); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -970,6 +1508,54 @@ func file_objects_proto_init() {
 			}
 		}
 		file_objects_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StaleTopology); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CellMastershipReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_objects_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EmptyRequest); i {
 			case 0:
 				return &v.state
@@ -988,7 +1574,7 @@ func file_objects_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_objects_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1020,11 +1606,13 @@ type PlayerClient interface {
 	RequestMutatingObjects(ctx context.Context, in *Cell, opts ...grpc.CallOption) (*MultipleObjects, error)
 	BroadcastMutatedObjects(ctx context.Context, in *MultipleObjects, opts ...grpc.CallOption) (*EmptyReply, error)
 	ReceiveCellMastership(ctx context.Context, in *CellList, opts ...grpc.CallOption) (*EmptyReply, error)
+	PrepareCellMastership(ctx context.Context, in *CellHandover, opts ...grpc.CallOption) (*EmptyReply, error)
 	GetCellState(ctx context.Context, in *Cell, opts ...grpc.CallOption) (*MultipleObjects, error)
 	IsAlive(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyReply, error)
 	SubscribePlayer(ctx context.Context, in *PlayerInfo, opts ...grpc.CallOption) (*SubscriptionReply, error)
 	NotifyOfSplitCell(ctx context.Context, in *Cell, opts ...grpc.CallOption) (*NotifyOfSplitCellReply, error)
 	ChangedCellMaster(ctx context.Context, in *ChangedCellMasterRequest, opts ...grpc.CallOption) (*ChangedCellMasterReply, error)
+	ReportCellMastership(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*CellMastershipReport, error)
 }
 
 type playerClient struct {
@@ -1089,6 +1677,15 @@ func (c *playerClient) ReceiveCellMastership(ctx context.Context, in *CellList,
 	return out, nil
 }
 
+func (c *playerClient) PrepareCellMastership(ctx context.Context, in *CellHandover, opts ...grpc.CallOption) (*EmptyReply, error) {
+	out := new(EmptyReply)
+	err := c.cc.Invoke(ctx, "/objects.Player/PrepareCellMastership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playerClient) GetCellState(ctx context.Context, in *Cell, opts ...grpc.CallOption) (*MultipleObjects, error) {
 	out := new(MultipleObjects)
 	err := c.cc.Invoke(ctx, "/objects.Player/GetCellState", in, out, opts...)
@@ -1134,6 +1731,15 @@ func (c *playerClient) ChangedCellMaster(ctx context.Context, in *ChangedCellMas
 	return out, nil
 }
 
+func (c *playerClient) ReportCellMastership(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*CellMastershipReport, error) {
+	out := new(CellMastershipReport)
+	err := c.cc.Invoke(ctx, "/objects.Player/ReportCellMastership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlayerServer is the server API for Player service.
 type PlayerServer interface {
 	ReceiveMutatedObjects(context.Context, *MultipleObjects) (*EmptyReply, error)
@@ -1142,11 +1748,13 @@ type PlayerServer interface {
 	RequestMutatingObjects(context.Context, *Cell) (*MultipleObjects, error)
 	BroadcastMutatedObjects(context.Context, *MultipleObjects) (*EmptyReply, error)
 	ReceiveCellMastership(context.Context, *CellList) (*EmptyReply, error)
+	PrepareCellMastership(context.Context, *CellHandover) (*EmptyReply, error)
 	GetCellState(context.Context, *Cell) (*MultipleObjects, error)
 	IsAlive(context.Context, *EmptyRequest) (*EmptyReply, error)
 	SubscribePlayer(context.Context, *PlayerInfo) (*SubscriptionReply, error)
 	NotifyOfSplitCell(context.Context, *Cell) (*NotifyOfSplitCellReply, error)
 	ChangedCellMaster(context.Context, *ChangedCellMasterRequest) (*ChangedCellMasterReply, error)
+	ReportCellMastership(context.Context, *EmptyRequest) (*CellMastershipReport, error)
 }
 
 // UnimplementedPlayerServer can be embedded to have forward compatible implementations.
@@ -1171,6 +1779,9 @@ func (*UnimplementedPlayerServer) BroadcastMutatedObjects(context.Context, *Mult
 func (*UnimplementedPlayerServer) ReceiveCellMastership(context.Context, *CellList) (*EmptyReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReceiveCellMastership not implemented")
 }
+func (*UnimplementedPlayerServer) PrepareCellMastership(context.Context, *CellHandover) (*EmptyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PrepareCellMastership not implemented")
+}
 func (*UnimplementedPlayerServer) GetCellState(context.Context, *Cell) (*MultipleObjects, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCellState not implemented")
 }
@@ -1186,6 +1797,9 @@ func (*UnimplementedPlayerServer) NotifyOfSplitCell(context.Context, *Cell) (*No
 func (*UnimplementedPlayerServer) ChangedCellMaster(context.Context, *ChangedCellMasterRequest) (*ChangedCellMasterReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangedCellMaster not implemented")
 }
+func (*UnimplementedPlayerServer) ReportCellMastership(context.Context, *EmptyRequest) (*CellMastershipReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportCellMastership not implemented")
+}
 
 func RegisterPlayerServer(s *grpc.Server, srv PlayerServer) {
 	s.RegisterService(&_Player_serviceDesc, srv)
@@ -1299,6 +1913,24 @@ func _Player_ReceiveCellMastership_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_PrepareCellMastership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CellHandover)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).PrepareCellMastership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/objects.Player/PrepareCellMastership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).PrepareCellMastership(ctx, req.(*CellHandover))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Player_GetCellState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Cell)
 	if err := dec(in); err != nil {
@@ -1389,6 +2021,24 @@ func _Player_ChangedCellMaster_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Player_ReportCellMastership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServer).ReportCellMastership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/objects.Player/ReportCellMastership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServer).ReportCellMastership(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Player_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "objects.Player",
 	HandlerType: (*PlayerServer)(nil),
@@ -1417,6 +2067,10 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ReceiveCellMastership",
 			Handler:    _Player_ReceiveCellMastership_Handler,
 		},
+		{
+			MethodName: "PrepareCellMastership",
+			Handler:    _Player_PrepareCellMastership_Handler,
+		},
 		{
 			MethodName: "GetCellState",
 			Handler:    _Player_GetCellState_Handler,
@@ -1437,6 +2091,10 @@ var _Player_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChangedCellMaster",
 			Handler:    _Player_ChangedCellMaster_Handler,
 		},
+		{
+			MethodName: "ReportCellMastership",
+			Handler:    _Player_ReportCellMastership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "objects.proto",
@@ -0,0 +1,65 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"testing"
+)
+
+func TestCellIdsFollowSplitPath(t *testing.T) {
+	cm := cellmanager.NewCellManager()
+	createCrowdedWorld(&cm)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	quadrants := map[string][2]int64{"0": {0, 0}, "1": {50, 0}, "2": {0, 50}, "3": {50, 50}}
+	for cellId, position := range quadrants {
+		if cellIdAt(&cm, position[0], position[1]) != cellId {
+			fatalFail(errors.New("quadrant " + cellId + " is not named by its quadkey"))
+		}
+	}
+
+	// the crowd is on the border of quadrants 0 and 2, so 2 is cut along x
+	divideInHalves(&cm, "2")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "2w"})
+	failIfNotNull(err, "could not divide cell")
+	cells := listCells(&cm, true)
+	for _, cellId := range []string{"2w", "2e", "2w0", "2w1", "2w2", "2w3"} {
+		if _, ok := cells[cellId]; !ok {
+			fatalFail(errors.New("there is no cell " + cellId))
+		}
+	}
+	expectPlayerCounts(&cm)
+
+	// merging and splitting again gives the same ids
+	cm.PerformMerge("2")
+	if _, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: "2w0"}); err == nil {
+		fatalFail(errors.New("a merged cell can still be found"))
+	}
+	divideInHalves(&cm, "2")
+	if _, ok := listCells(&cm, false)["2w"]; !ok {
+		fatalFail(errors.New("splitting again did not give the same ids"))
+	}
+	expectExactTiling(&cm)
+}
+
+func TestRecoverRejectsClaimsOutsideTheirPath(t *testing.T) {
+	ports := []int{firstRecoveryPort + 30, firstRecoveryPort + 31, firstRecoveryPort + 32}
+	_, misplacedServer := startCellMaster(ports[0], claimedCell("1", 0, 0, 50, 50))
+	defer misplacedServer.Stop()
+	_, numberedServer := startCellMaster(ports[1], claimedCell("cell7", 50, 50, 50, 50))
+	defer numberedServer.Stop()
+	_, placedServer := startCellMaster(ports[2], claimedCell("2", 0, 50, 50, 50))
+	defer placedServer.Stop()
+
+	cm := cellmanager.NewCellManager()
+	accepted, err := cm.RecoverFromPeers(peerAddresses(ports...), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+	if accepted != 1 {
+		fatalFail(errors.New("a claim that does not lie where its id says was accepted"))
+	}
+	expectClaim(listCells(&cm, false), "2", 0, 50, 50, 50, int32(ports[2]))
+	expectExactTiling(&cm)
+}
@@ -0,0 +1,122 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// nothing listens on these ports, so notifying the players fails fast
+const firstUnusedPort = 41000
+
+func hammerPlayers(cm *cellmanager.CellManager, worker int, playersPerWorker int, wg *sync.WaitGroup) {
+	defer wg.Done()
+	random := rand.New(rand.NewSource(int64(worker)))
+	for i := 0; i < playersPerWorker; i++ {
+		port := int32(firstUnusedPort + worker*playersPerWorker + i)
+		cm.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{
+			Ip: "localhost", Port: port, PosX: random.Int63n(1024), PosY: random.Int63n(1024),
+		})
+
+		if random.Intn(3) == 0 {
+			location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: port})
+			if err == nil && location.Found {
+				cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{Ip: "localhost", Port: port, CellId: location.CellId})
+			}
+		}
+	}
+}
+
+func hammerTopology(cm *cellmanager.CellManager, worker int, iterations int, wg *sync.WaitGroup) {
+	defer wg.Done()
+	random := rand.New(rand.NewSource(int64(worker)))
+	for i := 0; i < iterations; i++ {
+		cells, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{IncludeInterior: true})
+		if err != nil {
+			continue
+		}
+		cell := cells.Cells[random.Intn(len(cells.Cells))]
+
+		switch random.Intn(5) {
+		case 0:
+			cm.UpdateTopology()
+		case 1:
+			if cell.IsLeaf && cell.Width > 16 {
+				cm.PerformSplit(cell.CellId)
+			}
+		case 2:
+			if !cell.IsLeaf {
+				cm.PerformMerge(cell.CellId)
+			}
+		case 3:
+			cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cell.CellId})
+		case 4:
+			cm.RequestCellNeighbours(context.Background(), &generated.CellNeighbourRequest{CellId: cell.CellId})
+		}
+	}
+}
+
+func TestConcurrentPlayersAndTopologyChanges(t *testing.T) {
+	// a power of two keeps every split exact
+	cm := createWorld(1024, 1024)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(2)
+		go hammerPlayers(&cm, worker, 40, &wg)
+		go hammerTopology(&cm, worker, 40, &wg)
+	}
+	wg.Wait()
+
+	reply, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{})
+	failIfNotNull(err, "could not list cells")
+	area := int64(0)
+	for _, cell := range reply.Cells {
+		area += cell.Width * cell.Height
+	}
+	if area != 1024*1024 {
+		fatalFail(errors.New("leaves do not cover the world"))
+	}
+	expectExactTiling(&cm)
+
+	players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not list players")
+	seen := make(map[int32]bool, 0)
+	for index, port := range players.Port {
+		if seen[port] {
+			fatalFail(errors.New("player registered more than once"))
+		}
+		if players.Ip[index] != "localhost" || port < firstUnusedPort || port >= firstUnusedPort+8*40 {
+			fatalFail(errors.New("corrupted player registered"))
+		}
+		seen[port] = true
+	}
+}
+
+func TestConcurrentJoinAndLeaveKeepsPlayerLists(t *testing.T) {
+	cm := createWorld(1000, 1000)
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < 16; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				port := int32(firstUnusedPort + worker*50 + i)
+				addPlayer(&cm, "localhost", port, int64(worker), int64(i))
+				if i%2 == 0 {
+					cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{Ip: "localhost", Port: port, CellId: "initialCell"})
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not list players")
+	if len(players.Port) != 16*25 {
+		fatalFail(errors.New("players were lost or not removed under concurrent joins and leaves"))
+	}
+}
@@ -0,0 +1,98 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"testing"
+	"time"
+)
+
+// expectFlaps checks the metrics UpdateTopology keeps on the initial cell.
+func expectFlaps(cm *cellmanager.CellManager, splits int32, merges int32, flaps int32) {
+	metrics, err := cm.TopologyMetrics(context.Background(), &generated.TopologyMetricsRequest{})
+	failIfNotNull(err, "could not get topology metrics")
+	if len(metrics.Regions) != 1 || metrics.Regions[0].CellId != "initialCell" || metrics.Flaps != flaps {
+		fatalFail(errors.New(fmt.Sprintf("unexpected metrics %v", metrics)))
+	}
+	region := metrics.Regions[0]
+	if region.Splits != splits || region.Merges != merges || region.Flaps != flaps {
+		fatalFail(errors.New(fmt.Sprintf("expected %d splits, %d merges and %d flaps, got %v", splits, merges, flaps, region)))
+	}
+}
+
+func TestOverlappingWatermarksDoNotFlap(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+	cm.SetTopologyCooldown(0)
+	cm.SetMinCellLifetime(0)
+
+	// the split would be merged right back
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 2, MergeAt: 2, MinAge: time.Hour})
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 1)
+
+	// and the merge split right back
+	setPolicy(&cm, "players:2:1:0s")
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 2, MergeAt: 2, MinAge: -time.Second})
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+}
+
+func TestSplitCellsLiveForMinimumLifetime(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+	cm.SetTopologyCooldown(0)
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 2, MergeAt: 1, MinAge: -time.Second})
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+
+	_, err := cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{
+		Ip: "localhost", Port: firstUnusedPort + 1, CellId: cellIdAt(&cm, 50, 50),
+	})
+	failIfNotNull(err, "could not remove player")
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+
+	cm.SetMinCellLifetime(0)
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 1)
+}
+
+func TestTopologyMetricsCountFlaps(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+	cm.SetTopologyCooldown(0)
+	cm.SetMinCellLifetime(0)
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 2, MergeAt: 1, MinAge: -time.Second})
+
+	// a player walking in and out of the world makes it split and merge over and over
+	cm.UpdateTopology()
+	expectFlaps(&cm, 1, 0, 0)
+	for round := int32(1); round <= 2; round++ {
+		_, err := cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{
+			Ip: "localhost", Port: firstUnusedPort + 1, CellId: cellIdAt(&cm, 50, 50),
+		})
+		failIfNotNull(err, "could not remove player")
+		cm.UpdateTopology()
+		addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+		cm.UpdateTopology()
+		expectFlaps(&cm, 1+round, round, 2*round)
+	}
+
+	// changes further apart than the flap window are not flaps
+	cm.SetFlapWindow(0)
+	_, err := cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{
+		Ip: "localhost", Port: firstUnusedPort + 1, CellId: cellIdAt(&cm, 50, 50),
+	})
+	failIfNotNull(err, "could not remove player")
+	cm.UpdateTopology()
+	expectFlaps(&cm, 3, 3, 4)
+}
@@ -0,0 +1,109 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"testing"
+)
+
+const handoverPort = 8930
+
+// subscriberPorts returns the ports of the players subscribed to the cell at the player.
+func subscriberPorts(player *objects.Player, cellId string) map[int]bool {
+	player.CellMasterMutex.Lock()
+	defer player.CellMasterMutex.Unlock()
+	ports := make(map[int]bool, 0)
+	for _, subscriber := range (*player.SubscribedPlayers)[cellId] {
+		ports[subscriber.Port] = true
+	}
+	return ports
+}
+
+func TestSplitHandsCellsToPrimedCellMasters(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+
+	addPlayer(cm, "localhost", handoverPort, 10, 10)
+	cell := claimedCell("initialCell", 0, 0, 100, 100)
+	cell.Epoch = grantCellMaster(cm, "initialCell", handoverPort)
+	cell.TopologyVersion = listCells(cm, false)["initialCell"].TopologyVersion
+	addPlayer(cm, "localhost", handoverPort+1, 60, 60)
+
+	first, firstServer := startCellMaster(handoverPort, cell, handoverPort+1)
+	defer firstServer.Stop()
+	first.PosX, first.PosY = 10, 10
+	second, secondServer := startCellMaster(handoverPort+1, nil)
+	defer secondServer.Stop()
+	second.PosX, second.PosY = 60, 60
+	second.SetCellMaster(nil, nil, "initialCell", cell.TopologyVersion)
+
+	if !cm.PerformSplit("initialCell") {
+		fatalFail(errors.New("the cell was not split"))
+	}
+	cells := listCells(cm, true)
+	if cells["0"].CellMasterPort != handoverPort || cells["3"].CellMasterPort != handoverPort+1 {
+		fatalFail(errors.New("the children were not granted to the players in them"))
+	}
+	if cells["0"].Epoch <= cell.Epoch || cells["3"].Epoch <= cell.Epoch || cells["0"].Epoch == cells["3"].Epoch {
+		fatalFail(errors.New("the children were not granted with epochs of their own"))
+	}
+	if cells["initialCell"].Locked {
+		fatalFail(errors.New("the split cell was left locked"))
+	}
+	if owned, ok := first.OwnedCell(); !ok || owned.CellId != "0" {
+		fatalFail(errors.New("the old cell master was not handed the child it is in"))
+	}
+	if owned, ok := second.OwnedCell(); !ok || owned.CellId != "3" {
+		fatalFail(errors.New("the new cell master does not own its child"))
+	}
+
+	// the players are subscribed to the cell masters of their children and not to the split cell
+	if ports := subscriberPorts(first, "0"); len(ports) != 1 || !ports[handoverPort] {
+		fatalFail(errors.New(fmt.Sprintf("expected only the old cell master in its child, got %v", ports)))
+	}
+	if len(subscriberPorts(first, "initialCell")) != 0 {
+		fatalFail(errors.New("the old cell master kept the subscribers of the split cell"))
+	}
+	if ports := subscriberPorts(second, "3"); len(ports) != 1 || !ports[handoverPort+1] {
+		fatalFail(errors.New(fmt.Sprintf("expected the primed subscriber in the new cell, got %v", ports)))
+	}
+	if redirected := second.CurrentCellMaster(); redirected.CellMaster == nil || redirected.CellId != "3" ||
+		redirected.TopologyVersion != cells["3"].TopologyVersion {
+		fatalFail(errors.New("the player was not redirected to the cell master of its child"))
+	}
+}
+
+func TestUnprimedCellMasterIsNotGranted(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+
+	addPlayer(cm, "localhost", handoverPort, 10, 10)
+	cell := claimedCell("initialCell", 0, 0, 100, 100)
+	cell.Epoch = grantCellMaster(cm, "initialCell", handoverPort)
+	player, playerServer := startCellMaster(handoverPort, cell)
+	defer playerServer.Stop()
+	player.PosX, player.PosY = 10, 10
+
+	// the player in the other child is not running and can not be primed
+	addPlayer(cm, "localhost", handoverPort+1, 60, 60)
+	if !cm.PerformSplit("initialCell") {
+		fatalFail(errors.New("the cell was not split"))
+	}
+	cells := listCells(cm, true)
+	if cells["0"].CellMasterPort != handoverPort {
+		fatalFail(errors.New("the primed cell master was not granted its child"))
+	}
+	if cells["3"].CellMasterPort != -1 {
+		fatalFail(errors.New("a cell master that could not be primed was granted its child"))
+	}
+	if cells["initialCell"].Locked || cells["0"].Locked || cells["3"].Locked {
+		fatalFail(errors.New("the handover left cells locked"))
+	}
+}
@@ -0,0 +1,184 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+	"time"
+)
+
+const leaseCellMasterPort = 8920
+
+// grantCellMaster makes the player at the port cell master of the cell and returns the epoch it was granted.
+func grantCellMaster(cm *cellmanager.CellManager, cellId string, port int32) int64 {
+	reply, err := cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: cellId})
+	failIfNotNull(err, "could not request cell master")
+	if reply.Port != port {
+		fatalFail(errors.New(fmt.Sprintf("expected %d to become cell master, got %d", port, reply.Port)))
+	}
+	return listCells(cm, false)[cellId].Epoch
+}
+
+func TestCellMasterEpochsIncrease(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	first := grantCellMaster(&cm, "initialCell", firstUnusedPort)
+
+	// a cell master that was replaced can not unregister its successor
+	_, err = cm.UnregisterCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell", Epoch: first})
+	failIfNotNull(err, "could not unregister cell master")
+	second := grantCellMaster(&cm, "initialCell", firstUnusedPort)
+	if second <= first {
+		fatalFail(errors.New(fmt.Sprintf("epoch %d was granted after epoch %d", second, first)))
+	}
+	_, err = cm.UnregisterCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell", Epoch: first})
+	if err == nil || listCells(&cm, false)["initialCell"].CellMasterPort != firstUnusedPort {
+		fatalFail(errors.New("a cell master with a stale epoch unregistered the current one"))
+	}
+
+	// the epochs go on from where they were after a restart, even if the cell that had the latest one is gone
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	third := grantCellMaster(&cm, "0", firstUnusedPort)
+	cm.PerformMerge("initialCell")
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	if fourth := grantCellMaster(&recovered, "initialCell", firstUnusedPort); fourth != third+1 {
+		fatalFail(errors.New(fmt.Sprintf("expected epoch %d after the restart, got %d", third+1, fourth)))
+	}
+}
+
+func TestStaleEpochReportsAreRejected(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	stale := grantCellMaster(&cm, "initialCell", firstUnusedPort)
+	_, err := cm.UnregisterCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not unregister cell master")
+	epoch := grantCellMaster(&cm, "initialCell", firstUnusedPort)
+
+	report := generated.CellLoadReport{CellId: "initialCell", Ip: "localhost", Port: firstUnusedPort, Load: 1, Epoch: stale}
+	if _, err := cm.ReportCellLoad(context.Background(), &report); err == nil {
+		fatalFail(errors.New("a load report with a stale epoch was accepted"))
+	}
+	report.Epoch = epoch
+	_, err = cm.ReportCellLoad(context.Background(), &report)
+	failIfNotNull(err, "could not report load")
+
+	positions := generated.PositionReport{
+		Positions: []*generated.PlayerPosition{{Ip: "localhost", Port: firstUnusedPort, PosX: 20, PosY: 20}},
+		CellId:    "initialCell",
+		Epoch:     stale,
+	}
+	if _, err := cm.ReportPositions(context.Background(), &positions); err == nil {
+		fatalFail(errors.New("a position report with a stale epoch was accepted"))
+	}
+	if location := locatePlayer(&cm, firstUnusedPort); location.PosX != 10 {
+		fatalFail(errors.New("a stale position report moved a player"))
+	}
+	positions.Epoch = epoch
+	_, err = cm.ReportPositions(context.Background(), &positions)
+	failIfNotNull(err, "could not report positions")
+	if location := locatePlayer(&cm, firstUnusedPort); location.PosX != 20 {
+		fatalFail(errors.New("the position report of the cell master was not stored"))
+	}
+}
+
+func TestCellMasterLosesLeaseOnSplit(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", watchPort), grpc.WithInsecure())
+	failIfNotNull(err, "could not connect to the cell manager")
+	defer conn.Close()
+	client := generated.NewCellManagerClient(conn)
+
+	_, err = cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	addPlayer(cm, "localhost", leaseCellMasterPort, 10, 10)
+	cell := claimedCell("initialCell", 0, 0, 100, 100)
+	cell.Epoch = grantCellMaster(cm, "initialCell", leaseCellMasterPort)
+	player, playerServer := startCellMaster(leaseCellMasterPort, cell)
+	defer playerServer.Stop()
+
+	failIfNotNull(player.RenewCellMastership(&client), "could not renew cell mastership")
+	if _, owned := player.OwnedCell(); !owned {
+		fatalFail(errors.New("the cell master lost a renewed lease"))
+	}
+
+	// the split is not told to the cell master, it finds out when renewing
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	if player.RenewCellMastership(&client) == nil {
+		fatalFail(errors.New("the lease on a split cell was renewed"))
+	}
+	if _, owned := player.OwnedCell(); owned {
+		fatalFail(errors.New("the cell master kept a cell it lost the lease on"))
+	}
+}
+
+func TestCellMasterStopsWhenLeaseRunsOut(t *testing.T) {
+	player := objects.NewPlayer(1, 1)
+	_, err := player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells:       []*objects2.Cell{{CellId: "cellId", Width: 10, Height: 10, Epoch: 2}},
+		LeaseMillis: 20,
+	})
+	failIfNotNull(err, "could not receive cell mastership")
+	if _, owned := player.OwnedCell(); !owned {
+		fatalFail(errors.New("the cell master does not own a granted cell"))
+	}
+
+	// grants and revocations from before the current grant are ignored
+	_, err = player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells: []*objects2.Cell{{CellId: "cellId", Width: 20, Height: 20, Epoch: 1}},
+	})
+	if err == nil {
+		fatalFail(errors.New("a stale grant was accepted"))
+	}
+	_, err = player.NotifyOfSplitCell(context.Background(), &objects2.Cell{CellId: "cellId", Epoch: 1})
+	failIfNotNull(err, "could not notify of split cell")
+	if cell, owned := player.OwnedCell(); !owned || cell.Width != 10 {
+		fatalFail(errors.New("a stale message changed the owned cell"))
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if _, owned := player.OwnedCell(); owned {
+		fatalFail(errors.New("the cell master owns a cell whose lease has run out"))
+	}
+	mutated := &objects2.MultipleObjects{Objects: []*objects2.SingleObject{createSingleObject("key", "value", "id", "cellId")}}
+	if _, err := player.BroadcastMutatedObjects(context.Background(), mutated); err == nil {
+		fatalFail(errors.New("a cell master broadcast after its lease ran out"))
+	}
+}
+
+func TestSubscribersRejectStaleEpochs(t *testing.T) {
+	player := objects.NewPlayer(1, 1)
+	receive := func(cellId string, epoch int64) error {
+		_, err := player.ReceiveMutatedObjects(context.Background(), &objects2.MultipleObjects{
+			Objects: []*objects2.SingleObject{createSingleObject("key", fmt.Sprint(epoch), "id", cellId)},
+			Epoch:   epoch,
+		})
+		return err
+	}
+
+	failIfNotNull(receive("cellId", 2), "could not receive objects")
+	if receive("cellId", 1) == nil {
+		fatalFail(errors.New("objects from a replaced cell master were accepted"))
+	}
+	failIfNotNull(receive("cellId", 3), "could not receive objects from a new cell master")
+	failIfNotNull(receive("otherCell", 1), "could not receive objects of another cell")
+	if received := player.TakeMutatedObjects(); len(received) != 3 {
+		fatalFail(errors.New(fmt.Sprintf("expected 3 objects, got %d", len(received))))
+	}
+}
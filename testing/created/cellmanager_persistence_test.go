@@ -0,0 +1,153 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"github.com/golang/protobuf/proto"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createPersistentCellManager(dataDir string, snapshotInterval int) cellmanager.CellManager {
+	cm := cellmanager.NewCellManager()
+	err := cm.EnablePersistence(dataDir, snapshotInterval)
+	failIfNotNull(err, "could not enable persistence")
+	return cm
+}
+
+func createDataDir() string {
+	dataDir, err := ioutil.TempDir("", "cellManagerData")
+	failIfNotNull(err, "could not create data directory")
+	return dataDir
+}
+
+// changeWorld performs every kind of change the CellManager logs.
+func changeWorld(cm *cellmanager.CellManager) {
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	addPlayer(cm, "localhost", firstUnusedPort, 10, 10)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not select cell master")
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(cm, "localhost", firstUnusedPort+1, 10, 10)
+	addPlayer(cm, "localhost", firstUnusedPort+2, 10, 60)
+	addPlayer(cm, "localhost", firstUnusedPort+2, 20, 70)
+	addPlayer(cm, "localhost", firstUnusedPort+3, 60, 60)
+	addPlayer(cm, "localhost", firstUnusedPort+4, 60, 60)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: cellIdAt(cm, 50, 50)})
+	failIfNotNull(err, "could not select cell master")
+	_, err = cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{Ip: "localhost", Port: firstUnusedPort + 4, CellId: cellIdAt(cm, 50, 50)})
+	failIfNotNull(err, "could not remove player")
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: cellIdAt(cm, 0, 50)})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: cellIdAt(cm, 0, 50), NewWidth: 30, NewHeight: 20})
+	failIfNotNull(err, "could not change cell size")
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: cellIdAt(cm, 50, 0)})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(cm, "localhost", firstUnusedPort+5, 60, 10)
+	cm.PerformMerge(cellIdAt(cm, 50, 0))
+
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{cellIdAt(cm, 0, 0)}, SenderCellId: "locker"})
+	failIfNotNull(err, "could not lock cell")
+	_, err = cm.UnlockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{cellIdAt(cm, 0, 0)}, SenderCellId: "locker"})
+	failIfNotNull(err, "could not unlock cell")
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{cellIdAt(cm, 50, 50)}, SenderCellId: "locker"})
+	failIfNotNull(err, "could not lock cell")
+	_, err = cm.UnregisterCellMaster(context.Background(), &generated.CellMasterRequest{CellId: cellIdAt(cm, 50, 50)})
+	failIfNotNull(err, "could not unregister cell master")
+}
+
+func expectSameState(expected *cellmanager.CellManager, actual *cellmanager.CellManager) {
+	expectedCells := listCells(expected, true)
+	actualCells := listCells(actual, true)
+	if len(expectedCells) != len(actualCells) {
+		fatalFail(errors.New(fmt.Sprintf("expected %d cells, got %d", len(expectedCells), len(actualCells))))
+	}
+
+	for cellId, expectedCell := range expectedCells {
+		if !proto.Equal(expectedCell, actualCells[cellId]) {
+			fatalFail(errors.New(fmt.Sprintf("cell %s was %v, expected %v", cellId, actualCells[cellId], expectedCell)))
+		}
+
+		expectedPlayers, err := expected.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cellId})
+		failIfNotNull(err, "could not list players")
+		actualPlayers, err := actual.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cellId})
+		failIfNotNull(err, "could not list players")
+		if !proto.Equal(expectedPlayers, actualPlayers) {
+			fatalFail(errors.New(fmt.Sprintf("players in cell %s were %v, expected %v", cellId, actualPlayers, expectedPlayers)))
+		}
+	}
+}
+
+func TestRecoverFromLog(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	changeWorld(&cm)
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&cm, &recovered)
+}
+
+func TestRecoverFromSnapshotAndLog(t *testing.T) {
+	for _, snapshotInterval := range []int{1, 3, 7} {
+		dataDir := createDataDir()
+
+		cm := createPersistentCellManager(dataDir, snapshotInterval)
+		changeWorld(&cm)
+
+		recovered := createPersistentCellManager(dataDir, snapshotInterval)
+		expectSameState(&cm, &recovered)
+		os.RemoveAll(dataDir)
+	}
+}
+
+func TestRecoverAfterClose(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	changeWorld(&cm)
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+
+	logData, err := ioutil.ReadFile(filepath.Join(dataDir, "wal.log"))
+	failIfNotNull(err, "could not read log")
+	if len(logData) != 0 {
+		fatalFail(errors.New("closing did not replace the log with a snapshot"))
+	}
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&cm, &recovered)
+}
+
+func TestRecoverIgnoresTornLastEntry(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	changeWorld(&cm)
+
+	logFile, err := os.OpenFile(filepath.Join(dataDir, "wal.log"), os.O_WRONLY|os.O_APPEND, 0644)
+	failIfNotNull(err, "could not open log")
+	_, err = logFile.WriteString(`{"Index":1000,"Type":"addPl`)
+	failIfNotNull(err, "could not write torn entry")
+	logFile.Close()
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&cm, &recovered)
+
+	// changes made after the recovery must survive the next one as well
+	addPlayer(&recovered, "localhost", firstUnusedPort+6, 10, 10)
+	recoveredAgain := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&recovered, &recoveredAgain)
+}
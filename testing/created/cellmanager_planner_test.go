@@ -0,0 +1,185 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// scriptedPolicy splits and merges exactly the cells it is given.
+type scriptedPolicy struct {
+	splits map[string]bool
+	merges map[string]bool
+}
+
+func (policy scriptedPolicy) ShouldSplit(cell cellmanager.CellStats) bool {
+	return policy.splits[cell.CellId]
+}
+
+func (policy scriptedPolicy) ShouldMerge(cell cellmanager.CellStats) bool {
+	return policy.merges[cell.CellId]
+}
+
+// planTopology returns the cells the cell manager plans to merge and split, the splits are sorted.
+func planTopology(cm *cellmanager.CellManager) ([]string, []string) {
+	plan, err := cm.PlanTopology(context.Background(), &generated.PlanTopologyRequest{})
+	failIfNotNull(err, "could not plan topology")
+	merges := make([]string, 0)
+	splits := make([]string, 0)
+	for _, change := range plan.Changes {
+		if change.Type == generated.TopologyEventType_MERGE {
+			merges = append(merges, change.CellId)
+		} else {
+			splits = append(splits, change.CellId)
+		}
+	}
+	sort.Strings(splits)
+	return merges, splits
+}
+
+func TestPlanTopologyHasNoConflictingChanges(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "0"})
+	failIfNotNull(err, "could not divide cell")
+
+	cm.SetMinCellLifetime(0)
+	cm.SetSplitMergePolicy(scriptedPolicy{
+		splits: map[string]bool{"00": true, "01": true, "1": true, "2": true},
+		merges: map[string]bool{"0": true},
+	})
+	merges, splits := planTopology(&cm)
+	if len(merges) != 1 || merges[0] != "0" {
+		fatalFail(errors.New(fmt.Sprintf("expected a merge of 0, got %v", merges)))
+	}
+	if len(splits) != 2 || splits[0] != "1" || splits[1] != "2" {
+		fatalFail(errors.New(fmt.Sprintf("expected splits of 1 and 2 only, got %v", splits)))
+	}
+
+	// a merge of the root leaves nothing else to do
+	cm.SetSplitMergePolicy(scriptedPolicy{
+		splits: map[string]bool{"1": true},
+		merges: map[string]bool{"initialCell": true, "0": true},
+	})
+	merges, splits = planTopology(&cm)
+	if len(merges) != 1 || merges[0] != "initialCell" || len(splits) != 0 {
+		fatalFail(errors.New("changes below a merged node were planned"))
+	}
+}
+
+func TestPlanTopologyExplainsWithoutChanging(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 20, 20)
+	addPlayer(&cm, "localhost", firstUnusedPort+2, 30, 30)
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 3, MergeAt: 0, MinAge: time.Hour})
+	before := replicaState(&cm)
+
+	plan, err := cm.PlanTopology(context.Background(), &generated.PlanTopologyRequest{})
+	failIfNotNull(err, "could not plan topology")
+	if len(plan.Changes) != 1 {
+		fatalFail(errors.New(fmt.Sprintf("expected one planned change, got %v", plan.Changes)))
+	}
+	change := plan.Changes[0]
+	if change.Type != generated.TopologyEventType_SPLIT || change.CellId != "0" || change.PlayerCount != 3 {
+		fatalFail(errors.New("the crowded quadrant is not planned to be split"))
+	}
+	if change.Reason != "3 players, splitting at 3" || !strings.Contains(plan.Policy, "SplitAt:3") {
+		fatalFail(errors.New("unexpected reason " + change.Reason + " under policy " + plan.Policy))
+	}
+	if !sameState(before, replicaState(&cm)) {
+		fatalFail(errors.New("planning changed the tree"))
+	}
+
+	// the root is young, so it is not merged even though the policy would
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 1000, MergeAt: 10, MinAge: time.Hour})
+	if merges, splits := planTopology(&cm); len(merges) != 0 || len(splits) != 0 {
+		fatalFail(errors.New("changes were planned for a young world"))
+	}
+	cm.SetMinCellLifetime(0)
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 1000, MergeAt: 10, MinAge: -time.Second})
+	plan, err = cm.PlanTopology(context.Background(), &generated.PlanTopologyRequest{})
+	failIfNotNull(err, "could not plan topology")
+	if len(plan.Changes) != 1 || !strings.HasPrefix(plan.Changes[0].Reason, "3 players, merging at 10") {
+		fatalFail(errors.New(fmt.Sprintf("expected a merge of the root, got %v", plan.Changes)))
+	}
+	if !sameState(before, replicaState(&cm)) {
+		fatalFail(errors.New("planning changed the tree"))
+	}
+}
+
+func TestUpdateTopologyConvergesOnFlashCrowd(t *testing.T) {
+	cm := createWorld(128, 128)
+	for index := int32(0); index < 64; index++ {
+		addPlayer(&cm, "localhost", firstUnusedPort+index, int64(index%8)*16+3, int64(index/8)*16+3)
+	}
+	setPolicy(&cm, "players:2:0:1h")
+
+	// every round splits every crowded leaf, so each round goes one level deeper
+	for round := 1; round <= 3; round++ {
+		cm.UpdateTopology()
+		expectLeafCount(&cm, 1<<(2*uint(round)))
+	}
+	if _, splits := planTopology(&cm); len(splits) != 0 {
+		fatalFail(errors.New("the crowd was not spread over the leaves"))
+	}
+	for _, cell := range listCells(&cm, false) {
+		if cell.PlayerCount != 1 || cell.Locked {
+			fatalFail(errors.New("leaf " + cell.CellId + " was left crowded or locked"))
+		}
+	}
+	expectExactTiling(&cm)
+}
+
+func TestUpdateTopologyCoolsDownRegions(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+	cm.SetTopologyCooldown(time.Hour)
+	setPolicy(&cm, "players:2:0:1h")
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+
+	// a policy that merges everything may not undo the split while the region cools down
+	cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: 1000, MergeAt: 999, MinAge: -time.Second})
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+
+	cm.SetTopologyCooldown(0)
+	cm.SetMinCellLifetime(0)
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 1)
+}
+
+func TestUpdateTopologySkipsLockedCells(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{"1"}, SenderCellId: "tester"})
+	failIfNotNull(err, "could not lock cells")
+
+	cm.SetSplitMergePolicy(scriptedPolicy{splits: map[string]bool{"0": true, "1": true}})
+	cm.UpdateTopology()
+	cells := listCells(&cm, true)
+	if cells["0"].IsLeaf || !cells["1"].IsLeaf {
+		fatalFail(errors.New("the locked cell was split or the unlocked one was not"))
+	}
+	if cells["1"].Lockee != "tester" || cells["00"].Locked {
+		fatalFail(errors.New("the planner changed the locks of the cells"))
+	}
+
+	// a node with a locked leaf is not merged
+	cm.SetSplitMergePolicy(scriptedPolicy{merges: map[string]bool{"initialCell": true}})
+	cm.SetTopologyCooldown(0)
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 7)
+}
@@ -0,0 +1,111 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"math"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+// expectPlayerCounts checks the cached player count of every node against the players registered below it.
+func expectPlayerCounts(cm *cellmanager.CellManager) {
+	for cellId, cell := range listCells(cm, true) {
+		players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cellId})
+		failIfNotNull(err, "could not list players")
+		if int(cell.PlayerCount) != len(players.Port) {
+			fatalFail(errors.New(fmt.Sprintf("cell %s counts %d players but holds %d", cellId, cell.PlayerCount, len(players.Port))))
+		}
+	}
+}
+
+func TestPlayerCountsFollowChanges(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	for index := int32(0); index < 6; index++ {
+		addPlayer(&cm, "localhost", firstUnusedPort+index, int64(10+index*15), int64(10+index*15))
+	}
+	expectPlayerCounts(&cm)
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	topLeft := cellIdAt(&cm, 0, 0)
+	divideInHalves(&cm, topLeft)
+	expectPlayerCounts(&cm)
+
+	// registering again only moves the player
+	addPlayer(&cm, "localhost", firstUnusedPort, 12, 12)
+	_, err = cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{
+		Ip: "localhost", Port: firstUnusedPort + 5, CellId: cellIdAt(&cm, 50, 50),
+	})
+	failIfNotNull(err, "could not remove player")
+	_, err = cm.ReportPositions(context.Background(), &generated.PositionReport{Positions: []*generated.PlayerPosition{
+		{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 90, PosY: 10},
+	}})
+	failIfNotNull(err, "could not report positions")
+	expectPlayerCounts(&cm)
+	if cells := listCells(&cm, true); cells["initialCell"].PlayerCount != 5 {
+		fatalFail(errors.New("the root does not count every player"))
+	}
+
+	cm.PerformMerge(topLeft)
+	expectPlayerCounts(&cm)
+	cm.PerformMerge("initialCell")
+	expectPlayerCounts(&cm)
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectPlayerCounts(&recovered)
+}
+
+// createPopulatedWorld divides a world into 64 leaves and registers the players at random positions in it.
+func createPopulatedWorld(playerCount int) cellmanager.CellManager {
+	cm := createWorld(1000, 1000)
+	for depth := 0; depth < 3; depth++ {
+		for _, cellId := range leafIds(&cm) {
+			_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: cellId})
+			failIfNotNull(err, "could not divide cell")
+		}
+	}
+
+	random := rand.New(rand.NewSource(1))
+	for index := 0; index < playerCount; index++ {
+		addPlayer(&cm, "localhost", int32(index), random.Int63n(1000), random.Int63n(1000))
+	}
+	return cm
+}
+
+func BenchmarkUpdateTopology(b *testing.B) {
+	for _, playerCount := range []int{1000, 10000, 50000} {
+		cm := createPopulatedWorld(playerCount)
+		// a policy that never acts, so that every iteration only checks the tree
+		cm.SetSplitMergePolicy(cellmanager.PlayerCountPolicy{SplitAt: math.MaxInt32, MergeAt: -1})
+
+		b.Run(fmt.Sprintf("%d players", playerCount), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				cm.UpdateTopology()
+			}
+		})
+	}
+}
+
+func BenchmarkListCells(b *testing.B) {
+	for _, playerCount := range []int{1000, 10000, 50000} {
+		cm := createPopulatedWorld(playerCount)
+
+		b.Run(fmt.Sprintf("%d players", playerCount), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{IncludeInterior: true})
+				failIfNotNull(err, "could not list cells")
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"testing"
+	"time"
+)
+
+const policyCellMasterPort = 8880
+
+func setPolicy(cm *cellmanager.CellManager, spec string) {
+	policy, err := cellmanager.ParseSplitMergePolicy(spec)
+	failIfNotNull(err, "could not parse policy "+spec)
+	cm.SetSplitMergePolicy(policy)
+}
+
+func expectLeafCount(cm *cellmanager.CellManager, count int) {
+	if leaves := len(leafIds(cm)); leaves != count {
+		fatalFail(errors.New(fmt.Sprintf("expected %d leaves, got %d", count, leaves)))
+	}
+}
+
+func TestSplitMergePolicies(t *testing.T) {
+	quietOldCell := cellmanager.CellStats{PlayerCount: 1, Age: time.Minute, MutationsPerSecond: 1, Load: 0.1, Reported: true}
+	busyCell := cellmanager.CellStats{PlayerCount: 20, Age: time.Minute, MutationsPerSecond: 500, Load: 0.9, Reported: true}
+	unreportedCell := cellmanager.CellStats{PlayerCount: 20, Age: time.Minute}
+
+	policies := []cellmanager.SplitMergePolicy{
+		cellmanager.PlayerCountPolicy{SplitAt: 10, MergeAt: 2, MinAge: time.Second * 30},
+		cellmanager.MutationRatePolicy{SplitAbove: 100, MergeBelow: 10, MinAge: time.Second * 30},
+		cellmanager.CellMasterLoadPolicy{SplitAbove: 0.8, MergeBelow: 0.2, MinAge: time.Second * 30},
+	}
+	for index, policy := range policies {
+		if !policy.ShouldSplit(busyCell) || policy.ShouldSplit(quietOldCell) {
+			fatalFail(errors.New(fmt.Sprintf("policy %d split the wrong cell", index)))
+		}
+		if !policy.ShouldMerge(quietOldCell) || policy.ShouldMerge(busyCell) {
+			fatalFail(errors.New(fmt.Sprintf("policy %d merged the wrong cell", index)))
+		}
+
+		youngCell := quietOldCell
+		youngCell.Age = time.Second
+		if policy.ShouldMerge(youngCell) {
+			fatalFail(errors.New(fmt.Sprintf("policy %d merged a young cell", index)))
+		}
+	}
+
+	// only the player count is known without reports
+	if !policies[0].ShouldSplit(unreportedCell) || policies[1].ShouldSplit(unreportedCell) || policies[2].ShouldSplit(unreportedCell) {
+		fatalFail(errors.New("a policy did not handle an unreported cell"))
+	}
+}
+
+func TestParseSplitMergePolicy(t *testing.T) {
+	policy, err := cellmanager.ParseSplitMergePolicy("mutations:200:20:30s")
+	failIfNotNull(err, "could not parse policy")
+	if policy != (cellmanager.MutationRatePolicy{SplitAbove: 200, MergeBelow: 20, MinAge: time.Second * 30}) {
+		fatalFail(errors.New("parsed the wrong policy"))
+	}
+
+	for _, spec := range []string{"", "players:8:1", "crowd:8:1:30s", "load:high:0.2:30s", "players:1:8:30s", "load:0.8:0.2:soon"} {
+		if _, err := cellmanager.ParseSplitMergePolicy(spec); err == nil {
+			fatalFail(errors.New("parsed invalid policy " + spec))
+		}
+	}
+}
+
+func TestUpdateTopologyUsesPolicy(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 60)
+
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 1)
+
+	setPolicy(&cm, "players:2:0:1h")
+	cm.UpdateTopology()
+	expectLeafCount(&cm, 4)
+}
+
+func TestReportCellLoadOnlyFromCellMaster(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 10, 10)
+	cellMaster, err := cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not request cell master")
+
+	report := generated.CellLoadReport{CellId: "initialCell", Ip: "localhost", Port: firstUnusedPort + 1, Load: 1}
+	if cellMaster.Port == report.Port {
+		report.Port = firstUnusedPort
+	}
+	if _, err := cm.ReportCellLoad(context.Background(), &report); err == nil {
+		fatalFail(errors.New("a player that is not the cell master reported the load"))
+	}
+
+	report.Port = cellMaster.Port
+	reply, err := cm.ReportCellLoad(context.Background(), &report)
+	failIfNotNull(err, "could not report load")
+	if !reply.Accepted {
+		fatalFail(errors.New("the load report of the cell master was not accepted"))
+	}
+
+	report.Load = -1
+	if _, err := cm.ReportCellLoad(context.Background(), &report); err == nil {
+		fatalFail(errors.New("a negative load was accepted"))
+	}
+}
+
+func TestCellMasterLoadReportsSplitCells(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", watchPort), grpc.WithInsecure())
+	failIfNotNull(err, "could not connect to the cell manager")
+	defer conn.Close()
+	client := generated.NewCellManagerClient(conn)
+
+	_, err = cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	addPlayer(cm, "localhost", policyCellMasterPort, 10, 10)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not request cell master")
+	player, playerServer := startCellMaster(policyCellMasterPort, claimedCell("initialCell", 0, 0, 100, 100))
+	defer playerServer.Stop()
+
+	setPolicy(cm, "mutations:10:1:1h")
+	cm.UpdateTopology()
+	expectLeafCount(cm, 1)
+
+	// the first report only starts the measurement
+	failIfNotNull(player.ReportCellLoad(&client), "could not report load")
+	for i := 0; i < 100; i++ {
+		player.AppendMutatingObject(&objects2.SingleObject{ObjectId: fmt.Sprint(i)})
+	}
+	player.TakeMutatingObjects()
+	failIfNotNull(player.ReportCellLoad(&client), "could not report load")
+
+	cm.UpdateTopology()
+	expectLeafCount(cm, 4)
+	// the cell master is handed the child it is in
+	if cell, owned := player.OwnedCell(); !owned || cell.CellId != "0" {
+		fatalFail(errors.New("the cell master kept its split cell"))
+	}
+}
@@ -0,0 +1,177 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+func locatePlayer(cm *cellmanager.CellManager, port int32) *generated.PlayerLocationReply {
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: port})
+	failIfNotNull(err, "could not locate player")
+	if !location.Found {
+		fatalFail(errors.New("player is not registered"))
+	}
+	return location
+}
+
+func TestReportPositions(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 20, 20)
+	_, err = cm.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{
+		Ip: "localhost", Port: firstUnusedPort + 2, PosX: 30, PosY: 30, ObjectId: "walker",
+	})
+	failIfNotNull(err, "could not add player to cell")
+
+	reply, err := cm.ReportPositions(context.Background(), &generated.PositionReport{Positions: []*generated.PlayerPosition{
+		{Ip: "localhost", Port: firstUnusedPort, PosX: 15, PosY: 12},
+		{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 20, PosY: 20},
+		{ObjectId: "walker", PosX: 70, PosY: 80},
+		{Ip: "localhost", Port: firstUnusedPort + 3, PosX: 5, PosY: 5},
+		{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 500, PosY: 20},
+	}})
+	failIfNotNull(err, "could not report positions")
+	if reply.Updated != 2 || reply.Moved != 1 || reply.Unknown != 2 {
+		fatalFail(errors.New(fmt.Sprintf("expected 2 updated, 1 moved and 2 unknown, got %v", reply)))
+	}
+
+	location := locatePlayer(&cm, firstUnusedPort)
+	if location.PosX != 15 || location.PosY != 12 || location.CellId != cellIdAt(&cm, 0, 0) {
+		fatalFail(errors.New("the position of a player within its cell was not updated"))
+	}
+	location = locatePlayer(&cm, firstUnusedPort+2)
+	if location.PosX != 70 || location.PosY != 80 || location.CellId != cellIdAt(&cm, 50, 50) {
+		fatalFail(errors.New("a player that left its cell was not moved to the new one"))
+	}
+
+	cells := listCells(&cm, false)
+	if cells[cellIdAt(&cm, 0, 0)].PlayerCount != 2 || cells[cellIdAt(&cm, 50, 50)].PlayerCount != 1 {
+		fatalFail(errors.New("the moved player is counted in the wrong cell"))
+	}
+	players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cellIdAt(&cm, 50, 50)})
+	failIfNotNull(err, "could not list players")
+	if len(players.PosX) != 1 || players.PosX[0] != 70 || players.PosY[0] != 80 {
+		fatalFail(errors.New("the players of a cell are listed without their positions"))
+	}
+}
+
+func TestLocatePlayerFollowsTopologyChanges(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 60, 60)
+
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	if locatePlayer(&cm, firstUnusedPort).CellId != cellIdAt(&cm, 50, 50) {
+		fatalFail(errors.New("a player was not located in its cell after a split"))
+	}
+
+	cm.PerformMerge("initialCell")
+	if locatePlayer(&cm, firstUnusedPort).CellId != "initialCell" {
+		fatalFail(errors.New("a player was not located in its cell after a merge"))
+	}
+
+	_, err = cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{Ip: "localhost", Port: firstUnusedPort, CellId: "initialCell"})
+	failIfNotNull(err, "could not remove player")
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort})
+	failIfNotNull(err, "could not locate player")
+	if location.Found {
+		fatalFail(errors.New("a player that left was located"))
+	}
+}
+
+func TestReportPositionsByObjectId(t *testing.T) {
+	cm := createWorld(100, 100)
+	for index := int32(0); index < 3; index++ {
+		_, err := cm.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{
+			Ip: "localhost", Port: firstUnusedPort + index, PosX: 10, PosY: 10, ObjectId: "walker" + fmt.Sprint(index),
+		})
+		failIfNotNull(err, "could not add player to cell")
+	}
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.PlayerLeftCell(context.Background(), &generated.PlayerInCellRequest{Ip: "localhost", Port: firstUnusedPort + 2, CellId: cellIdAt(&cm, 0, 0)})
+	failIfNotNull(err, "could not remove player")
+
+	// cell masters only know the object ids of their subscribers
+	reply, err := cm.ReportPositions(context.Background(), &generated.PositionReport{Positions: []*generated.PlayerPosition{
+		{ObjectId: "walker0", PosX: 80, PosY: 20},
+		{ObjectId: "walker1", PosX: 15, PosY: 15},
+		{ObjectId: "walker2", PosX: 60, PosY: 60},
+	}})
+	failIfNotNull(err, "could not report positions")
+	if reply.Updated != 2 || reply.Moved != 1 || reply.Unknown != 1 {
+		fatalFail(errors.New(fmt.Sprintf("expected 2 updated, 1 moved and 1 unknown, got %v", reply)))
+	}
+
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{ObjectId: "walker0"})
+	failIfNotNull(err, "could not locate player")
+	if !location.Found || location.CellId != cellIdAt(&cm, 50, 0) || location.PosX != 80 {
+		fatalFail(errors.New("a player reported by its object id was not moved to its new cell"))
+	}
+	if locatePlayer(&cm, firstUnusedPort).CellId != cellIdAt(&cm, 50, 0) {
+		fatalFail(errors.New("a player reported by its object id is not found by its address in its new cell"))
+	}
+}
+
+func TestRecoverReportedPositionsFromLog(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	divideInHalves(&cm, "initialCell")
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	_, err = cm.ReportPositions(context.Background(), &generated.PositionReport{Positions: []*generated.PlayerPosition{
+		{Ip: "localhost", Port: firstUnusedPort, PosX: 90, PosY: 10},
+	}})
+	failIfNotNull(err, "could not report positions")
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&cm, &recovered)
+	if location := locatePlayer(&recovered, firstUnusedPort); location.PosX != 90 {
+		fatalFail(errors.New("the reported position was not recovered"))
+	}
+}
+
+func TestPlayerReportsPositionsInBatches(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", watchPort), grpc.WithInsecure())
+	failIfNotNull(err, "could not connect to the cell manager")
+	defer conn.Close()
+	client := generated.NewCellManagerClient(conn)
+
+	_, err = cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	for port := int32(firstUnusedPort); port < firstUnusedPort+2; port++ {
+		_, err = cm.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{
+			Ip: "localhost", Port: port, PosX: 10, PosY: 10, ObjectId: objects.ToAddress("localhost", port),
+		})
+		failIfNotNull(err, "could not add player to cell")
+	}
+
+	player := objects.NewPlayer(8, 3)
+	failIfNotNull(player.ReportPositions(&client), "could not send an empty report")
+	player.RecordPosition(objects.ToAddress("localhost", firstUnusedPort), 40, 40)
+	player.RecordPosition(objects.ToAddress("localhost", firstUnusedPort), 41, 42)
+	player.RecordPosition(objects.ToAddress("localhost", firstUnusedPort+1), 60, 60)
+	failIfNotNull(player.ReportPositions(&client), "could not report positions")
+
+	if location := locatePlayer(cm, firstUnusedPort); location.PosX != 41 || location.PosY != 42 {
+		fatalFail(errors.New("the latest recorded position was not reported"))
+	}
+	if location := locatePlayer(cm, firstUnusedPort+1); location.PosX != 60 || location.PosY != 60 {
+		fatalFail(errors.New("a recorded position was not reported"))
+	}
+}
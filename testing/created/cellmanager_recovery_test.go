@@ -0,0 +1,201 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+	"testing"
+)
+
+const firstRecoveryPort = 8870
+
+// startCellMaster serves a player that is cell master for the given cell, with itself and the given ports subscribed.
+func startCellMaster(port int, cell *objects.Cell, subscriberPorts ...int) (*objects.Player, *grpc.Server) {
+	lis, err := net.Listen("tcp", ":"+fmt.Sprint(port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	playerServer := grpc.NewServer()
+	player := objects.NewPlayer(100, 1)
+	player.Ip = "localhost"
+	player.Port = port
+	player.ObjectId = "player" + fmt.Sprint(port)
+	objects2.RegisterPlayerServer(playerServer, player)
+	go func() {
+		if err := playerServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			log.Fatalf("failed to serve %v", err)
+		}
+	}()
+
+	if cell != nil {
+		player.Cells = cell
+		for _, subscriberPort := range append([]int{port}, subscriberPorts...) {
+			_, err := player.SubscribePlayer(context.Background(), &objects2.PlayerInfo{
+				Ip: "localhost", Port: int32(subscriberPort), PosX: cell.PosX, PosY: cell.PosY,
+				ObjectId: "player" + fmt.Sprint(subscriberPort),
+			})
+			failIfNotNull(err, "could not subscribe player")
+		}
+	}
+	return player, playerServer
+}
+
+func claimedCell(cellId string, posX int64, posY int64, width int64, height int64) *objects.Cell {
+	cell := objects.NewCell(cellId)
+	cell.PosX, cell.PosY, cell.Width, cell.Height = posX, posY, width, height
+	return &cell
+}
+
+func peerAddresses(ports ...int) []string {
+	addresses := make([]string, len(ports))
+	for index, port := range ports {
+		addresses[index] = objects.ToAddress("localhost", int32(port))
+	}
+	return addresses
+}
+
+func expectLeafArea(cm *cellmanager.CellManager, area int64) {
+	leafArea := int64(0)
+	for _, cell := range listCells(cm, false) {
+		leafArea += cell.Width * cell.Height
+	}
+	if leafArea != area {
+		fatalFail(errors.New(fmt.Sprintf("leaves cover %d, expected %d", leafArea, area)))
+	}
+}
+
+func expectClaim(cells map[string]*generated.CellInfo, cellId string, posX int64, posY int64, width int64, height int64, cellMasterPort int32) {
+	cell, ok := cells[cellId]
+	if !ok || !cell.IsLeaf {
+		fatalFail(errors.New("claimed cell " + cellId + " was not recovered as a leaf"))
+	}
+	expectCell(cell, posX, posY, width, height)
+	if cell.CellMasterIp != "localhost" || cell.CellMasterPort != cellMasterPort {
+		fatalFail(errors.New("cell " + cellId + " recovered with the wrong cell master"))
+	}
+}
+
+func TestRecoverFromPeers(t *testing.T) {
+	topHalf, topHalfServer := startCellMaster(firstRecoveryPort, claimedCell("2", 0, 50, 50, 50), firstUnusedPort)
+	defer topHalfServer.Stop()
+	smallCorner, smallCornerServer := startCellMaster(firstRecoveryPort+1, claimedCell("00", 0, 0, 25, 25))
+	defer smallCornerServer.Stop()
+	_, innerServer := startCellMaster(firstRecoveryPort+2, claimedCell("03", 25, 25, 25, 25))
+	defer innerServer.Stop()
+	stale, staleServer := startCellMaster(firstRecoveryPort+3, claimedCell("0", 0, 0, 50, 50), firstUnusedPort+1)
+	defer staleServer.Stop()
+	_, resizedServer := startCellMaster(firstRecoveryPort+4, claimedCell("30", 50, 50, 30, 20))
+	defer resizedServer.Stop()
+	_, idleServer := startCellMaster(firstRecoveryPort+5, nil)
+	defer idleServer.Stop()
+
+	// subscribers move after subscribing, by subscribing again or by a broadcast of their player object
+	_, err := topHalf.SubscribePlayer(context.Background(), &objects2.PlayerInfo{
+		Ip: "localhost", Port: int32(firstUnusedPort), PosX: 30, PosY: 80, ObjectId: "player" + fmt.Sprint(firstUnusedPort),
+	})
+	failIfNotNull(err, "could not subscribe player")
+	broadcastAt(smallCorner, "00", "player"+fmt.Sprint(firstRecoveryPort+1), 20, 10)
+
+	cm := cellmanager.NewCellManager()
+	accepted, err := cm.RecoverFromPeers(peerAddresses(firstRecoveryPort, firstRecoveryPort+1, firstRecoveryPort+2,
+		firstRecoveryPort+3, firstRecoveryPort+4, firstRecoveryPort+5, firstUnusedPort), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+	if accepted != 4 {
+		fatalFail(errors.New(fmt.Sprintf("expected 4 accepted claims, got %d", accepted)))
+	}
+
+	cells := listCells(&cm, true)
+	expectClaim(cells, "2", 0, 50, 50, 50, firstRecoveryPort)
+	expectClaim(cells, "00", 0, 0, 25, 25, firstRecoveryPort+1)
+	expectClaim(cells, "03", 25, 25, 25, 25, firstRecoveryPort+2)
+	expectClaim(cells, "30", 50, 50, 30, 20, firstRecoveryPort+4)
+	expectLeafArea(&cm, 100*100)
+	if _, ok := cells["01"]; !ok {
+		fatalFail(errors.New("the unclaimed quadrants were not named by their path"))
+	}
+
+	players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: "2"})
+	failIfNotNull(err, "could not list players")
+	if len(players.Port) != 2 {
+		fatalFail(errors.New("subscribers of a recovered cell were not added as players"))
+	}
+	for _, expected := range []*generated.PlayerPosition{
+		{ObjectId: "player" + fmt.Sprint(firstUnusedPort), PosX: 30, PosY: 80},
+		{ObjectId: "player" + fmt.Sprint(firstRecoveryPort), PosX: 0, PosY: 50},
+		{ObjectId: "player" + fmt.Sprint(firstRecoveryPort+1), PosX: 20, PosY: 10},
+	} {
+		location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{ObjectId: expected.ObjectId})
+		failIfNotNull(err, "could not locate player")
+		if !location.Found || location.PosX != expected.PosX || location.PosY != expected.PosY {
+			fatalFail(errors.New(fmt.Sprintf("player %s was not recovered at (%d, %d)", expected.ObjectId, expected.PosX, expected.PosY)))
+		}
+	}
+
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{ObjectId: "player" + fmt.Sprint(firstRecoveryPort+3)})
+	failIfNotNull(err, "could not locate player")
+	if location.Found {
+		fatalFail(errors.New("subscribers of a rejected claim were added"))
+	}
+
+	if _, owned := stale.OwnedCell(); owned {
+		fatalFail(errors.New("the cell master of a rejected claim still owns its cell"))
+	}
+	if _, owned := topHalf.OwnedCell(); !owned {
+		fatalFail(errors.New("an accepted cell master lost its cell"))
+	}
+	if _, owned := smallCorner.OwnedCell(); !owned {
+		fatalFail(errors.New("an accepted cell master lost its cell"))
+	}
+}
+
+func TestRecoverFromPeersIsDeterministic(t *testing.T) {
+	ports := []int{firstRecoveryPort + 10, firstRecoveryPort + 11, firstRecoveryPort + 12}
+	_, firstServer := startCellMaster(ports[0], claimedCell("1", 50, 0, 50, 50))
+	defer firstServer.Stop()
+	_, secondServer := startCellMaster(ports[1], claimedCell("23", 25, 75, 25, 25))
+	defer secondServer.Stop()
+	_, thirdServer := startCellMaster(ports[2], claimedCell("23", 25, 75, 25, 25))
+	defer thirdServer.Stop()
+
+	cm := cellmanager.NewCellManager()
+	_, err := cm.RecoverFromPeers(peerAddresses(ports[0], ports[1]), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+	reversed := cellmanager.NewCellManager()
+	_, err = reversed.RecoverFromPeers(peerAddresses(ports[1], ports[0]), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+
+	cells, reversedCells := listCells(&cm, true), listCells(&reversed, true)
+	if len(cells) != len(reversedCells) {
+		fatalFail(errors.New("the order of the peers changed the recovered tree"))
+	}
+	for cellId, cell := range cells {
+		if !proto.Equal(cell, reversedCells[cellId]) {
+			fatalFail(errors.New("the order of the peers changed cell " + cellId))
+		}
+	}
+
+	// of two cell masters claiming the same cell, the one with the lowest address keeps it
+	conflicting := cellmanager.NewCellManager()
+	accepted, err := conflicting.RecoverFromPeers(peerAddresses(ports[2], ports[1]), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+	if accepted != 1 {
+		fatalFail(errors.New("both claims on the same cell were accepted"))
+	}
+	expectClaim(listCells(&conflicting, false), "23", 25, 75, 25, 25, int32(ports[1]))
+}
+
+func TestRecoverFromPeersFailsWithExistingTree(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.RecoverFromPeers(peerAddresses(firstUnusedPort), 100, 100)
+	if err == nil {
+		fatalFail(errors.New("recovery replaced an existing tree"))
+	}
+}
@@ -0,0 +1,330 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+const firstReplicaPort = 8850
+
+func replicaAddresses(count int) []string {
+	addresses := make([]string, count)
+	for index := range addresses {
+		addresses[index] = "localhost:" + fmt.Sprint(firstReplicaPort+index)
+	}
+	return addresses
+}
+
+// startReplica starts the replica at index id, it keeps its state in the data directory unless that is empty.
+func startReplica(id int, addresses []string, dataDir string) (*cellmanager.CellManager, *grpc.Server) {
+	cm := cellmanager.NewCellManager()
+	if len(dataDir) > 0 {
+		failIfNotNull(cm.EnablePersistence(dataDir, 2), "could not enable persistence")
+	}
+	lis, err := net.Listen("tcp", addresses[id])
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer(grpc.UnaryInterceptor(cm.LeaderOnlyInterceptor))
+	generated.RegisterCellManagerServer(server, &cm)
+	generated.RegisterReplicationServer(server, &cm)
+	go func() {
+		if err := server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			log.Fatalf("failed to serve %v", err)
+		}
+	}()
+
+	failIfNotNull(cm.EnableReplication(id, addresses), "could not enable replication")
+	return &cm, server
+}
+
+func waitForLeader(replicas []*cellmanager.CellManager) int {
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		for index, replica := range replicas {
+			if replica != nil && replica.IsLeader() {
+				return index
+			}
+		}
+	}
+	fatalFail(errors.New("no leader was elected"))
+	return -1
+}
+
+// replicaState describes the cells and players of a replica, it is empty while the replica has no world.
+func replicaState(cm *cellmanager.CellManager) map[string]proto.Message {
+	state := make(map[string]proto.Message, 0)
+	cells, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{IncludeInterior: true})
+	if err != nil {
+		return state
+	}
+
+	for _, cell := range cells.Cells {
+		state["cell "+cell.CellId] = cell
+		players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: cell.CellId})
+		failIfNotNull(err, "could not list players")
+		state["players "+cell.CellId] = players
+	}
+	return state
+}
+
+func sameState(first map[string]proto.Message, second map[string]proto.Message) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for key, message := range first {
+		if !proto.Equal(message, second[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// waitForReplicas waits until every running replica has the state of the leader, and it has the given number of cells.
+func waitForReplicas(replicas []*cellmanager.CellManager, leader int, cellCount int) {
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(20 * time.Millisecond) {
+		expected := replicaState(replicas[leader])
+		if len(expected) != cellCount*2 {
+			continue
+		}
+
+		converged := true
+		for _, replica := range replicas {
+			if replica != nil && !sameState(expected, replicaState(replica)) {
+				converged = false
+			}
+		}
+		if converged {
+			return
+		}
+	}
+	fatalFail(errors.New("the replicas did not converge"))
+}
+
+func TestReplicatedCellManager(t *testing.T) {
+	addresses := replicaAddresses(3)
+	replicas := make([]*cellmanager.CellManager, len(addresses))
+	servers := make([]*grpc.Server, len(addresses))
+	for id := range addresses {
+		replicas[id], servers[id] = startReplica(id, addresses, "")
+	}
+	defer func() {
+		for id, server := range servers {
+			server.Stop()
+			replicas[id].StopReplication()
+		}
+	}()
+
+	conn, err := cellmanager.DialCellManager(addresses)
+	failIfNotNull(err, "could not connect to the cell managers")
+	defer conn.Close()
+	client := generated.NewCellManagerClient(conn)
+
+	_, err = client.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	_, err = client.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort, PosX: 10, PosY: 10})
+	failIfNotNull(err, "could not add player")
+	_, err = client.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	leader := waitForLeader(replicas)
+	waitForReplicas(replicas, leader, 5)
+
+	follower := (leader + 1) % len(replicas)
+	_, err = replicas[follower].DivideCell(context.Background(), &generated.CellRequest{CellId: cellIdAt(replicas[follower], 0, 0)})
+	if err == nil {
+		fatalFail(errors.New("a follower changed its state on its own"))
+	}
+
+	// the client keeps working while a new leader is elected
+	servers[leader].Stop()
+	replicas[leader].StopReplication()
+	stopped := replicas[leader]
+	replicas[leader] = nil
+	_, err = client.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 60, PosY: 60})
+	failIfNotNull(err, "could not add player after the leader stopped")
+
+	newLeader := waitForLeader(replicas)
+	waitForReplicas(replicas, newLeader, 5)
+	if sameState(replicaState(stopped), replicaState(replicas[newLeader])) {
+		fatalFail(errors.New("the player was not added by the new leader"))
+	}
+
+	// a restarted replica is empty and catches up from the leader
+	replicas[leader], servers[leader] = startReplica(leader, addresses, "")
+	waitForReplicas(replicas, newLeader, 5)
+}
+
+// stopReplica stops the replica and closes its data directory.
+func stopReplica(replica *cellmanager.CellManager, server *grpc.Server) {
+	server.Stop()
+	replica.StopReplication()
+	failIfNotNull(replica.ClosePersistence(), "could not close persistence")
+}
+
+func TestPersistentReplicasKeepTheirState(t *testing.T) {
+	addresses := replicaAddresses(3)
+	dataDirs := make([]string, len(addresses))
+	replicas := make([]*cellmanager.CellManager, len(addresses))
+	servers := make([]*grpc.Server, len(addresses))
+	for id := range addresses {
+		dataDirs[id] = createDataDir()
+		defer os.RemoveAll(dataDirs[id])
+		replicas[id], servers[id] = startReplica(id, addresses, dataDirs[id])
+	}
+	defer func() {
+		for id, server := range servers {
+			stopReplica(replicas[id], server)
+		}
+	}()
+
+	conn, err := cellmanager.DialCellManager(addresses)
+	failIfNotNull(err, "could not connect to the cell managers")
+	defer conn.Close()
+	client := generated.NewCellManagerClient(conn)
+
+	_, err = client.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	_, err = client.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort, PosX: 10, PosY: 10})
+	failIfNotNull(err, "could not add player")
+
+	// the same cell is divided twice at once, the change that comes second no longer fits and is skipped by all
+	results := make(chan error, 2)
+	for range []int{0, 1} {
+		go func() {
+			_, err := client.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+			results <- err
+		}()
+	}
+	if first, second := <-results, <-results; (first == nil) == (second == nil) {
+		fatalFail(errors.New(fmt.Sprintf("expected exactly one division to succeed, got %v and %v", first, second)))
+	}
+	leader := waitForLeader(replicas)
+	waitForReplicas(replicas, leader, 5)
+	expected := replicaState(replicas[leader])
+
+	// the replicas come back with the state they stored, before any of them is elected
+	for id := range addresses {
+		stopReplica(replicas[id], servers[id])
+	}
+	for id := range addresses {
+		replicas[id], servers[id] = startReplica(id, addresses, dataDirs[id])
+		if !sameState(expected, replicaState(replicas[id])) {
+			fatalFail(errors.New(fmt.Sprintf("replica %d did not restore its state", id)))
+		}
+	}
+	leader = waitForLeader(replicas)
+	_, err = client.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 60, PosY: 60})
+	failIfNotNull(err, "could not add player after the restart")
+	waitForReplicas(replicas, leader, 5)
+
+	// a replica that lost its data directory is sent the state in place of the compacted log
+	follower := (leader + 1) % len(replicas)
+	stopReplica(replicas[follower], servers[follower])
+	failIfNotNull(os.RemoveAll(dataDirs[follower]), "could not remove data directory")
+	replicas[follower], servers[follower] = startReplica(follower, addresses, dataDirs[follower])
+	_, err = client.AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort + 2, PosX: 60, PosY: 10})
+	failIfNotNull(err, "could not add player")
+	waitForReplicas(replicas, leader, 5)
+}
+
+func TestIsolatedLeaderTurnsCallsAway(t *testing.T) {
+	addresses := replicaAddresses(3)
+	replicas := make([]*cellmanager.CellManager, len(addresses))
+	servers := make([]*grpc.Server, len(addresses))
+	for id := range addresses {
+		replicas[id], servers[id] = startReplica(id, addresses, "")
+	}
+	leader := waitForLeader(replicas)
+	defer func() {
+		servers[leader].Stop()
+		replicas[leader].StopReplication()
+	}()
+	_, err := replicas[leader].SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	for id := range replicas {
+		if id != leader {
+			servers[id].Stop()
+			replicas[id].StopReplication()
+		}
+	}
+
+	// a caller that gives up is answered right away
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = replicas[leader].AddPlayerToCellWithPositions(ctx, &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort, PosX: 10, PosY: 10})
+	if err == nil || time.Since(start) > time.Millisecond*constants.ReplicationTimeoutMilli {
+		fatalFail(errors.New("a call was not given up when its context was done"))
+	}
+
+	// the others are told to retry on the leader once the leader has stepped down
+	start = time.Now()
+	_, err = replicas[leader].AddPlayerToCellWithPositions(context.Background(), &generated.PlayerInCellRequestWithPositions{Ip: "localhost", Port: firstUnusedPort + 1, PosX: 10, PosY: 10})
+	if status.Code(err) != codes.Unavailable || time.Since(start) > time.Millisecond*constants.ReplicationTimeoutMilli*3 {
+		fatalFail(errors.New(fmt.Sprintf("an isolated leader did not turn the call away in time, got %v", err)))
+	}
+	if replicas[leader].IsLeader() {
+		fatalFail(errors.New("an isolated leader did not step down"))
+	}
+	if _, err := replicas[leader].ListCells(context.Background(), &generated.ListCellsRequest{}); err != nil {
+		fatalFail(errors.New("the tree was left locked by a call that was turned away"))
+	}
+}
+
+func TestConcurrentRequestsGrantACellOnce(t *testing.T) {
+	addresses := replicaAddresses(3)
+	replicas := make([]*cellmanager.CellManager, len(addresses))
+	servers := make([]*grpc.Server, len(addresses))
+	for id := range addresses {
+		replicas[id], servers[id] = startReplica(id, addresses, "")
+	}
+	defer func() {
+		for id, server := range servers {
+			server.Stop()
+			replicas[id].StopReplication()
+		}
+	}()
+
+	leader := replicas[waitForLeader(replicas)]
+	_, err := leader.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	addPlayer(leader, "localhost", firstUnusedPort, 10, 10)
+
+	// the requests see the cell without a cell master while the first grant is replicated
+	replies := make(chan *generated.CellMasterReply, 20)
+	var wg sync.WaitGroup
+	for index := 0; index < cap(replies); index++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reply, err := leader.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+			failIfNotNull(err, "could not request cell master")
+			replies <- reply
+		}()
+	}
+	wg.Wait()
+	close(replies)
+
+	for reply := range replies {
+		if reply.Port != firstUnusedPort {
+			fatalFail(errors.New(fmt.Sprintf("expected %d to be cell master, got %d", firstUnusedPort, reply.Port)))
+		}
+	}
+	if epoch := listCells(leader, false)["initialCell"].Epoch; epoch != 1 {
+		fatalFail(errors.New(fmt.Sprintf("a granted cell was granted again, it has epoch %d", epoch)))
+	}
+}
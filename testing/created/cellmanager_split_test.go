@@ -0,0 +1,149 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"os"
+	"testing"
+)
+
+// createCrowdedWorld has four players standing close together on the border of the left quadrants.
+func createCrowdedWorld(cm *cellmanager.CellManager) {
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	for index, posX := range []int64{10, 11, 12, 13} {
+		addPlayer(cm, "localhost", firstUnusedPort+int32(index), posX, 50)
+	}
+}
+
+func divideInHalves(cm *cellmanager.CellManager, cellId string) {
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: cellId, SplitMode: generated.SplitMode_BALANCED_HALVES})
+	failIfNotNull(err, "could not divide cell in halves")
+}
+
+func TestDivideCellInBalancedHalves(t *testing.T) {
+	cm := cellmanager.NewCellManager()
+	createCrowdedWorld(&cm)
+	divideInHalves(&cm, "initialCell")
+
+	cells := listCells(&cm, false)
+	if len(cells) != 2 {
+		fatalFail(errors.New("the cell was not cut in two"))
+	}
+	expectCell(cells[cellIdAt(&cm, 0, 0)], 0, 0, 12, 100)
+	expectCell(cells[cellIdAt(&cm, 12, 0)], 12, 0, 88, 100)
+	if cells[cellIdAt(&cm, 0, 0)].PlayerCount != 2 || cells[cellIdAt(&cm, 12, 0)].PlayerCount != 2 {
+		fatalFail(errors.New("the crowd was not divided between the halves"))
+	}
+
+	// new players are placed by the halves
+	addPlayer(&cm, "localhost", firstUnusedPort+10, 11, 90)
+	addPlayer(&cm, "localhost", firstUnusedPort+11, 50, 50)
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort + 10})
+	failIfNotNull(err, "could not locate player")
+	if location.CellId != cellIdAt(&cm, 0, 0) {
+		fatalFail(errors.New("player was placed in the wrong half"))
+	}
+	location, err = cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort + 11})
+	failIfNotNull(err, "could not locate player")
+	if location.CellId != cellIdAt(&cm, 12, 0) {
+		fatalFail(errors.New("player was placed in the wrong half"))
+	}
+}
+
+func TestDivideCellInHalvesAlongLongerSide(t *testing.T) {
+	cm := createWorld(40, 100)
+	divideInHalves(&cm, "initialCell")
+
+	cells := listCells(&cm, false)
+	expectCell(cells[cellIdAt(&cm, 0, 0)], 0, 0, 40, 50)
+	expectCell(cells[cellIdAt(&cm, 0, 50)], 0, 50, 40, 50)
+}
+
+func TestDivideTinyCellInHalvesFails(t *testing.T) {
+	cm := createWorld(1, 1)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell", SplitMode: generated.SplitMode_BALANCED_HALVES})
+	if err == nil {
+		fatalFail(errors.New("a cell of a single position was cut in halves"))
+	}
+}
+
+func TestResizeHalves(t *testing.T) {
+	cm := cellmanager.NewCellManager()
+	createCrowdedWorld(&cm)
+	divideInHalves(&cm, "initialCell")
+	left := cellIdAt(&cm, 0, 0)
+
+	_, err := cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: left, NewWidth: 30, NewHeight: 50})
+	if err == nil {
+		fatalFail(errors.New("a half changed size along the cut"))
+	}
+
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: left, NewWidth: 30, NewHeight: 100})
+	failIfNotNull(err, "could not resize half")
+	cells := listCells(&cm, false)
+	expectCell(cells[left], 0, 0, 30, 100)
+	expectCell(cells[cellIdAt(&cm, 30, 0)], 30, 0, 70, 100)
+}
+
+func TestMergeHalves(t *testing.T) {
+	cm := cellmanager.NewCellManager()
+	createCrowdedWorld(&cm)
+	divideInHalves(&cm, "initialCell")
+	divideInHalves(&cm, cellIdAt(&cm, 12, 0))
+	addPlayer(&cm, "localhost", firstUnusedPort+10, 90, 90)
+
+	cm.PerformMerge("initialCell")
+	cells := listCells(&cm, false)
+	if len(cells) != 1 {
+		fatalFail(errors.New("the halves were not merged"))
+	}
+	expectCell(cells["initialCell"], 0, 0, 100, 100)
+	if cells["initialCell"].PlayerCount != 5 {
+		fatalFail(errors.New("the players of the halves were not kept"))
+	}
+}
+
+func TestRecoverHalvesFromLog(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	createCrowdedWorld(&cm)
+	divideInHalves(&cm, "initialCell")
+	divideInHalves(&cm, cellIdAt(&cm, 12, 0))
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+
+	recovered := createPersistentCellManager(dataDir, 1000)
+	expectSameState(&cm, &recovered)
+}
+
+func TestPerformSplitUsesSplitMode(t *testing.T) {
+	cm := cellmanager.NewCellManager()
+	createCrowdedWorld(&cm)
+	cm.SetSplitMode(generated.SplitMode_BALANCED_HALVES)
+
+	cm.PerformSplit("initialCell")
+	if len(leafIds(&cm)) != 2 {
+		fatalFail(errors.New("the split mode was not used"))
+	}
+}
+
+func TestRecoverHalvesFromPeers(t *testing.T) {
+	port := firstRecoveryPort + 20
+	_, server := startCellMaster(port, claimedCell("w", 0, 0, 30, 100))
+	defer server.Stop()
+
+	cm := cellmanager.NewCellManager()
+	accepted, err := cm.RecoverFromPeers(peerAddresses(port), 100, 100)
+	failIfNotNull(err, "could not recover from peers")
+	if accepted != 1 {
+		fatalFail(errors.New("the claim on a half was not accepted"))
+	}
+
+	cells := listCells(&cm, false)
+	expectClaim(cells, "w", 0, 0, 30, 100, int32(port))
+	expectCell(cells[cellIdAt(&cm, 30, 0)], 30, 0, 70, 100)
+}
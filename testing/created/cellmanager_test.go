@@ -14,41 +14,51 @@ import (
 	"testing"
 )
 
-func TestCreateCell(t *testing.T) {
+func createWorld(width int64, height int64) cellmanager.CellManager {
 	cm := cellmanager.NewCellManager()
-	request := generated.CellRequest{CellId: "testId"}
-	_, err := cm.CreateCell(context.Background(), &request)
-	failIfNotNull(err, "could not create cell")
-	if (*cm.Cells)[0].CellId != "testId" {
-		fatalFail(errors.New("cell was not inserted with CreateCell"))
-	}
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: width, Height: height})
+	failIfNotNull(err, "could not set world size")
+	return cm
 }
 
-func TestDeleteCell(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	request := generated.CellRequest{CellId: "testId"}
-	_, err := cm.CreateCell(context.Background(), &request)
-	failIfNotNull(err, "could not create cell")
-	if (*cm.Cells)[0].CellId != "testId" {
-		fatalFail(errors.New("cell was not inserted with CreateCell"))
+func listCells(cm *cellmanager.CellManager, includeInterior bool) map[string]*generated.CellInfo {
+	reply, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{IncludeInterior: includeInterior})
+	failIfNotNull(err, "could not list cells")
+	cells := make(map[string]*generated.CellInfo, 0)
+	for _, cell := range reply.Cells {
+		cells[cell.CellId] = cell
 	}
+	return cells
+}
 
-	_, err = cm.DeleteCell(context.Background(), &request)
-	failIfNotNull(err, "could not delete cell")
-	if len(*cm.Cells) != 0 {
-		fatalFail(errors.New("cell was not deleted with DeleteCell"))
-	}
+func leafIds(cm *cellmanager.CellManager) []string {
+	reply, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{})
+	failIfNotNull(err, "could not list cells")
+	return reply.CellId
+}
+
+func addPlayer(cm *cellmanager.CellManager, ip string, port int32, posX int64, posY int64) {
+	_, err := cm.AddPlayerToCellWithPositions(
+		context.Background(),
+		&generated.PlayerInCellRequestWithPositions{Ip: ip, Port: port, PosX: posX, PosY: posY},
+	)
+	failIfNotNull(err, "could not add player to cell")
 }
 
 func TestUnregisterCellMaster(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	(*cm.Cells)[0].CellMaster = &objects.Client{Ip: "testIp", Port: 1337, TrustLevel: 0}
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "testIp", 1337, 0, 0)
+	_, err := cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not select cell master")
+	if listCells(&cm, false)["initialCell"].CellMasterPort != 1337 {
+		fatalFail(errors.New("cell master was not selected"))
+	}
+
 	status, err := cm.UnregisterCellMaster(
-		context.Background(), &generated.CellMasterRequest{CellId: "testId1"},
+		context.Background(), &generated.CellMasterRequest{CellId: "initialCell"},
 	)
 	failIfNotNull(err, "could not unregister cell")
-	if status.WasUnregistered == true && (*cm.Cells)[0].CellMaster == nil {
+	if status.WasUnregistered == true && listCells(&cm, false)["initialCell"].CellMasterPort == -1 {
 		return
 	} else {
 		fatalFail(errors.New("CellMaster was not unregistered with UnregisterCellMaster"))
@@ -56,119 +66,218 @@ func TestUnregisterCellMaster(t *testing.T) {
 }
 
 func TestUnregisterCellMasterReturnsOnFail(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	(*cm.Cells)[0].CellMaster = &objects.Client{Ip: "testIp", Port: 1337, TrustLevel: 0}
+	cm := createWorld(100, 100)
 	status, err := cm.UnregisterCellMaster(
 		context.Background(), &generated.CellMasterRequest{CellId: "invalidId"},
 	)
-	failIfNotNull(err, "could not unregister cell")
-	if status.WasUnregistered == false {
+	if status.WasUnregistered == false && err != nil {
 		return
 	}
 	fatalFail(errors.New("unregister succeeded when it should not have"))
 }
 
-func TestCreateCellCreatesEmptyPlayerList(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	request := generated.CellRequest{CellId: "testId"}
-	_, err := cm.CreateCell(context.Background(), &request)
-	failIfNotNull(err, "could not create cell")
-	if (*cm.Cells)[0].Players == nil {
-		fatalFail(errors.New("cell did not create empty player list with CreateCell"))
-	}
-}
-
 func TestListCells(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	cm.AppendCell(objects.Cell{CellId: "testId2"})
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "testIp", 1337, 10, 10)
+	addPlayer(&cm, "testIp", 1338, 20, 20)
+
 	cellList, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{})
 	failIfNotNull(err, "could not list cells")
-	testId1Exists := false
-	testId2Exists := false
-	for _, cellId := range cellList.CellId {
-		if cellId == "testId1" {
-			testId1Exists = true
+	if len(cellList.CellId) != 4 || len(cellList.Cells) != 4 {
+		fatalFail(errors.New("ListCells did not return the four leaves"))
+	}
+
+	for index, cell := range cellList.Cells {
+		if cell.CellId != cellList.CellId[index] {
+			fatalFail(errors.New("cell ids and cells are not in the same order"))
+		}
+		if !cell.IsLeaf || cell.Depth != 1 || cell.ParentId != "initialCell" {
+			fatalFail(errors.New("leaf returned with incorrect tree position"))
 		}
-		if cellId == "testId2" {
-			testId2Exists = true
+		if cell.Width != 50 || cell.Height != 50 {
+			fatalFail(errors.New("leaf returned with incorrect size"))
 		}
+		if cell.PosX == 0 && cell.PosY == 0 && cell.PlayerCount != 2 {
+			fatalFail(errors.New("leaf returned with incorrect player count"))
+		}
+		if cell.CellMasterPort != -1 || cell.Locked {
+			fatalFail(errors.New("leaf returned with cell master or lock"))
+		}
+	}
+}
+
+func TestListCellsIncludesInteriorNodes(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "testIp", 1337, 10, 10)
+
+	cells := listCells(&cm, true)
+	if len(cells) != 5 {
+		fatalFail(errors.New("ListCells did not return both root and leaves"))
 	}
-	if !testId1Exists || !testId2Exists {
-		fatalFail(errors.New("cells were not returned from ListCells"))
+
+	root, exists := cells["initialCell"]
+	if !exists || root.IsLeaf || root.Depth != 0 || root.ParentId != "" {
+		fatalFail(errors.New("root returned incorrectly"))
+	}
+	if root.Width != 100 || root.Height != 100 || root.PlayerCount != 1 {
+		fatalFail(errors.New("root returned with incorrect geometry or player count"))
 	}
 }
 
-func TestListPlayersInCell(t *testing.T) {
+func TestListCellsReportsLockState(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{"initialCell"}, SenderCellId: "tester"})
+	failIfNotNull(err, "could not lock cells")
+
+	cell := listCells(&cm, false)["initialCell"]
+	if !cell.Locked || cell.Lockee != "tester" {
+		fatalFail(errors.New("lock state was not returned from ListCells"))
+	}
+}
+
+func TestListCellsFailsWithoutWorld(t *testing.T) {
 	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
+	_, err := cm.ListCells(context.Background(), &generated.ListCellsRequest{})
+	if err == nil {
+		fatalFail(errors.New("listed cells without a world"))
+	}
+}
+
+func TestListPlayersInCell(t *testing.T) {
+	cm := createWorld(100, 100)
 	testIp := "192.168.16.1"
-	(*cm.Cells)[0].AppendPlayer(objects.Client{Ip: testIp, Port: 1337})
+	_, err := cm.AddPlayerToCellWithPositions(
+		context.Background(),
+		&generated.PlayerInCellRequestWithPositions{Ip: testIp, Port: 1337, PosX: 10, PosY: 10, ObjectId: "object1"},
+	)
+	failIfNotNull(err, "could not add player to cell")
 	playerList, err := cm.ListPlayersInCell(
-		context.Background(), &generated.ListPlayersRequest{CellId: "testId1"},
+		context.Background(), &generated.ListPlayersRequest{CellId: "initialCell"},
 	)
 	failIfNotNull(err, "could not list players object cell")
-	if len(playerList.Port) == 0 || len(playerList.Ip) == 0 {
+	if len(playerList.Port) == 0 || len(playerList.Ip) == 0 || len(playerList.ObjectId) == 0 {
 		fatalFail(errors.New("players were not returned from ListPlayersInCell"))
 	}
-	if playerList.Ip[0] == testIp && playerList.Port[0] == 1337 {
+	if playerList.Ip[0] == testIp && playerList.Port[0] == 1337 && playerList.ObjectId[0] == "object1" {
 		return
 	}
 	fatalFail(errors.New("incorrect players were returned from ListPlayersInCell"))
 }
 
-func TestAddPlayerToCell(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Players: make([]objects.Client, 0)})
-	testIp := "192.168.16.1"
-	status, err := cm.AddPlayerToCell(
+func TestListPlayersInCellIncludesDescendants(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "testIp", 1337, 10, 10)
+	addPlayer(&cm, "testIp", 1338, 90, 90)
+
+	playerList, err := cm.ListPlayersInCell(
+		context.Background(), &generated.ListPlayersRequest{CellId: "initialCell"},
+	)
+	failIfNotNull(err, "could not list players object cell")
+	if len(playerList.Port) != 2 {
+		fatalFail(errors.New("players of child cells were not returned from ListPlayersInCell"))
+	}
+}
+
+func TestListPlayersInInvalidCellFails(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.ListPlayersInCell(
+		context.Background(), &generated.ListPlayersRequest{CellId: "invalidId"},
+	)
+	if err == nil {
+		fatalFail(errors.New("listed players of a cell that does not exist"))
+	}
+}
+
+func TestLocatePlayer(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.AddPlayerToCellWithPositions(
 		context.Background(),
-		&generated.PlayerInCellRequest{CellId: "testId1", Ip: testIp, Port: 1337},
+		&generated.PlayerInCellRequestWithPositions{Ip: "testIp", Port: 1337, PosX: 90, PosY: 90, ObjectId: "object1"},
 	)
 	failIfNotNull(err, "could not add player to cell")
-	addedPlayer := (*cm.Cells)[0].Players[0]
-	if status.Succeeded && addedPlayer.Port == 1337 && addedPlayer.Ip == testIp {
-		return
+	addPlayer(&cm, "testIp", 1338, 90, 90)
+
+	expectedCell := ""
+	for _, cell := range listCells(&cm, false) {
+		if cell.PosX == 50 && cell.PosY == 50 {
+			expectedCell = cell.CellId
+		}
+	}
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: expectedCell})
+	failIfNotNull(err, "could not select cell master")
+
+	byAddress, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "testIp", Port: 1337})
+	failIfNotNull(err, "could not locate player")
+	byObjectId, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{ObjectId: "object1"})
+	failIfNotNull(err, "could not locate player")
+
+	for _, location := range []*generated.PlayerLocationReply{byAddress, byObjectId} {
+		if !location.Found || location.CellId != expectedCell {
+			fatalFail(errors.New("player located in wrong cell"))
+		}
+		if location.Port != 1337 || location.ObjectId != "object1" {
+			fatalFail(errors.New("wrong player located"))
+		}
+		if location.CellMasterIp != "testIp" || location.CellMasterPort < 1337 {
+			fatalFail(errors.New("cell master of the player was not returned"))
+		}
+	}
+}
+
+func TestLocateUnknownPlayer(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "testIp", 1337, 10, 10)
+
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{ObjectId: "unknown"})
+	failIfNotNull(err, "could not locate player")
+	if location.Found {
+		fatalFail(errors.New("located a player that is not registered"))
+	}
+
+	_, err = cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{})
+	if err == nil {
+		fatalFail(errors.New("located a player without address or object id"))
 	}
-	fatalFail(errors.New("player was not added correctly"))
 }
 
 func TestAddPlayerToCellWithPositionsBoundary(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Players: make([]objects.Client, 0), PosY: 0, PosX: 0, Width: 100, Height: 100})
+	cm := createWorld(100, 100)
 	testIp := "192.168.16.1"
 	status, err := cm.AddPlayerToCellWithPositions(
 		context.Background(),
-		&generated.PlayerInCellRequestWithPositions{PosX: 100, PosY: 0, Ip: testIp, Port: 1337},
+		&generated.PlayerInCellRequestWithPositions{PosX: 99, PosY: 0, Ip: testIp, Port: 1337},
 	)
 	failIfNotNull(err, "could not add player to cell")
-	addedPlayer := (*cm.Cells)[0].Players[0]
-	if status.Succeeded && addedPlayer.Port == 1337 && addedPlayer.Ip == testIp {
+	if status.Succeeded && listCells(&cm, false)["initialCell"].PlayerCount == 1 {
 		return
 	}
 	fatalFail(errors.New("player was not added correctly"))
 }
 
 func TestAddPlayerToCellWithPositionsCenter(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Players: make([]objects.Client, 0), PosY: 0, PosX: 0, Width: 100, Height: 100})
+	cm := createWorld(100, 100)
 	testIp := "192.168.16.1"
 	status, err := cm.AddPlayerToCellWithPositions(
 		context.Background(),
 		&generated.PlayerInCellRequestWithPositions{PosX: 50, PosY: 50, Ip: testIp, Port: 1337},
 	)
 	failIfNotNull(err, "could not add player to cell")
-	addedPlayer := (*cm.Cells)[0].Players[0]
-	if status.Succeeded && addedPlayer.Port == 1337 && addedPlayer.Ip == testIp {
+	if status.Succeeded && listCells(&cm, false)["initialCell"].PlayerCount == 1 {
 		return
 	}
 	fatalFail(errors.New("player was not added correctly"))
 }
 
 func TestAddPlayerToCellWithPositionsShouldFailOnInvalidPositions(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Players: make([]objects.Client, 0), PosY: 0, PosX: 0, Width: 100, Height: 100})
+	cm := createWorld(100, 100)
 	testIp := "192.168.16.1"
 	status, err := cm.AddPlayerToCellWithPositions(
 		context.Background(),
@@ -180,21 +289,6 @@ func TestAddPlayerToCellWithPositionsShouldFailOnInvalidPositions(t *testing.T)
 	fatalFail(errors.New("player was added incorrectly"))
 }
 
-func TestAddPlayerToCellThrowsIfInvalidCellId(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Players: make([]objects.Client, 0)})
-	testIp := "192.168.16.1"
-	status, err := cm.AddPlayerToCell(
-		context.Background(),
-		&generated.PlayerInCellRequest{CellId: "invalidTestId", Ip: testIp, Port: 1337},
-	)
-	if status.Succeeded == false && err != nil {
-		return
-	} else {
-		fatalFail(errors.New("AddPlayerToCell did not throw on invalid cellId"))
-	}
-}
-
 func TestSetWorldSize(t *testing.T) {
 	cm := cellmanager.NewCellManager()
 	cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
@@ -206,10 +300,9 @@ func TestSetWorldSize(t *testing.T) {
 }
 
 func TestSetWorldSizeCreatesBigCell(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
-	if (*cm.Cells)[0].Width == 100 && (*cm.Cells)[0].Height == 100 &&
-		(*cm.Cells)[0].PosX == 0 && (*cm.Cells)[0].PosY == 0 {
+	cm := createWorld(100, 100)
+	cell := listCells(&cm, false)["initialCell"]
+	if cell.Width == 100 && cell.Height == 100 && cell.PosX == 0 && cell.PosY == 0 {
 		return
 	} else {
 		fatalFail(errors.New("SetWorldSize does not initialize world cell"))
@@ -228,164 +321,155 @@ func TestSetWorldSizeFailsIfACellExists(t *testing.T) {
 }
 
 func TestPlayerLeftCell(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId2"})
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
+	cm := createWorld(100, 100)
 	testIp := "192.168.16.1"
 	testIp2 := "192.168.16.2"
-	(*cm.Cells)[1].AppendPlayer(objects.Client{Ip: testIp, Port: 1337})
-	(*cm.Cells)[1].AppendPlayer(objects.Client{Ip: testIp2, Port: 1337})
+	addPlayer(&cm, testIp, 1337, 10, 10)
+	addPlayer(&cm, testIp2, 1337, 10, 10)
 	reply, err := cm.PlayerLeftCell(
 		context.Background(),
-		&generated.PlayerInCellRequest{Port: 1337, Ip: testIp, CellId: "testId1"},
+		&generated.PlayerInCellRequest{Port: 1337, Ip: testIp, CellId: "initialCell"},
 	)
-	failIfNotNull(err, "could not list players object cell")
+	failIfNotNull(err, "could not remove player from cell")
 	if !reply.PlayerLeft {
 		fatalFail(errors.New("PlayerLeft bool is invalid"))
 	}
-	player2Exists := false
-	for _, player := range (*cm.Cells)[0].Players {
-		if player.Ip == testIp2 {
-			player2Exists = false
-		}
-	}
-	if player2Exists {
+	if listCells(&cm, false)["initialCell"].PlayerCount != 1 {
 		fatalFail(errors.New("player was not removed from cell object playerleftcell"))
 	}
 }
 
 func TestLockCells(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	cm.AppendCell(objects.Cell{CellId: "testId2"})
-	cm.AppendCell(objects.Cell{CellId: "testId3"})
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	ids := leafIds(&cm)
 
-	ids := []string{"testId1", "testId2"}
-	request := generated.LockCellsRequest{CellId: ids, SenderCellId: "tester"}
+	request := generated.LockCellsRequest{CellId: ids[:2], SenderCellId: "tester"}
 	reply, err := cm.LockCells(context.Background(), &request)
 	failIfNotNull(err, "could not lock cells")
 	if !reply.Locked {
 		fatalFail(errors.New("locked bool is invalid"))
 	}
 
-	if !(*cm.Cells)[0].Locked {
-		fatalFail(errors.New("cell testId1 is not locked"))
-	} else if !(*cm.Cells)[1].Locked {
-		fatalFail(errors.New("cell testId2 is not locked"))
-	} else if (*cm.Cells)[2].Locked {
-		fatalFail(errors.New("cell testId3 is locked"))
+	cells := listCells(&cm, false)
+	if !cells[ids[0]].Locked {
+		fatalFail(errors.New("first cell is not locked"))
+	} else if !cells[ids[1]].Locked {
+		fatalFail(errors.New("second cell is not locked"))
+	} else if cells[ids[2]].Locked {
+		fatalFail(errors.New("third cell is locked"))
 	}
 
-	if (*cm.Cells)[0].Lockee != "tester" {
-		fatalFail(errors.New("cell testId1 has wrong lockee"))
-	} else if (*cm.Cells)[1].Lockee != "tester" {
-		fatalFail(errors.New("cell testId2 has wrong lockee"))
+	if cells[ids[0]].Lockee != "tester" {
+		fatalFail(errors.New("first cell has wrong lockee"))
+	} else if cells[ids[1]].Lockee != "tester" {
+		fatalFail(errors.New("second cell has wrong lockee"))
 	}
 }
 
 func TestCannotLockWhenACellIsLocked(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	cm.AppendCell(objects.Cell{CellId: "testId2", Locked: true})
-	cm.AppendCell(objects.Cell{CellId: "testId3"})
-
-	ids := []string{"testId1", "testId2"}
-	request := generated.LockCellsRequest{CellId: ids, SenderCellId: "tester"}
-	reply, err := cm.LockCells(context.Background(), &request)
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	ids := leafIds(&cm)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[1:2], SenderCellId: "other"})
 	failIfNotNull(err, "could not lock cells")
-	if reply.Locked {
-		fatalFail(errors.New("locked bool is invalid"))
+
+	request := generated.LockCellsRequest{CellId: ids[:2], SenderCellId: "tester"}
+	_, err = cm.LockCells(context.Background(), &request)
+	if err == nil {
+		fatalFail(errors.New("locked cells when one was already locked"))
 	}
 
-	if (*cm.Cells)[0].Locked {
-		fatalFail(errors.New("cell testId1 is locked"))
-	} else if !(*cm.Cells)[1].Locked {
-		fatalFail(errors.New("cell testId2 is not locked"))
-	} else if (*cm.Cells)[2].Locked {
-		fatalFail(errors.New("cell testId3 is locked"))
+	cells := listCells(&cm, false)
+	if cells[ids[0]].Locked {
+		fatalFail(errors.New("first cell is locked"))
+	} else if !cells[ids[1]].Locked {
+		fatalFail(errors.New("second cell is not locked"))
+	} else if cells[ids[2]].Locked {
+		fatalFail(errors.New("third cell is locked"))
 	}
 }
 
 func TestUnlockCells(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Locked: true})
-	cm.AppendCell(objects.Cell{CellId: "testId2", Locked: true})
-	cm.AppendCell(objects.Cell{CellId: "testId3"})
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	ids := leafIds(&cm)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[:2]})
+	failIfNotNull(err, "could not lock cells")
 
-	ids := []string{"testId1", "testId2"}
-	request := generated.LockCellsRequest{CellId: ids}
+	request := generated.LockCellsRequest{CellId: ids[:2]}
 	reply, err := cm.UnlockCells(context.Background(), &request)
-	failIfNotNull(err, "could not lock cells")
+	failIfNotNull(err, "could not unlock cells")
 	if reply.Locked {
 		fatalFail(errors.New("locked bool is invalid"))
 	}
 
-	if (*cm.Cells)[0].Locked {
-		fatalFail(errors.New("cell testId1 is not unlocked"))
-	} else if (*cm.Cells)[1].Locked {
-		fatalFail(errors.New("cell testId2 is not unlocked"))
-	} else if (*cm.Cells)[2].Locked {
-		fatalFail(errors.New("cell testId3 is locked"))
+	cells := listCells(&cm, false)
+	if cells[ids[0]].Locked {
+		fatalFail(errors.New("first cell is not unlocked"))
+	} else if cells[ids[1]].Locked {
+		fatalFail(errors.New("second cell is not unlocked"))
+	} else if cells[ids[2]].Locked {
+		fatalFail(errors.New("third cell is locked"))
 	}
 }
 
 func TestCannotUnlockWhenACellIsNotLocked(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Locked: true})
-	cm.AppendCell(objects.Cell{CellId: "testId2"})
-	cm.AppendCell(objects.Cell{CellId: "testId3"})
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	ids := leafIds(&cm)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[:1]})
+	failIfNotNull(err, "could not lock cells")
 
-	ids := []string{"testId1", "testId2"}
-	request := generated.LockCellsRequest{CellId: ids}
+	request := generated.LockCellsRequest{CellId: ids[:2]}
 	reply, err := cm.UnlockCells(context.Background(), &request)
-	failIfNotNull(err, "could not lock cells")
-	if !reply.Locked {
-		fatalFail(errors.New("locked bool is invalid"))
+	if err == nil || !reply.Locked {
+		fatalFail(errors.New("unlocked cells when one was not locked"))
 	}
 
-	if !(*cm.Cells)[0].Locked {
-		fatalFail(errors.New("cell testId1 is unlocked"))
-	} else if (*cm.Cells)[1].Locked {
-		fatalFail(errors.New("cell testId2 is locked"))
-	} else if (*cm.Cells)[2].Locked {
-		fatalFail(errors.New("cell testId3 is locked"))
+	cells := listCells(&cm, false)
+	if !cells[ids[0]].Locked {
+		fatalFail(errors.New("first cell is unlocked"))
+	} else if cells[ids[1]].Locked {
+		fatalFail(errors.New("second cell is locked"))
 	}
 }
 
 func TestCannotUnlockWhenACellIsLockedBySomeoneElse(t *testing.T) {
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1", Locked: true, Lockee: "tester"})
-	cm.AppendCell(objects.Cell{CellId: "testId2", Locked: true, Lockee: "hacker"})
-	cm.AppendCell(objects.Cell{CellId: "testId3"})
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	ids := leafIds(&cm)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[:1], SenderCellId: "tester"})
+	failIfNotNull(err, "could not lock cells")
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[1:2], SenderCellId: "hacker"})
+	failIfNotNull(err, "could not lock cells")
 
-	ids := []string{"testId1", "testId2"}
-	request := generated.LockCellsRequest{CellId: ids}
+	request := generated.LockCellsRequest{CellId: ids[:2], SenderCellId: "tester"}
 	reply, err := cm.UnlockCells(context.Background(), &request)
-	failIfNotNull(err, "could not lock cells")
-	if !reply.Locked {
-		fatalFail(errors.New("locked bool is invalid"))
+	if err == nil || !reply.Locked {
+		fatalFail(errors.New("unlocked a cell locked by someone else"))
 	}
 
-	if !(*cm.Cells)[0].Locked {
-		fatalFail(errors.New("cell testId1 is unlocked"))
-	} else if !(*cm.Cells)[1].Locked {
-		fatalFail(errors.New("cell testId2 is unlocked"))
-	} else if (*cm.Cells)[2].Locked {
-		fatalFail(errors.New("cell testId3 is locked"))
+	cells := listCells(&cm, false)
+	if !cells[ids[0]].Locked {
+		fatalFail(errors.New("first cell is unlocked"))
+	} else if !cells[ids[1]].Locked {
+		fatalFail(errors.New("second cell is unlocked"))
 	}
 }
 
 func TestRequestCellMaster(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.Cell{CellId: "testId2", CellMaster: &cellMaster}
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.Cell{CellId: "testId1"})
-	cm.AppendCell(mainCell)
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "randomIp", 1337, 10, 10)
 
-	request := generated.CellMasterRequest{CellId: "testId2"}
+	request := generated.CellMasterRequest{CellId: "initialCell"}
 	reply, err := cm.RequestCellMaster(context.Background(), &request)
-	failIfNotNull(err, "could not lock cells")
+	failIfNotNull(err, "could not request cell master")
 	if reply.Ip == "" {
 		fatalFail(errors.New("returned empty cellMaster"))
 	}
@@ -397,40 +481,35 @@ func TestRequestCellMaster(t *testing.T) {
 	}
 }
 
-func TestRequestCellMasterSelectsNewCellMaster(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.NewCell("testId2")
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.NewCell("testId1"))
-	cm.AppendCell(mainCell)
-	(*cm.Cells)[1].Players = append((*cm.Cells)[1].Players, cellMaster)
+func TestRequestCellMasterKeepsCellMaster(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "randomIp", 1337, 10, 10)
 
-	request := generated.CellMasterRequest{CellId: "testId2"}
+	request := generated.CellMasterRequest{CellId: "initialCell"}
+	_, err := cm.RequestCellMaster(context.Background(), &request)
+	failIfNotNull(err, "could not request cell master")
+	addPlayer(&cm, "randomIp", 1338, 10, 10)
 	reply, err := cm.RequestCellMaster(context.Background(), &request)
-	failIfNotNull(err, "Error")
-	if reply.Ip == "" {
-		fatalFail(errors.New("returned empty cellMaster"))
-	}
-	if reply.Ip != "randomIp" {
-		fatalFail(errors.New("returned wrong Ip"))
-	}
+	failIfNotNull(err, "could not request cell master")
 	if reply.Port != 1337 {
-		fatalFail(errors.New("returned wrong Port"))
+		fatalFail(errors.New("cell master changed without being unregistered"))
 	}
 }
 
 func TestRequestCellMasterFailsOnEmptyCell(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.NewCell("testId2")
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(objects.NewCell("testId1"))
-	cm.AppendCell(mainCell)
-	(*cm.Cells)[0].Players = append((*cm.Cells)[0].Players, cellMaster)
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "randomIp", 1337, 10, 10)
+	emptyCell := ""
+	for _, cell := range listCells(&cm, false) {
+		if cell.PlayerCount == 0 {
+			emptyCell = cell.CellId
+		}
+	}
 
-	request := generated.CellMasterRequest{CellId: "testId2"}
-	_, err := cm.RequestCellMaster(context.Background(), &request)
+	request := generated.CellMasterRequest{CellId: emptyCell}
+	_, err = cm.RequestCellMaster(context.Background(), &request)
 	if err != nil {
 		return
 	}
@@ -438,17 +517,8 @@ func TestRequestCellMasterFailsOnEmptyCell(t *testing.T) {
 }
 
 func TestRequestCellMasterWithPositions(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.NewCell("testId2")
-	mainCell.PosX = 0
-	mainCell.PosY = 0
-	mainCell.Width = 100
-	mainCell.Height = 100
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(mainCell)
-
-	(*cm.Cells)[0].CellMaster = &cellMaster
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", 1, 10, 10)
 
 	request := generated.Position{PosX: 50, PosY: 50}
 	newCm, err := cm.RequestCellMasterWithPositions(context.Background(), &request)
@@ -456,7 +526,7 @@ func TestRequestCellMasterWithPositions(t *testing.T) {
 		fatalFail(errors.New("error on requesting CM"))
 	}
 
-	if newCm.Port == cellMaster.Port {
+	if newCm.Port == 1 {
 		return
 	}
 
@@ -464,17 +534,8 @@ func TestRequestCellMasterWithPositions(t *testing.T) {
 }
 
 func TestRequestCellMasterWithPositionsFailsIfOutOfBounds(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.NewCell("testId2")
-	mainCell.PosX = 0
-	mainCell.PosY = 0
-	mainCell.Width = 100
-	mainCell.Height = 100
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(mainCell)
-
-	(*cm.Cells)[0].CellMaster = &cellMaster
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", 1, 10, 10)
 
 	request := generated.Position{PosX: 150, PosY: 50}
 	_, err := cm.RequestCellMasterWithPositions(context.Background(), &request)
@@ -485,19 +546,9 @@ func TestRequestCellMasterWithPositionsFailsIfOutOfBounds(t *testing.T) {
 }
 
 func TestDivideCell(t *testing.T) {
-	cellMaster := objects.Client{Ip: "randomIp", Port: 1337}
-	mainCell := objects.NewCell("testCell")
-	mainCell.PosX = 0
-	mainCell.PosY = 0
-	mainCell.Width = 100
-	mainCell.Height = 100
-
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(mainCell)
-
-	(*cm.Cells)[0].CellMaster = &cellMaster
+	cm := createWorld(100, 100)
 
-	request := generated.CellRequest{CellId:"testCell"}
+	request := generated.CellRequest{CellId: "initialCell"}
 
 	res, err := cm.DivideCell(context.Background(), &request)
 	failIfNotNull(err, "request for DivideCell failed")
@@ -506,7 +557,8 @@ func TestDivideCell(t *testing.T) {
 		fatalFail(errors.New("divide failed"))
 	}
 
-	if len(*cm.Cells) != 4 {
+	cells := listCells(&cm, false)
+	if len(cells) != 4 {
 		fatalFail(errors.New("did not create 4 cells"))
 	}
 
@@ -514,18 +566,18 @@ func TestDivideCell(t *testing.T) {
 	second := false
 	third := false
 	fourth := false
-	for _, cell := range *cm.Cells {
-		if cell.Width != mainCell.Width/2 || cell.Height != mainCell.Height/2 {
+	for _, cell := range cells {
+		if cell.Width != 50 || cell.Height != 50 {
 			fatalFail(errors.New("height or width set incorrectly"))
 		}
 
-		if cell.PosX == mainCell.PosX && cell.PosY == mainCell.PosY {
+		if cell.PosX == 0 && cell.PosY == 0 {
 			first = true
-		} else if cell.PosX == mainCell.PosX && cell.PosY == mainCell.PosY + mainCell.Height/2 {
+		} else if cell.PosX == 0 && cell.PosY == 50 {
 			second = true
-		} else if cell.PosX == mainCell.PosX + mainCell.Width/2 && cell.PosY == mainCell.PosY {
+		} else if cell.PosX == 50 && cell.PosY == 0 {
 			third = true
-		} else if cell.PosX == mainCell.PosX + mainCell.Width/2 && cell.PosY == mainCell.PosY + mainCell.Height/2 {
+		} else if cell.PosX == 50 && cell.PosY == 50 {
 			fourth = true
 		}
 	}
@@ -535,128 +587,274 @@ func TestDivideCell(t *testing.T) {
 	}
 }
 
-func TestMergeCell(t *testing.T) {
-	testCell1 := objects.NewCell("testCell1")
-	testCell1.PosX = 100
-	testCell1.PosY = 0
-	testCell1.Width = 100
-	testCell1.Height = 100
-
-	testCell2 := objects.NewCell("testCell2")
-	testCell2.PosX = 0
-	testCell2.PosY = 0
-	testCell2.Width = 100
-	testCell2.Height = 100
+func TestDivideCellMovesPlayersIntoChildren(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+1, 60, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort+2, 10, 60)
+	addPlayer(&cm, "localhost", firstUnusedPort+3, 60, 60)
+	addPlayer(&cm, "localhost", firstUnusedPort+4, 99, 99)
 
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(testCell1)
-	cm.AppendCell(testCell2)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
 
-	succeeded := cm.TryToMergeCell(testCell1)
+	cells := listCells(&cm, false)
+	expectedCounts := map[string]int32{
+		cellIdAt(&cm, 0, 0): 1, cellIdAt(&cm, 50, 0): 1, cellIdAt(&cm, 0, 50): 1, cellIdAt(&cm, 50, 50): 2,
+	}
+	for cellId, count := range expectedCounts {
+		if cells[cellId].PlayerCount != count {
+			fatalFail(errors.New("cell " + cellId + " did not get the players standing in it"))
+		}
+	}
 
-	if !succeeded {
-		fatalFail(errors.New("could not merge cell"))
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort + 1})
+	failIfNotNull(err, "could not locate player")
+	if location.CellId != cellIdAt(&cm, 50, 0) {
+		fatalFail(errors.New("player was moved into the wrong cell"))
 	}
+}
+
+func TestRegisteringAgainUpdatesPosition(t *testing.T) {
+	cm := createWorld(100, 100)
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	addPlayer(&cm, "localhost", firstUnusedPort, 80, 80)
 
-	if len(*cm.Cells) != 1 {
-		fatalFail(errors.New("cell did not merge but cell manager returned true"))
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort})
+	failIfNotNull(err, "could not locate player")
+	if location.CellId != cellIdAt(&cm, 50, 50) {
+		fatalFail(errors.New("the player was not moved to its last known position"))
+	}
+	if len(listCells(&cm, true)) != 5 || listCells(&cm, false)[location.CellId].PlayerCount != 1 {
+		fatalFail(errors.New("registering again added the player twice"))
 	}
+}
 
-	if (*cm.Cells)[0].PosX != 0 {
-		fatalFail(errors.New("x position not set correctly"))
+func TestDivideLockedCellFails(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{"initialCell"}})
+	failIfNotNull(err, "could not lock cells")
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	if err == nil || len(listCells(&cm, false)) != 1 {
+		fatalFail(errors.New("divided a locked cell"))
 	}
+}
 
-	if (*cm.Cells)[0].PosY != 0 {
-		fatalFail(errors.New("y position not set correctly"))
+func cellIdAt(cm *cellmanager.CellManager, posX int64, posY int64) string {
+	for _, cell := range listCells(cm, false) {
+		if cell.PosX == posX && cell.PosY == posY {
+			return cell.CellId
+		}
 	}
+	fatalFail(errors.New("no leaf at the given position"))
+	return ""
+}
+
+func createUnevenWorld() cellmanager.CellManager {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: cellIdAt(&cm, 0, 0)})
+	failIfNotNull(err, "could not divide cell")
+	return cm
+}
 
-	if (*cm.Cells)[0].Width != 200 {
-		fatalFail(errors.New("width not set correctly"))
+func neighbourPositions(cm *cellmanager.CellManager, cellId string) map[[2]int64]bool {
+	reply, err := cm.RequestCellNeighbours(context.Background(), &generated.CellNeighbourRequest{CellId: cellId})
+	failIfNotNull(err, "could not request neighbours")
+	if len(reply.CellId) != len(reply.Neighbours) || len(reply.CellId) != len(reply.SharesEdge) {
+		fatalFail(errors.New("neighbour lists differ in length"))
+	}
+	positions := make(map[[2]int64]bool, 0)
+	for index, neighbour := range reply.Neighbours {
+		if neighbour.CellId != reply.CellId[index] || !neighbour.IsLeaf {
+			fatalFail(errors.New("neighbour returned incorrectly"))
+		}
+		positions[[2]int64{neighbour.PosX, neighbour.PosY}] = reply.SharesEdge[index]
 	}
+	return positions
+}
+
+func TestRequestCellNeighboursAcrossDepths(t *testing.T) {
+	cm := createUnevenWorld()
 
-	if (*cm.Cells)[0].Height != 100 {
-		fatalFail(errors.New("height not set correctly"))
+	neighbours := neighbourPositions(&cm, cellIdAt(&cm, 50, 0))
+	expected := map[[2]int64]bool{{50, 50}: true, {25, 0}: true, {25, 25}: true, {0, 50}: false}
+	if len(neighbours) != len(expected) {
+		fatalFail(errors.New("wrong number of neighbours returned"))
+	}
+	for position, sharesEdge := range expected {
+		if returned, exists := neighbours[position]; !exists || returned != sharesEdge {
+			fatalFail(errors.New("neighbour missing or edge and corner mixed up"))
+		}
 	}
 }
 
-func TestMergeIncompatibleCells(t *testing.T) {
-	testCell1 := objects.NewCell("testCell1")
-	testCell1.PosX = 10
-	testCell1.PosY = 10
-	testCell1.Width = 100
-	testCell1.Height = 100
+func TestRequestCellNeighboursOfDeepCell(t *testing.T) {
+	cm := createUnevenWorld()
 
-	testCell2 := objects.NewCell("testCell2")
-	testCell2.PosX = 0
-	testCell2.PosY = 0
-	testCell2.Width = 100
-	testCell2.Height = 100
+	neighbours := neighbourPositions(&cm, cellIdAt(&cm, 25, 25))
+	expected := map[[2]int64]bool{{0, 25}: true, {25, 0}: true, {50, 0}: true, {0, 50}: true, {0, 0}: false, {50, 50}: false}
+	if len(neighbours) != len(expected) {
+		fatalFail(errors.New("wrong number of neighbours returned"))
+	}
+	for position, sharesEdge := range expected {
+		if returned, exists := neighbours[position]; !exists || returned != sharesEdge {
+			fatalFail(errors.New("neighbour missing or edge and corner mixed up"))
+		}
+	}
+}
 
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(testCell1)
-	cm.AppendCell(testCell2)
+func TestRequestCellNeighboursOfSplitCellFails(t *testing.T) {
+	cm := createUnevenWorld()
+	_, err := cm.RequestCellNeighbours(context.Background(), &generated.CellNeighbourRequest{CellId: "initialCell"})
+	if err == nil {
+		fatalFail(errors.New("returned neighbours of a cell that is not a leaf"))
+	}
+}
 
-	succeeded := cm.TryToMergeCell(testCell1)
+func expectCell(cell *generated.CellInfo, posX int64, posY int64, width int64, height int64) {
+	if cell.PosX != posX || cell.PosY != posY || cell.Width != width || cell.Height != height {
+		fatalFail(fmt.Errorf("expected cell at (%d, %d) of size (%d, %d), got (%d, %d) of size (%d, %d)",
+			posX, posY, width, height, cell.PosX, cell.PosY, cell.Width, cell.Height))
+	}
+}
 
-	if succeeded {
-		fatalFail(errors.New("merged cell when they do not align"))
+func TestRequestCellSizeChange(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	topLeft, bottomLeft, topRight, bottomRight := cellIdAt(&cm, 0, 0), cellIdAt(&cm, 0, 50), cellIdAt(&cm, 50, 0), cellIdAt(&cm, 50, 50)
+
+	reply, err := cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 30, NewHeight: 70})
+	failIfNotNull(err, "could not change cell size")
+	if !reply.Succeeded {
+		fatalFail(errors.New("succeeded == false but no error"))
+	}
+
+	cells := listCells(&cm, false)
+	expectCell(cells[topLeft], 0, 0, 30, 70)
+	expectCell(cells[bottomLeft], 0, 70, 30, 30)
+	expectCell(cells[topRight], 30, 0, 70, 70)
+	expectCell(cells[bottomRight], 30, 70, 70, 30)
+
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: bottomRight, NewWidth: 20, NewHeight: 40})
+	failIfNotNull(err, "could not change cell size")
+
+	cells = listCells(&cm, false)
+	expectCell(cells[topLeft], 0, 0, 80, 60)
+	expectCell(cells[bottomLeft], 0, 60, 80, 40)
+	expectCell(cells[topRight], 80, 0, 20, 60)
+	expectCell(cells[bottomRight], 80, 60, 20, 40)
+	for _, cell := range cells {
+		if cell.Locked {
+			fatalFail(errors.New("cell left locked after size change"))
+		}
 	}
 }
 
-func TestMergeCellsInformsCellMasterOfMergedCell(t *testing.T) {
-	cellMaster := objects.Client{Ip: "localhost", Port: 8889}
+func TestRequestCellSizeChangeMovesPlayersAcrossBorders(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(&cm, "testIp", 1337, 40, 40)
+	addPlayer(&cm, "testIp", 1338, 60, 60)
+	topLeft, bottomRight := cellIdAt(&cm, 0, 0), cellIdAt(&cm, 50, 50)
+
+	// the border moves past the first player, into the bottom right cell
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 30, NewHeight: 30})
+	failIfNotNull(err, "could not change cell size")
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "testIp", Port: 1337})
+	failIfNotNull(err, "could not locate player")
+	if !location.Found || location.CellId != bottomRight {
+		fatalFail(errors.New("player was not moved into the cell that now holds it"))
+	}
+
+	// and back past both players, into the top left cell
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 70, NewHeight: 70})
+	failIfNotNull(err, "could not change cell size")
+	players, err := cm.ListPlayersInCell(context.Background(), &generated.ListPlayersRequest{CellId: topLeft})
+	failIfNotNull(err, "could not list players")
+	if len(players.Port) != 2 {
+		fatalFail(errors.New(fmt.Sprintf("expected both players in the grown cell, got %d", len(players.Port))))
+	}
+	expectPlayerCounts(&cm)
+}
+
+func TestRequestCellSizeChangeFailsIfNeighbourIsLocked(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	topLeft, bottomRight := cellIdAt(&cm, 0, 0), cellIdAt(&cm, 50, 50)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: []string{bottomRight}, SenderCellId: "other"})
+	failIfNotNull(err, "could not lock cells")
+
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 30, NewHeight: 70})
+	if err == nil {
+		fatalFail(errors.New("changed size while a neighbour was locked"))
+	}
 
-	testCell1 := objects.NewCell("testCell1")
-	testCell1.CellMaster = &cellMaster
-	testCell1.PosX = 100
-	testCell1.PosY = 0
-	testCell1.Width = 100
-	testCell1.Height = 100
+	cells := listCells(&cm, false)
+	expectCell(cells[topLeft], 0, 0, 50, 50)
+	if cells[topLeft].Locked || !cells[bottomRight].Locked {
+		fatalFail(errors.New("lock state changed by failed size change"))
+	}
+}
 
-	testCell2 := objects.NewCell("testCell2")
-	testCell2.PosX = 0
-	testCell2.PosY = 0
-	testCell2.Width = 100
-	testCell2.Height = 100
+func TestRequestCellSizeChangeRejectsInvalidSizes(t *testing.T) {
+	cm := createUnevenWorld()
+	topRight := cellIdAt(&cm, 50, 0)
+	invalidRequests := []*generated.CellChangeSizeRequest{
+		{CellId: "initialCell", NewWidth: 50, NewHeight: 50},
+		{CellId: topRight, NewWidth: 0, NewHeight: 50},
+		{CellId: topRight, NewWidth: 50, NewHeight: 100},
+		// the top left cell is split, so its borders can not be moved
+		{CellId: topRight, NewWidth: 40, NewHeight: 40},
+	}
+	for _, request := range invalidRequests {
+		if _, err := cm.RequestCellSizeChange(context.Background(), request); err == nil {
+			fatalFail(errors.New("invalid size change was performed"))
+		}
+	}
+}
 
-	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8889))
+func TestRequestCellSizeChangeInformsCellMasters(t *testing.T) {
+	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8886))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-
 	playerServer := grpc.NewServer()
 	cellMasterServer := objects.NewPlayer(1, 1)
-	(*cellMasterServer.Cells)[testCell1.CellId] = testCell1
+	cellMasterServer.Ip = "localhost"
+	cellMasterServer.Port = 8886
 	objects2.RegisterPlayerServer(playerServer, cellMasterServer)
 	go func() {
-		if err := playerServer.Serve(lis); err != nil && err.Error() != "the server has been stopped" {
+		if err := playerServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			log.Fatalf("failed to serve %v", err)
 		}
 	}()
+	defer playerServer.Stop()
 
-	cm := cellmanager.NewCellManager()
-	cm.AppendCell(testCell1)
-	cm.AppendCell(testCell2)
-
-	succeeded := cm.TryToMergeCell(testCell1)
+	cm := createWorld(100, 100)
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	topLeft, topRight := cellIdAt(&cm, 0, 0), cellIdAt(&cm, 50, 0)
+	addPlayer(&cm, "localhost", 8886, 60, 10)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: topRight})
+	failIfNotNull(err, "could not select cell master")
 
-	if !succeeded {
-		fatalFail(errors.New("could not merge cell"))
-	}
-
-	if (*cellMasterServer.Cells)[testCell1.CellId].PosX != 0 {
-		fatalFail(errors.New("x position not set correctly"))
-	}
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 30, NewHeight: 70})
+	failIfNotNull(err, "could not change cell size")
 
-	if (*cellMasterServer.Cells)[testCell1.CellId].PosY != 0 {
-		fatalFail(errors.New("y position not set correctly"))
+	ownedCell := cellMasterServer.Cells
+	if ownedCell == nil || ownedCell.CellId != topRight {
+		fatalFail(errors.New("cell master was not informed of the size change"))
 	}
-
-	if (*cellMasterServer.Cells)[testCell1.CellId].Width != 200 {
-		fatalFail(errors.New("width not set correctly"))
-	}
-
-	if (*cellMasterServer.Cells)[testCell1.CellId].Height != 100 {
-		fatalFail(errors.New("height not set correctly"))
+	if ownedCell.PosX != 30 || ownedCell.PosY != 0 || ownedCell.Width != 70 || ownedCell.Height != 70 {
+		fatalFail(errors.New("cell master received wrong cell size"))
 	}
 }
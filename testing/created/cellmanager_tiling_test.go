@@ -0,0 +1,96 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"math/rand"
+	"testing"
+)
+
+func expectExactTiling(cm *cellmanager.CellManager) {
+	if violations := cm.TilingViolations(); len(violations) != 0 {
+		fatalFail(errors.New(fmt.Sprintf("the leaves do not tile the world: %v", violations)))
+	}
+}
+
+func TestDivideOddCellTilesExactly(t *testing.T) {
+	cm := createWorld(5, 7)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	expectExactTiling(&cm)
+
+	cells := listCells(&cm, false)
+	expectCell(cells[cellIdAt(&cm, 0, 0)], 0, 0, 2, 3)
+	expectCell(cells[cellIdAt(&cm, 0, 3)], 0, 3, 2, 4)
+	expectCell(cells[cellIdAt(&cm, 2, 0)], 2, 0, 3, 3)
+	expectCell(cells[cellIdAt(&cm, 2, 3)], 2, 3, 3, 4)
+
+	// every position is in exactly one leaf, so players on the borders end up where they stand
+	addPlayer(&cm, "localhost", firstUnusedPort, 2, 3)
+	location, err := cm.LocatePlayer(context.Background(), &generated.LocatePlayerRequest{Ip: "localhost", Port: firstUnusedPort})
+	failIfNotNull(err, "could not locate player")
+	if location.CellId != cellIdAt(&cm, 2, 3) {
+		fatalFail(errors.New("player on a border was placed in the wrong cell"))
+	}
+}
+
+func TestRepeatedSplitsOfOddCellsTileExactly(t *testing.T) {
+	cm := createWorld(37, 23)
+	random := rand.New(rand.NewSource(13))
+	for i := 0; i < 40; i++ {
+		leaves := leafIds(&cm)
+		mode := generated.SplitMode(random.Intn(2))
+		cm.DivideCell(context.Background(), &generated.CellRequest{CellId: leaves[random.Intn(len(leaves))], SplitMode: mode})
+		expectExactTiling(&cm)
+	}
+
+	reply, err := cm.VerifyTiling(context.Background(), &generated.VerifyTilingRequest{})
+	failIfNotNull(err, "could not verify tiling")
+	if !reply.Valid {
+		fatalFail(errors.New("a valid tree was reported as invalid"))
+	}
+}
+
+func TestMinCellSize(t *testing.T) {
+	cm := createWorld(16, 16)
+	if cm.SetMinCellSize(0) == nil {
+		fatalFail(errors.New("a minimum cell size of 0 was accepted"))
+	}
+	failIfNotNull(cm.SetMinCellSize(5), "could not set minimum cell size")
+
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	topLeft := cellIdAt(&cm, 0, 0)
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: topLeft})
+	if err == nil {
+		fatalFail(errors.New("a cell was split into cells below the minimum size"))
+	}
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: topLeft, SplitMode: generated.SplitMode_BALANCED_HALVES})
+	if err == nil {
+		fatalFail(errors.New("a cell was cut into halves below the minimum size"))
+	}
+
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 12, NewHeight: 8})
+	if err == nil {
+		fatalFail(errors.New("a resize left a neighbour below the minimum size"))
+	}
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 11, NewHeight: 8})
+	failIfNotNull(err, "could not resize cell")
+	expectExactTiling(&cm)
+}
+
+func TestTilingViolationsFindsOverlap(t *testing.T) {
+	cm := createWorld(100, 100)
+	_, err := cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	cm.CellTree.Children[0].Width++
+	reply, err := cm.VerifyTiling(context.Background(), &generated.VerifyTilingRequest{})
+	failIfNotNull(err, "could not verify tiling")
+	if reply.Valid || len(reply.Violations) == 0 {
+		fatalFail(errors.New("overlapping cells were not found"))
+	}
+}
@@ -0,0 +1,141 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"os"
+	"testing"
+)
+
+// expectStale fails unless the error rejects a message as stale and points at the owner.
+func expectStale(err error, ownerCellId string, ownerVersion int64, ownerPort int32) {
+	stale, ok := objects.StaleTopologyOf(err)
+	if !ok {
+		fatalFail(errors.New(fmt.Sprintf("expected a stale topology error, got %v", err)))
+	}
+	if stale.OwnerCellId != ownerCellId || stale.OwnerTopologyVersion != ownerVersion || stale.OwnerPort != ownerPort {
+		fatalFail(errors.New(fmt.Sprintf(
+			"expected the owner to be cell %s at version %d on port %d, got %v", ownerCellId, ownerVersion, ownerPort, stale,
+		)))
+	}
+}
+
+func TestTopologyVersionsChangeOnSplitAndMerge(t *testing.T) {
+	dataDir := createDataDir()
+	defer os.RemoveAll(dataDir)
+
+	cm := createPersistentCellManager(dataDir, 1000)
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	created := listCells(&cm, false)["initialCell"].TopologyVersion
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	cells := listCells(&cm, true)
+	split := cells["0"].TopologyVersion
+	if split <= created || cells["3"].TopologyVersion != split || cells["initialCell"].TopologyVersion != created {
+		fatalFail(errors.New("the children of a split cell did not get a new topology version"))
+	}
+
+	cm.PerformMerge("initialCell")
+	merged := listCells(&cm, false)["initialCell"].TopologyVersion
+	if merged <= split {
+		fatalFail(errors.New("a merged cell kept the topology version it had before it was split"))
+	}
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	resplit := listCells(&cm, false)["0"].TopologyVersion
+	if resplit <= merged {
+		fatalFail(errors.New("a cell split again has the topology version of the cell it replaces"))
+	}
+
+	// the cell master is found with the address to send to it with
+	addPlayer(&cm, "localhost", firstUnusedPort, 10, 10)
+	reply, err := cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "0"})
+	failIfNotNull(err, "could not request cell master")
+	if reply.CellId != "0" || reply.TopologyVersion != resplit {
+		fatalFail(errors.New("the cell master reply does not address the cell at its topology version"))
+	}
+
+	failIfNotNull(cm.ClosePersistence(), "could not close persistence")
+	recovered := createPersistentCellManager(dataDir, 1000)
+	if listCells(&recovered, false)["0"].TopologyVersion != resplit {
+		fatalFail(errors.New("the topology version of a cell changed with the restart"))
+	}
+	_, err = recovered.DivideCell(context.Background(), &generated.CellRequest{CellId: "0"})
+	failIfNotNull(err, "could not divide cell")
+	if listCells(&recovered, false)["00"].TopologyVersion <= resplit {
+		fatalFail(errors.New("the topology version went back after the restart"))
+	}
+}
+
+func TestCellMasterRejectsStaleAddresses(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", watchPort), grpc.WithInsecure())
+	failIfNotNull(err, "could not connect to the cell manager")
+	defer conn.Close()
+
+	_, err = cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	split := listCells(cm, false)["0"].TopologyVersion
+
+	player := objects.NewPlayer(100, 1)
+	player.Ip, player.Port = "localhost", int(firstUnusedPort)
+	player.SetCellManager(generated.NewCellManagerClient(conn))
+	_, err = player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells: []*objects2.Cell{{CellId: "0", Width: 50, Height: 50, TopologyVersion: split}},
+	})
+	failIfNotNull(err, "could not receive cell mastership")
+
+	addressed := createSingleObject("key", "value", "id", "0")
+	addressed.PosX, addressed.PosY, addressed.TopologyVersion = 10, 10, split
+	_, err = player.RequestObjectMutation(context.Background(), addressed)
+	failIfNotNull(err, "could not request a mutation of the owned cell")
+
+	// the cell is merged and the cell master is granted the merged cell
+	cm.PerformMerge("initialCell")
+	merged := listCells(cm, false)["initialCell"].TopologyVersion
+	_, err = player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells: []*objects2.Cell{{CellId: "initialCell", Width: 100, Height: 100, TopologyVersion: merged}},
+	})
+	failIfNotNull(err, "could not receive cell mastership")
+
+	stale := createSingleObject("key", "value", "id", "0")
+	stale.PosX, stale.PosY, stale.TopologyVersion = 10, 10, split
+	_, err = player.RequestObjectMutation(context.Background(), stale)
+	expectStale(err, "initialCell", merged, firstUnusedPort)
+	_, err = player.SubscribePlayer(context.Background(), &objects2.PlayerInfo{
+		Ip: "localhost", Port: firstUnusedPort + 1, PosX: 10, PosY: 10, CellId: "0", TopologyVersion: split,
+	})
+	expectStale(err, "initialCell", merged, firstUnusedPort)
+	if len(player.TakeMutatingObjects()) != 1 {
+		fatalFail(errors.New("a stale mutation was queued"))
+	}
+
+	// after another split the owner of a position outside the owned cell is looked up at the cell manager
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	addPlayer(cm, "localhost", firstUnusedPort+2, 60, 60)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: "3"})
+	failIfNotNull(err, "could not request cell master")
+	resplit := listCells(cm, false)["3"].TopologyVersion
+	_, err = player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells: []*objects2.Cell{{CellId: "0", Width: 50, Height: 50, TopologyVersion: resplit}},
+	})
+	failIfNotNull(err, "could not receive cell mastership")
+
+	mutated := createSingleObject("key", "value", "id", "initialCell")
+	mutated.PosX, mutated.PosY, mutated.TopologyVersion = 60, 60, merged
+	_, err = player.BroadcastMutatedObjects(context.Background(), &objects2.MultipleObjects{
+		Objects: []*objects2.SingleObject{mutated},
+	})
+	expectStale(err, "3", resplit, firstUnusedPort+2)
+}
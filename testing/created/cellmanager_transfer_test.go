@@ -0,0 +1,183 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"testing"
+	"time"
+)
+
+const transferPort = 8940
+
+// queueObjectsAt requests a mutation of an object at the cell master for every id, at the given positions.
+func queueObjectsAt(player *objects.Player, positions map[string][2]int64) {
+	for id, position := range positions {
+		object := createSingleObject("key", "value", id, "")
+		object.PosX, object.PosY = position[0], position[1]
+		_, err := player.RequestObjectMutation(context.Background(), object)
+		failIfNotNull(err, "could not request object mutation")
+	}
+}
+
+// awaitObjects takes the queued mutations of the cell master until it has the expected number of them or a second
+// has passed.
+func awaitObjects(player *objects.Player, count int) []*objects2.SingleObject {
+	taken := player.TakeMutatingObjects()
+	for deadline := time.Now().Add(time.Second); len(taken) < count && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		taken = append(taken, player.TakeMutatingObjects()...)
+	}
+	return taken
+}
+
+// expectObjects fails unless the objects are exactly the ones with the ids, each once and addressed to the cell.
+func expectObjects(taken []*objects2.SingleObject, cellId string, ids ...string) {
+	seen := make(map[string]int, 0)
+	for _, object := range taken {
+		if object.CellId != cellId {
+			fatalFail(errors.New(fmt.Sprintf("object %s is addressed to %s, not %s", object.ObjectId, object.CellId, cellId)))
+		}
+		seen[object.ObjectId]++
+	}
+	if len(taken) != len(ids) {
+		fatalFail(errors.New(fmt.Sprintf("expected the objects %v in cell %s, got %v", ids, cellId, seen)))
+	}
+	for _, id := range ids {
+		if seen[id] != 1 {
+			fatalFail(errors.New(fmt.Sprintf("expected the objects %v in cell %s, got %v", ids, cellId, seen)))
+		}
+	}
+}
+
+// startGrantedCellMaster starts the player at the port and grants it the leaf it is in.
+func startGrantedCellMaster(cm *cellmanager.CellManager, port int, posX int64, posY int64) (*objects.Player, func()) {
+	cellId := cellIdAt(cm, posX-posX%50, posY-posY%50)
+	addPlayer(cm, "localhost", int32(port), posX, posY)
+	epoch := grantCellMaster(cm, cellId, int32(port))
+	info := listCells(cm, false)[cellId]
+	cell := claimedCell(cellId, info.PosX, info.PosY, info.Width, info.Height)
+	cell.Epoch, cell.TopologyVersion = epoch, info.TopologyVersion
+
+	player, playerServer := startCellMaster(port, cell)
+	player.PosX, player.PosY = posX, posY
+	return player, playerServer.Stop
+}
+
+func TestSplitPartitionsObjectsAmongChildren(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+
+	first, stopFirst := startGrantedCellMaster(cm, transferPort, 10, 10)
+	defer stopFirst()
+	addPlayer(cm, "localhost", transferPort+1, 60, 60)
+	second, secondServer := startCellMaster(transferPort+1, nil)
+	defer secondServer.Stop()
+	second.PosX, second.PosY = 60, 60
+	queueObjectsAt(first, map[string][2]int64{
+		"topLeft": {10, 10}, "alsoTopLeft": {20, 30}, "bottomRight": {60, 60}, "alsoBottomRight": {90, 70},
+		"topRight": {60, 10}, "bottomLeft": {10, 60},
+	})
+
+	if !cm.PerformSplit("initialCell") {
+		fatalFail(errors.New("the cell was not split"))
+	}
+	topLeft, bottomRight := cellIdAt(cm, 0, 0), cellIdAt(cm, 50, 50)
+	expectObjects(first.TakeMutatingObjects(), topLeft, "topLeft", "alsoTopLeft")
+	expectObjects(awaitObjects(second, 2), bottomRight, "bottomRight", "alsoBottomRight")
+
+	// the objects in children without players are held until the children are granted
+	held := []struct {
+		posX, posY int64
+		id         string
+	}{{60, 10, "topRight"}, {10, 60, "bottomLeft"}}
+	for index, object := range held {
+		port := transferPort + 2 + index
+		addPlayer(cm, "localhost", int32(port), object.posX, object.posY)
+		player, playerServer := startCellMaster(port, nil)
+		defer playerServer.Stop()
+		_, err := cm.RequestCellMasterWithPositions(context.Background(), &generated.Position{PosX: object.posX, PosY: object.posY})
+		failIfNotNull(err, "could not request cell master")
+		expectObjects(awaitObjects(player, 1), cellIdAt(cm, object.posX-object.posX%50, object.posY-object.posY%50), object.id)
+	}
+}
+
+func TestMergeUnionsObjectsOfChildren(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	first, stopFirst := startGrantedCellMaster(cm, transferPort, 10, 10)
+	defer stopFirst()
+	second, stopSecond := startGrantedCellMaster(cm, transferPort+1, 60, 60)
+	defer stopSecond()
+	queueObjectsAt(first, map[string][2]int64{"topLeft": {10, 10}, "movedAway": {60, 10}})
+	queueObjectsAt(second, map[string][2]int64{"bottomRight": {60, 60}, "alsoBottomRight": {90, 90}})
+
+	if !cm.PerformMerge("initialCell") {
+		fatalFail(errors.New("the cell was not merged"))
+	}
+	merged, replaced := first, second
+	if cell, owned := second.OwnedCell(); owned && cell.CellId == "initialCell" {
+		merged, replaced = second, first
+	}
+	if cell, owned := merged.OwnedCell(); !owned || cell.CellId != "initialCell" {
+		fatalFail(errors.New("neither cell master was granted the merged cell"))
+	}
+	expectObjects(awaitObjects(merged, 4), "initialCell", "topLeft", "movedAway", "bottomRight", "alsoBottomRight")
+	if left := replaced.TakeMutatingObjects(); len(left) != 0 {
+		fatalFail(errors.New(fmt.Sprintf("the old cell master kept %d objects of the merged cell", len(left))))
+	}
+}
+
+func TestHeldObjectsAreRetriedByThePlanner(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+
+	first, stopFirst := startGrantedCellMaster(cm, transferPort, 10, 10)
+	defer stopFirst()
+	// the player in the other child is not running yet and can not be primed
+	addPlayer(cm, "localhost", transferPort+1, 60, 60)
+	queueObjectsAt(first, map[string][2]int64{"bottomRight": {60, 60}})
+	if !cm.PerformSplit("initialCell") {
+		fatalFail(errors.New("the cell was not split"))
+	}
+
+	// the child is granted without its held objects being passed on
+	bottomRight := cellIdAt(cm, 50, 50)
+	epoch := grantCellMaster(cm, bottomRight, transferPort+1)
+	info := listCells(cm, false)[bottomRight]
+	cell := claimedCell(bottomRight, info.PosX, info.PosY, info.Width, info.Height)
+	cell.Epoch, cell.TopologyVersion = epoch, info.TopologyVersion
+	second, secondServer := startCellMaster(transferPort+1, cell)
+	defer secondServer.Stop()
+
+	cm.UpdateTopology()
+	expectObjects(awaitObjects(second, 1), bottomRight, "bottomRight")
+}
+
+func TestRepeatedDeliveryIsDropped(t *testing.T) {
+	player := objects.NewPlayer(100, 1)
+	player.Cells = claimedCell("initialCell", 0, 0, 100, 100)
+
+	// the first object is delivered again after a delivery that timed out, the second is the next one of the release
+	for _, sequence := range []int32{0, 0, 1} {
+		object := createSingleObject("key", "value", "delivered"+fmt.Sprint(sequence), "")
+		object.PosX, object.PosY = 10, 10
+		object.Released = &objects2.ReleaseTag{CellId: "released", Epoch: 3, Sequence: sequence}
+		_, err := player.RequestObjectMutation(context.Background(), object)
+		failIfNotNull(err, "could not deliver object")
+	}
+	expectObjects(player.TakeMutatingObjects(), "initialCell", "delivered0", "delivered1")
+}
@@ -0,0 +1,137 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	"google.golang.org/grpc"
+	"log"
+	"net"
+	"testing"
+)
+
+const watchPort = 8860
+
+func startWatchedCellManager() (*cellmanager.CellManager, *grpc.Server) {
+	cm := cellmanager.NewCellManager()
+	lis, err := net.Listen("tcp", ":"+fmt.Sprint(watchPort))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	generated.RegisterCellManagerServer(server, &cm)
+	go func() {
+		if err := server.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			log.Fatalf("failed to serve %v", err)
+		}
+	}()
+	return &cm, server
+}
+
+func watchTopology(ctx context.Context) generated.CellManager_WatchTopologyClient {
+	conn, err := grpc.Dial("localhost:"+fmt.Sprint(watchPort), grpc.WithInsecure())
+	failIfNotNull(err, "could not connect to the cell manager")
+	stream, err := generated.NewCellManagerClient(conn).WatchTopology(ctx, &generated.WatchTopologyRequest{})
+	failIfNotNull(err, "could not watch the topology")
+	return stream
+}
+
+// expectEvent receives the next event and checks its type and that its version follows the given one.
+func expectEvent(stream generated.CellManager_WatchTopologyClient, eventType generated.TopologyEventType, version int64) *generated.TopologyEvent {
+	event, err := stream.Recv()
+	failIfNotNull(err, "could not receive topology event")
+	if event.Type != eventType {
+		fatalFail(errors.New(fmt.Sprintf("expected a %v event, got %v", eventType, event.Type)))
+	}
+	if event.Version != version+1 {
+		fatalFail(errors.New(fmt.Sprintf("expected version %d, got %d", version+1, event.Version)))
+	}
+	return event
+}
+
+func TestWatchTopology(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := watchTopology(ctx)
+
+	snapshot, err := stream.Recv()
+	failIfNotNull(err, "could not receive topology snapshot")
+	if snapshot.Type != generated.TopologyEventType_SNAPSHOT || len(snapshot.Cells) != 0 {
+		fatalFail(errors.New("the first event is not a snapshot of the empty world"))
+	}
+
+	_, err = cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	created := expectEvent(stream, generated.TopologyEventType_WORLD_CREATED, snapshot.Version)
+
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+	split := expectEvent(stream, generated.TopologyEventType_SPLIT, created.Version)
+	if split.CellId != "initialCell" || len(split.Cells) != 5 || split.Cells[0].IsLeaf {
+		fatalFail(errors.New("the split event does not hold the split cell and its children"))
+	}
+
+	ids := leafIds(cm)
+	_, err = cm.LockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[:2], SenderCellId: "tester"})
+	failIfNotNull(err, "could not lock cells")
+	locked := expectEvent(stream, generated.TopologyEventType_LOCKED, split.Version)
+	if len(locked.Cells) != 2 || !locked.Cells[0].Locked {
+		fatalFail(errors.New("the lock event does not hold the locked cells"))
+	}
+	_, err = cm.UnlockCells(context.Background(), &generated.LockCellsRequest{CellId: ids[:2], SenderCellId: "tester"})
+	failIfNotNull(err, "could not unlock cells")
+	unlocked := expectEvent(stream, generated.TopologyEventType_UNLOCKED, locked.Version)
+
+	topLeft := cellIdAt(cm, 0, 0)
+	_, err = cm.RequestCellSizeChange(context.Background(), &generated.CellChangeSizeRequest{CellId: topLeft, NewWidth: 60, NewHeight: 40})
+	failIfNotNull(err, "could not resize cell")
+	resized := expectEvent(stream, generated.TopologyEventType_RESIZE, unlocked.Version)
+	if len(resized.Cells) != 4 {
+		fatalFail(errors.New("the resize event does not hold every moved sibling"))
+	}
+
+	addPlayer(cm, "localhost", firstUnusedPort, 10, 10)
+	_, err = cm.RequestCellMaster(context.Background(), &generated.CellMasterRequest{CellId: topLeft})
+	failIfNotNull(err, "could not request cell master")
+	changed := expectEvent(stream, generated.TopologyEventType_CELL_MASTER_CHANGED, resized.Version)
+	if changed.CellId != topLeft || changed.Cells[0].CellMasterPort != firstUnusedPort {
+		fatalFail(errors.New("the cell master event does not hold the new cell master"))
+	}
+
+	_, err = cm.UnregisterCellMaster(context.Background(), &generated.CellMasterRequest{CellId: topLeft})
+	failIfNotNull(err, "could not unregister cell master")
+	unset := expectEvent(stream, generated.TopologyEventType_CELL_MASTER_CHANGED, changed.Version)
+	if unset.Cells[0].CellMasterIp != "" {
+		fatalFail(errors.New("the cell master event still holds the unregistered cell master"))
+	}
+
+	// the merged cells are locked while their new cell master is primed
+	cm.PerformMerge("initialCell")
+	handedOver := expectEvent(stream, generated.TopologyEventType_LOCKED, unset.Version)
+	released := expectEvent(stream, generated.TopologyEventType_UNLOCKED, handedOver.Version)
+	merged := expectEvent(stream, generated.TopologyEventType_MERGE, released.Version)
+	if merged.CellId != "initialCell" || len(merged.Cells) != 1 || !merged.Cells[0].IsLeaf {
+		fatalFail(errors.New("the merge event does not hold the merged cell"))
+	}
+}
+
+func TestWatchTopologyStartsWithSnapshot(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+	_, err = cm.DivideCell(context.Background(), &generated.CellRequest{CellId: "initialCell"})
+	failIfNotNull(err, "could not divide cell")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	snapshot, err := watchTopology(ctx).Recv()
+	failIfNotNull(err, "could not receive topology snapshot")
+	if snapshot.Type != generated.TopologyEventType_SNAPSHOT || len(snapshot.Cells) != 5 || snapshot.Version != 2 {
+		fatalFail(errors.New("the snapshot does not describe the current tree"))
+	}
+}
@@ -0,0 +1,116 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/cmd/constants"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	objects2 "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"testing"
+)
+
+// broadcastAt broadcasts a mutation of the keys of the object at the position, addressed to the cell.
+func broadcastAt(player *objects.Player, cellId string, id string, posX int64, posY int64, keysAndValues ...string) {
+	object := &objects2.SingleObject{ObjectId: id, CellId: cellId, PosX: posX, PosY: posY}
+	for index := 0; index+1 < len(keysAndValues); index += 2 {
+		object.UpdateKey = append(object.UpdateKey, keysAndValues[index])
+		object.NewValue = append(object.NewValue, keysAndValues[index+1])
+	}
+	_, err := player.BroadcastMutatedObjects(context.Background(), &objects2.MultipleObjects{
+		Objects: []*objects2.SingleObject{object},
+	})
+	failIfNotNull(err, "could not broadcast mutated objects")
+}
+
+// expectState fails unless the objects hold exactly the values, given as object id to key to value.
+func expectState(state []*objects2.SingleObject, expected map[string]map[string]string) {
+	if len(state) != len(expected) {
+		fatalFail(errors.New(fmt.Sprintf("expected %d objects in the cell state, got %d", len(expected), len(state))))
+	}
+	for _, object := range state {
+		values, ok := expected[object.ObjectId]
+		if !ok || len(object.UpdateKey) != len(values) {
+			fatalFail(errors.New(fmt.Sprintf("unexpected object %v in the cell state", object)))
+		}
+		for index, key := range object.UpdateKey {
+			if values[key] != object.NewValue[index] {
+				fatalFail(errors.New(fmt.Sprintf("expected %s of %s to be %s, got %s", key, object.ObjectId, values[key], object.NewValue[index])))
+			}
+		}
+	}
+}
+
+func TestCellStateHoldsLatestValues(t *testing.T) {
+	player, playerServer := startCellMaster(transferPort, nil)
+	defer playerServer.Stop()
+	_, err := player.ReceiveCellMastership(context.Background(), &objects2.CellList{
+		Cells: []*objects2.Cell{{CellId: "cellId", Width: 100, Height: 100, Epoch: 3, TopologyVersion: 2}},
+	})
+	failIfNotNull(err, "could not receive cell mastership")
+
+	broadcastAt(player, "cellId", "moved", 10, 10, "icon", "a", "health", "10")
+	broadcastAt(player, "cellId", "moved", 20, 20, "icon", "b")
+	broadcastAt(player, "cellId", "still", 30, 30, "icon", "c")
+	broadcastAt(player, "cellId", "removed", 40, 40, "icon", "d")
+	broadcastAt(player, "cellId", "removed", 40, 40, constants.RemovedKey, "")
+	broadcastAt(player, "cellId", "left", 50, 50, "icon", "e")
+	broadcastAt(player, "", "left", 150, 50, "icon", "e")
+
+	state, err := player.GetCellState(context.Background(), &objects2.Cell{CellId: "cellId", TopologyVersion: 2})
+	failIfNotNull(err, "could not get cell state")
+	expectState(state.Objects, map[string]map[string]string{
+		"moved": {"icon": "b", "health": "10"},
+		"still": {"icon": "c"},
+	})
+	if state.Epoch != 3 || state.Objects[0].ObjectId != "moved" || state.Objects[0].PosX != 20 {
+		fatalFail(errors.New("the cell state does not hold the latest positions in order with the epoch"))
+	}
+
+	// the snapshot is a copy and requests for a replaced version of the cell are rejected
+	state.Objects[0].NewValue[0] = "changed"
+	again, err := player.GetCellState(context.Background(), &objects2.Cell{})
+	failIfNotNull(err, "could not get cell state")
+	if again.Objects[0].NewValue[0] != "b" {
+		fatalFail(errors.New("a snapshot of the cell state changed the cell state"))
+	}
+	if _, err := player.GetCellState(context.Background(), &objects2.Cell{CellId: "cellId", TopologyVersion: 1}); err == nil {
+		fatalFail(errors.New("the state of a replaced version of the cell was returned"))
+	}
+}
+
+func TestCellStateIsHandedOverOnSplit(t *testing.T) {
+	cm, server := startWatchedCellManager()
+	defer server.Stop()
+	_, err := cm.SetWorldSize(context.Background(), &generated.WorldSize{Width: 100, Height: 100})
+	failIfNotNull(err, "could not set world size")
+
+	first, stopFirst := startGrantedCellMaster(cm, transferPort, 10, 10)
+	defer stopFirst()
+	addPlayer(cm, "localhost", transferPort+1, 60, 60)
+	second, secondServer := startCellMaster(transferPort+1, nil)
+	defer secondServer.Stop()
+	second.PosX, second.PosY = 60, 60
+	broadcastAt(first, "initialCell", "stays", 10, 10, "icon", "a")
+	broadcastAt(first, "initialCell", "goes", 60, 60, "icon", "b", "health", "5")
+	broadcastAt(first, "initialCell", "goes", 70, 70, "icon", "c")
+
+	if !cm.PerformSplit("initialCell") {
+		fatalFail(errors.New("the cell was not split"))
+	}
+	state, err := first.GetCellState(context.Background(), &objects2.Cell{})
+	failIfNotNull(err, "could not get cell state")
+	expectState(state.Objects, map[string]map[string]string{"stays": {"icon": "a"}})
+
+	// the new cell master stores the handed over object once it has applied it
+	handedOver := awaitObjects(second, 1)
+	_, err = second.BroadcastMutatedObjects(context.Background(), &objects2.MultipleObjects{Objects: handedOver})
+	failIfNotNull(err, "could not broadcast mutated objects")
+	state, err = second.GetCellState(context.Background(), &objects2.Cell{})
+	failIfNotNull(err, "could not get cell state")
+	expectState(state.Objects, map[string]map[string]string{"goes": {"icon": "c", "health": "5"}})
+	if state.Objects[0].PosX != 70 || state.Objects[0].CellId != cellIdAt(cm, 50, 50) {
+		fatalFail(errors.New("the handed over object is not stored at its latest position in the new cell"))
+	}
+}
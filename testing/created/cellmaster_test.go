@@ -13,16 +13,20 @@ import (
 	"testing"
 )
 
-func createSingleObject(propertyKey string, newValue string, id string, cellId string) generated.SingleObject {
+func createSingleObject(propertyKey string, newValue string, id string, cellId string) *generated.SingleObject {
 	objIds := []string{propertyKey}
 	newValues := []string{newValue}
-	return generated.SingleObject{ObjectId: id, UpdateKey: objIds, NewValue: newValues, CellId: cellId, PosY: 0, PosX: 0}
+	return &generated.SingleObject{ObjectId: id, UpdateKey: objIds, NewValue: newValues, CellId: cellId, PosY: 0, PosX: 0}
 }
 
 func TestSendUpdate(t *testing.T) {
 	cm := objects.NewPlayer(1, 1)
+	cell := objects.NewCell("cellId")
+	cell.Height = 100
+	cell.Width = 100
+	cm.Cells = &cell
 	obj := createSingleObject("key", "value", "key2", "cellId", )
-	_, err := cm.RequestObjectMutation(context.Background(), &obj)
+	_, err := cm.RequestObjectMutation(context.Background(), obj)
 
 	failIfNotNull(err, "could not update cellmaster")
 	if (*cm.MutatingObjects)[0].UpdateKey[0] == "key" {
@@ -35,7 +39,7 @@ func TestReceiveCellMasterShip(t *testing.T) {
 	cm := objects.NewPlayer(1, 1)
 	cl := generated.CellList{Cells: []*generated.Cell{{CellId: "cellid", PosY: 1, PosX: 1, Width: 1, Height: 1}}}
 	cm.ReceiveCellMastership(context.Background(), &cl)
-	if _, ok := (*cm.Cells)["cellid"]; ok {
+	if cm.Cells != nil && cm.Cells.CellId == "cellid" {
 		return
 	}
 	log.Fatalf("receive cell mastership does not work. ")
@@ -116,7 +120,7 @@ func TestRequestObjectMutationSetToCorrectCellId(t *testing.T) {
 	cell.PosY = 0
 	cell.Height = 100
 	cell.Width = 100
-	(*cm.Cells)["testcell"] = cell
+	cm.Cells = &cell
 
 	_, err := cm.RequestObjectMutation(context.Background(), &generated.SingleObject{ObjectId: "test", PosX: 50, PosY: 50})
 	failIfNotNull(err, "Failed RequestObjectMutation")
@@ -149,13 +153,8 @@ func TestSubscribePlayerToSingleCell(t *testing.T) {
 	cell1.PosY = 0
 	cell1.Height = 100
 	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
+	cm.Cells = &cell1
 	cell2 := objects.NewCell("cell2")
-	cell2.PosX = 50
-	cell2.PosY = 0
-	cell2.Height = 100
-	cell2.Width = 100
-	(*cm.Cells)[cell2.CellId] = cell2
 
 	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8888))
 	if err != nil {
@@ -166,7 +165,7 @@ func TestSubscribePlayerToSingleCell(t *testing.T) {
 	subscriber := objects.NewPlayer(1, 1)
 	generated.RegisterPlayerServer(playerServer, subscriber)
 	go func() {
-		if err := playerServer.Serve(lis); err != nil && err.Error() != "the server has been stopped" {
+		if err := playerServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			log.Fatalf("failed to serve %v", err)
 		}
 	}()
@@ -189,52 +188,6 @@ func TestSubscribePlayerToSingleCell(t *testing.T) {
 	playerServer.GracefulStop()
 }
 
-func TestSubscribePlayerToMultipleCells(t *testing.T) {
-	cm := objects.NewPlayer(1, 1)
-	cell1 := objects.NewCell("cell1")
-	cell1.PosX = 0
-	cell1.PosY = 0
-	cell1.Height = 100
-	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
-	cell2 := objects.NewCell("cell2")
-	cell2.PosX = 50
-	cell2.PosY = 0
-	cell2.Height = 100
-	cell2.Width = 100
-	(*cm.Cells)[cell2.CellId] = cell2
-
-	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8888))
-	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
-	}
-
-	playerServer := grpc.NewServer()
-	subscriber := objects.NewPlayer(1, 1)
-	generated.RegisterPlayerServer(playerServer, subscriber)
-	go func() {
-		if err := playerServer.Serve(lis); err != nil && err.Error() != "the server has been stopped" {
-			log.Fatalf("failed to serve %v", err)
-		}
-	}()
-
-	res, err := cm.SubscribePlayer(context.Background(), &generated.PlayerInfo{Ip: "localhost", Port: 8888, PosX: 60, PosY: 60})
-
-	if !res.Succeeded {
-		fatalFail(errors.New("succeeded == false but no error"))
-	}
-
-	if _, subscribed := (*cm.SubscribedPlayers)[cell1.CellId]["localhost:8888"]; !subscribed {
-		fatalFail(errors.New("not subscribed to cell1"))
-	}
-
-	if _, subscribed := (*cm.SubscribedPlayers)[cell2.CellId]["localhost:8888"]; !subscribed {
-		fatalFail(errors.New("not subscribed to cell2"))
-	}
-
-	playerServer.GracefulStop()
-}
-
 func TestSubscribePlayerOutsideCells(t *testing.T) {
 	cm := objects.NewPlayer(1, 1)
 	cell1 := objects.NewCell("cell1")
@@ -242,15 +195,10 @@ func TestSubscribePlayerOutsideCells(t *testing.T) {
 	cell1.PosY = 0
 	cell1.Height = 100
 	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
+	cm.Cells = &cell1
 	cell2 := objects.NewCell("cell2")
-	cell2.PosX = 50
-	cell2.PosY = 0
-	cell2.Height = 100
-	cell2.Width = 100
-	(*cm.Cells)[cell2.CellId] = cell2
 
-	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8888))
+	lis, err := net.Listen("tcp", ":"+fmt.Sprint(8887))
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
@@ -259,12 +207,12 @@ func TestSubscribePlayerOutsideCells(t *testing.T) {
 	subscriber := objects.NewPlayer(1, 1)
 	generated.RegisterPlayerServer(playerServer, subscriber)
 	go func() {
-		if err := playerServer.Serve(lis); err != nil && err.Error() != "the server has been stopped" {
+		if err := playerServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			log.Fatalf("failed to serve %v", err)
 		}
 	}()
 
-	res, err := cm.SubscribePlayer(context.Background(), &generated.PlayerInfo{Ip: "localhost", Port: 8888, PosX: 200, PosY: 200})
+	res, err := cm.SubscribePlayer(context.Background(), &generated.PlayerInfo{Ip: "localhost", Port: 8887, PosX: 200, PosY: 200})
 	if err == nil {
 		fatalFail(errors.New("did not receive error on failed subscription"))
 	}
@@ -273,11 +221,11 @@ func TestSubscribePlayerOutsideCells(t *testing.T) {
 		fatalFail(errors.New("succeeded == true but there is an error"))
 	}
 
-	if _, subscribed := (*cm.SubscribedPlayers)[cell1.CellId]["localhost:8888"]; subscribed {
+	if _, subscribed := (*cm.SubscribedPlayers)[cell1.CellId]["localhost:8887"]; subscribed {
 		fatalFail(errors.New("subscribed to cell1"))
 	}
 
-	if _, subscribed := (*cm.SubscribedPlayers)[cell2.CellId]["localhost:8888"]; subscribed {
+	if _, subscribed := (*cm.SubscribedPlayers)[cell2.CellId]["localhost:8887"]; subscribed {
 		fatalFail(errors.New("subscribed to cell2"))
 	}
 
@@ -291,13 +239,8 @@ func TestSubscribeUnConnectablePlayerCells(t *testing.T) {
 	cell1.PosY = 0
 	cell1.Height = 100
 	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
+	cm.Cells = &cell1
 	cell2 := objects.NewCell("cell2")
-	cell2.PosX = 50
-	cell2.PosY = 0
-	cell2.Height = 100
-	cell2.Width = 100
-	(*cm.Cells)[cell2.CellId] = cell2
 
 	res, err := cm.SubscribePlayer(context.Background(), &generated.PlayerInfo{Ip: "localhost", Port: 8888, PosX: 200, PosY: 200})
 	if err == nil {
@@ -324,7 +267,7 @@ func TestPlayerIsInOwnedCell(t *testing.T) {
 	cell1.PosY = 0
 	cell1.Height = 100
 	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
+	cm.Cells = &cell1
 	//cell2 := objects.NewCell("cell2")
 	//cell2.PosX = 50
 	//cell2.PosY = 0
@@ -332,7 +275,7 @@ func TestPlayerIsInOwnedCell(t *testing.T) {
 	//cell2.Width = 100
 	//(*cm.Cells)[cell2.CellId] = cell2
 
-	res := cm.PlayerIsInOwnedCell(cellmanager.Position{PosX: 50, PosY: 50})
+	res := cm.PlayerIsInOwnedCell(&cellmanager.Position{PosX: 50, PosY: 50})
 	if res {
 		return
 	}
@@ -346,7 +289,7 @@ func TestPlayerIsInOwnedCellReturnsFalseIfOutside(t *testing.T) {
 	cell1.PosY = 0
 	cell1.Height = 100
 	cell1.Width = 100
-	(*cm.Cells)[cell1.CellId] = cell1
+	cm.Cells = &cell1
 	//cell2 := objects.NewCell("cell2")
 	//cell2.PosX = 50
 	//cell2.PosY = 0
@@ -354,7 +297,7 @@ func TestPlayerIsInOwnedCellReturnsFalseIfOutside(t *testing.T) {
 	//cell2.Width = 100
 	//(*cm.Cells)[cell2.CellId] = cell2
 
-	res := cm.PlayerIsInOwnedCell(cellmanager.Position{PosX: 150, PosY: 50})
+	res := cm.PlayerIsInOwnedCell(&cellmanager.Position{PosX: 150, PosY: 50})
 	if !res {
 		return
 	}
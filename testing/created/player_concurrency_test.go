@@ -0,0 +1,141 @@
+package created
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Frans-Lukas/checkerboard/pkg/created/cell/objects"
+	"github.com/Frans-Lukas/checkerboard/pkg/generated/cellmanager"
+	generated "github.com/Frans-Lukas/checkerboard/pkg/generated/objects"
+	"google.golang.org/grpc"
+	"sync"
+	"testing"
+)
+
+const reconnectPort = 8950
+
+func createOwnedCell(cm *objects.Player) {
+	cell := objects.NewCell("ownedCell")
+	cell.Width = 100
+	cell.Height = 100
+	cm.Cells = &cell
+}
+
+func TestConcurrentMutationsAreTakenExactlyOnce(t *testing.T) {
+	cm := objects.NewPlayer(1000, 1)
+	createOwnedCell(cm)
+
+	workers := 8
+	mutationsPerWorker := 200
+	taken := make(map[string]int, 0)
+	done := make(chan bool)
+	consumerFinished := make(chan bool)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				for _, object := range cm.TakeMutatingObjects() {
+					taken[object.ObjectId]++
+				}
+				consumerFinished <- true
+				return
+			default:
+				for _, object := range cm.TakeMutatingObjects() {
+					taken[object.ObjectId]++
+				}
+			}
+		}
+	}()
+
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < mutationsPerWorker; i++ {
+				object := &generated.SingleObject{ObjectId: fmt.Sprintf("%d-%d", worker, i), PosX: 50, PosY: 50}
+				_, err := cm.RequestObjectMutation(context.Background(), object)
+				failIfNotNull(err, "RequestObjectMutation failed")
+				cm.RequestMutatingObjects(context.Background(), &generated.Cell{CellId: "ownedCell"})
+			}
+		}(worker)
+	}
+	wg.Wait()
+	done <- true
+	<-consumerFinished
+
+	if len(taken) != workers*mutationsPerWorker {
+		fatalFail(errors.New(fmt.Sprintf("expected %d mutations, got %d", workers*mutationsPerWorker, len(taken))))
+	}
+	for objectId, count := range taken {
+		if count != 1 {
+			fatalFail(errors.New(fmt.Sprintf("mutation %s was taken %d times", objectId, count)))
+		}
+	}
+}
+
+func TestConcurrentSubscriptionsAreAllKept(t *testing.T) {
+	cm := objects.NewPlayer(1000, 1)
+	createOwnedCell(cm)
+
+	workers := 8
+	subscribersPerWorker := 25
+	wg := sync.WaitGroup{}
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < subscribersPerWorker; i++ {
+				port := int32(firstUnusedPort + worker*subscribersPerWorker + i)
+				_, err := cm.SubscribePlayer(context.Background(), &generated.PlayerInfo{Ip: "localhost", Port: port, PosX: 10, PosY: 10})
+				failIfNotNull(err, "SubscribePlayer failed")
+				cm.ShouldSplitCell()
+				cm.PlayerIsInOwnedCell(&cellmanager.Position{PosX: 10, PosY: 10})
+				cm.OwnedCell()
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if len((*cm.SubscribedPlayers)["ownedCell"]) != workers*subscribersPerWorker {
+		fatalFail(errors.New(fmt.Sprintf("expected %d subscribers, got %d", workers*subscribersPerWorker, len((*cm.SubscribedPlayers)["ownedCell"]))))
+	}
+}
+
+func TestReconnectingWhileTheCellMasterChanges(t *testing.T) {
+	_, cellMasterServer := startCellMaster(reconnectPort, claimedCell("cell", 0, 0, 100, 100))
+	defer cellMasterServer.Stop()
+	player := objects.NewPlayer(100, 1)
+	player.Ip, player.Port, player.ObjectId = "localhost", int(firstUnusedPort), "reconnecting"
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			_, err := player.ChangedCellMaster(context.Background(), &generated.ChangedCellMasterRequest{
+				Ip: "localhost", Port: reconnectPort, NewCellId: "cell",
+			})
+			failIfNotNull(err, "could not change cell master")
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			conn, err := grpc.Dial(objects.ToAddress("localhost", reconnectPort), grpc.WithInsecure())
+			failIfNotNull(err, "could not connect to the cell master")
+			cellMaster := generated.NewPlayerClient(conn)
+			player.SetCellMaster(&cellMaster, conn, "cell", 0)
+			if current := player.CurrentCellMaster(); current.CellMaster != nil && current.CellId != "cell" {
+				fatalFail(errors.New("the cell master was changed without its cell"))
+			}
+			player.DropCellMaster(&cellMaster)
+		}
+	}()
+	wg.Wait()
+
+	if current := player.CurrentCellMaster(); current.Connection == nil || current.CellId != "cell" {
+		fatalFail(errors.New("the player was left without a connection to its cell master"))
+	}
+}
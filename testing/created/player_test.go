@@ -68,8 +68,8 @@ func TestSendUpdateMultipleObjects(t *testing.T) {
 		fatalFail(errors.New("objects not added to ObjectsToUpdate"))
 	}
 
-	object1Updated := generated.SingleObject{}
-	object2Updated := generated.SingleObject{}
+	object1Updated := &generated.SingleObject{}
+	object2Updated := &generated.SingleObject{}
 
 	if (*player1.MutatedObjects)[0].ObjectId == "object1" {
 		object1Updated = (*player1.MutatedObjects)[0]
@@ -155,8 +155,8 @@ func TestSendUpdateMultipleTimes(t *testing.T) {
 		fatalFail(errors.New("objects not added to ObjectsToUpdate"))
 	}
 
-	object2Updated := generated.SingleObject{}
-	firstFound := generated.SingleObject{}
+	object2Updated := &generated.SingleObject{}
+	firstFound := &generated.SingleObject{}
 
 	if (*player1.MutatedObjects)[0].ObjectId == "object2" {
 		firstFound = (*player1.MutatedObjects)[0]